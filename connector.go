@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
@@ -122,6 +123,18 @@ type IBConnector interface {
 	UpdateObject(obj IBObject, ref string) (refRes string, err error)
 }
 
+// IBFunctionCaller is implemented by connectors which can call WAPI
+// functions (the _function query parameter) on objects.
+type IBFunctionCaller interface {
+	CallFunction(ref string, function string, args interface{}, res interface{}) error
+}
+
+// IBFileUploader is implemented by connectors which can upload files to the
+// grid, for the WAPI functions taking the token of an uploaded file.
+type IBFileUploader interface {
+	UploadFile(filename string, data []byte) (token string, err error)
+}
+
 type Connector struct {
 	hostCfg        HostConfig
 	authCfg        AuthConfig
@@ -480,6 +493,92 @@ func (c *Connector) UpdateObject(obj IBObject, ref string) (refRes string, err e
 	return
 }
 
+// CallFunction calls the WAPI function of the object with the given reference.
+// The arguments are sent as the JSON body of the request and the response,
+// if any, is unmarshalled into res unless it is nil.
+func (c *Connector) CallFunction(ref string, function string, args interface{}, res interface{}) error {
+	if ref == "" {
+		return fmt.Errorf("empty reference to an object is not allowed")
+	}
+	req, err := c.requestBuilder.BuildRequest(CREATE, nil, ref, nil)
+	if err != nil {
+		return err
+	}
+	qry := req.URL.Query()
+	qry.Set("_function", function)
+	req.URL.RawQuery = qry.Encode()
+
+	body := []byte("{}")
+	if args != nil {
+		if body, err = json.Marshal(args); err != nil {
+			return err
+		}
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	resp, err := c.requestor.SendRequest(req)
+	if err != nil {
+		log.Printf("failed to call function %s of %s: %s", function, ref, err)
+		return err
+	}
+	if res == nil || len(resp) == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(resp, res); err != nil {
+		log.Printf("cannot unmarshall function call response '%s', err: '%s'\n", string(resp), err)
+		return err
+	}
+	return nil
+}
+
+// UploadFile uploads the file to the grid and returns the token which
+// refers to it in the fileop function calls.
+func (c *Connector) UploadFile(filename string, data []byte) (string, error) {
+	var dest struct {
+		Token string `json:"token"`
+		Url   string `json:"url"`
+	}
+	err := c.CallFunction("fileop", "uploadinit", map[string]string{"filename": filename}, &dest)
+	if err != nil {
+		return "", err
+	}
+	if dest.Token == "" || dest.Url == "" {
+		return "", fmt.Errorf("no upload URL returned for the file '%s'", filename)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return "", err
+	}
+	if _, err = part.Write(data); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+	// the request is built as any other one, so that it is authenticated the
+	// same way, then sent to the upload URL
+	req, err := c.requestBuilder.BuildRequest(CREATE, nil, "fileop", nil)
+	if err != nil {
+		return "", err
+	}
+	if req.URL, err = url.Parse(dest.Url); err != nil {
+		return "", err
+	}
+	req.Host = req.URL.Host
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Body = ioutil.NopCloser(body)
+	req.ContentLength = int64(body.Len())
+	if _, err = c.requestor.SendRequest(req); err != nil {
+		log.Printf("failed to upload the file %s: %s", filename, err)
+		return "", err
+	}
+	return dest.Token, nil
+}
+
 // Logout sends a request to invalidate the ibapauth cookie and should
 // be used in a defer statement after the Connector has been successfully
 // initialized.
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -69,6 +70,25 @@ func (hr *FakeHttpRequestor) SendRequest(req *http.Request) ([]byte, error) {
 	return hr.res, nil
 }
 
+// fakeSequenceRequestor records the requests and answers them in turn,
+// failing those with an error at the same position.
+type fakeSequenceRequestor struct {
+	reqs []*http.Request
+	res  [][]byte
+	errs []error
+}
+
+func (hr *fakeSequenceRequestor) Init(authCfg AuthConfig, trCfg TransportConfig) {}
+
+func (hr *fakeSequenceRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	hr.reqs = append(hr.reqs, req)
+	i := len(hr.reqs) - 1
+	if i < len(hr.errs) && hr.errs[i] != nil {
+		return nil, hr.errs[i]
+	}
+	return hr.res[i], nil
+}
+
 func MockValidateConnector(c *Connector) (err error) {
 	return
 }
@@ -479,6 +499,100 @@ var _ = Describe("Connector", func() {
 				Expect(actual).To(Equal(expectObj))
 			})
 		})
+
+		Describe("CallFunction", func() {
+			ref := "zone_auth/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxl:example.com/default"
+
+			requestType := CREATE
+			urlStr := fmt.Sprintf("https://%s:%s/wapi/v%s/%s",
+				host, port, version, ref)
+			httpReq, _ := http.NewRequest(requestType.toMethod(), urlStr, bytes.NewBuffer([]byte{}))
+			frb := &FakeRequestBuilder{
+				r:   requestType,
+				obj: nil,
+				ref: ref,
+
+				urlStr: urlStr,
+				req:    httpReq,
+			}
+
+			fhr := &FakeHttpRequestor{
+				trCfg: transportConfig,
+
+				req: httpReq,
+				res: []byte(`{"result":"done"}`),
+			}
+
+			OrigValidateConnector := ValidateConnector
+			ValidateConnector = MockValidateConnector
+			defer func() { ValidateConnector = OrigValidateConnector }()
+
+			conn, err := NewConnector(hostCfg, authCfg, transportConfig, frb, fhr)
+
+			if err != nil {
+				Fail("Error creating Connector")
+			}
+			It("should send the arguments to the function and return its result", func() {
+				var res map[string]string
+				err := conn.CallFunction(ref, "dnssec_operation", map[string]string{"operation": "SIGN"}, &res)
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(map[string]string{"result": "done"}))
+				Expect(httpReq.URL.RawQuery).To(Equal("_function=dnssec_operation"))
+				body, _ := ioutil.ReadAll(httpReq.Body)
+				Expect(string(body)).To(Equal(`{"operation":"SIGN"}`))
+			})
+		})
+		Describe("UploadFile", func() {
+			uploadUrl := fmt.Sprintf("https://%s/http_direct_file_io/req_id-UPLOAD-0001/dtc.pem", host)
+			newUploadConnector := func(authCfg AuthConfig) (*Connector, *fakeSequenceRequestor) {
+				wrb, _ := NewWapiRequestBuilder(hostCfg, authCfg)
+				fhr := &fakeSequenceRequestor{res: [][]byte{
+					[]byte(`{"token":"upload-token","url":"` + uploadUrl + `"}`),
+					nil,
+				}}
+
+				OrigValidateConnector := ValidateConnector
+				ValidateConnector = MockValidateConnector
+				defer func() { ValidateConnector = OrigValidateConnector }()
+
+				conn, err := NewConnector(hostCfg, authCfg, transportConfig, wrb, fhr)
+				if err != nil {
+					Fail("Error creating Connector")
+				}
+				return conn, fhr
+			}
+
+			It("should initialise the upload, then send the file to the URL returned", func() {
+				conn, fhr := newUploadConnector(authCfg)
+				token, err := conn.UploadFile("dtc.pem", []byte("-----BEGIN CERTIFICATE-----"))
+				Expect(err).To(BeNil())
+				Expect(token).To(Equal("upload-token"))
+				Expect(fhr.reqs).To(HaveLen(2))
+				Expect(fhr.reqs[0].URL.RawQuery).To(Equal("_function=uploadinit"))
+				body, _ := ioutil.ReadAll(fhr.reqs[0].Body)
+				Expect(string(body)).To(Equal(`{"filename":"dtc.pem"}`))
+
+				upload := fhr.reqs[1]
+				Expect(upload.URL.String()).To(Equal(uploadUrl))
+				Expect(upload.Host).To(Equal(host))
+				username, password, ok := upload.BasicAuth()
+				Expect(ok).To(BeTrue())
+				Expect([]string{username, password}).To(Equal([]string{authCfg.Username, authCfg.Password}))
+				file, header, err := upload.FormFile("file")
+				Expect(err).To(BeNil())
+				Expect(header.Filename).To(Equal("dtc.pem"))
+				content, _ := ioutil.ReadAll(file)
+				Expect(string(content)).To(Equal("-----BEGIN CERTIFICATE-----"))
+			})
+
+			It("should not use basic authentication with a client certificate", func() {
+				conn, fhr := newUploadConnector(AuthConfig{ClientCert: []byte("cert"), ClientKey: []byte("key")})
+				_, err := conn.UploadFile("dtc.pem", []byte("-----BEGIN CERTIFICATE-----"))
+				Expect(err).To(BeNil())
+				_, _, ok := fhr.reqs[1].BasicAuth()
+				Expect(ok).To(BeFalse())
+			})
+		})
 		Describe("makeRequest", func() {
 			Context("for GET request", func() {
 				netviewName := "private-view"
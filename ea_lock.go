@@ -0,0 +1,681 @@
+package ibclient
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Compile-time interface checks
+var _ Lock = new(EALock)
+
+const (
+	defaultLockTTL           = time.Duration(timeout) * time.Second
+	defaultLockRetryAttempts = 10
+	defaultLockRetryMinDelay = 1 * time.Second
+	defaultLockRetryMaxDelay = 10 * time.Second
+)
+
+// EALockConfig holds the tunables of an EALock. Zero values are replaced by
+// defaults matching the behaviour of NetworkViewLock.
+type EALockConfig struct {
+	// TTL is the lease length. A lock which was neither released nor renewed
+	// within TTL is considered stale and may be taken over by another holder.
+	TTL time.Duration
+
+	// RetryAttempts is the number of retries after the first failed attempt.
+	RetryAttempts int
+
+	// RetryMinDelay and RetryMaxDelay bound the exponential backoff between attempts.
+	RetryMinDelay time.Duration
+	RetryMaxDelay time.Duration
+
+	// RenewInterval enables lease renewal (heartbeat) while the lock is held.
+	// It should be well below TTL. Zero disables renewal.
+	RenewInterval time.Duration
+}
+
+func (cfg EALockConfig) withDefaults() EALockConfig {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultLockTTL
+	}
+	if cfg.RetryAttempts < 0 {
+		cfg.RetryAttempts = 0
+	} else if cfg.RetryAttempts == 0 {
+		cfg.RetryAttempts = defaultLockRetryAttempts
+	}
+	if cfg.RetryMinDelay <= 0 {
+		cfg.RetryMinDelay = defaultLockRetryMinDelay
+	}
+	if cfg.RetryMaxDelay < cfg.RetryMinDelay {
+		cfg.RetryMaxDelay = defaultLockRetryMaxDelay
+		if cfg.RetryMaxDelay < cfg.RetryMinDelay {
+			cfg.RetryMaxDelay = cfg.RetryMinDelay
+		}
+	}
+	return cfg
+}
+
+// backoff returns the delay before the given retry (starting from 1):
+// exponentially growing, capped by RetryMaxDelay, with jitter in its upper half.
+func (cfg EALockConfig) backoff(retry int) time.Duration {
+	delay := cfg.RetryMinDelay
+	for i := 1; i < retry && delay < cfg.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > cfg.RetryMaxDelay {
+		delay = cfg.RetryMaxDelay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// EALockMetrics reports lock contention statistics of an EALock.
+type EALockMetrics struct {
+	Attempts        uint64        // acquisition attempts
+	Acquired        uint64        // successful acquisitions
+	Contended       uint64        // attempts which found the lock held by someone else
+	Takeovers       uint64        // stale locks taken over after their TTL expired
+	Renewals        uint64        // successful lease renewals
+	RenewalFailures uint64        // failed lease renewals
+	WaitTime        time.Duration // total time spent waiting for the lock
+}
+
+// EALock is a distributed lock kept in extensible attributes of any
+// NIOS object which supports them (network view, network container, zone, etc.).
+//
+// LockEA holds the tenant ID of the holder or 'Available', LockTimeoutEA
+// the time of acquisition or last renewal and LockTokenEA a fencing token
+// which is incremented on every acquisition. LockTimeoutEA and LockTokenEA
+// must be defined as INTEGER extensible attributes. LockTokenEA may be
+// empty, in which case no fencing token is kept and the token is always 0.
+type EALock struct {
+	ObjMgr        *ObjectManager
+	ObjectType    string
+	SearchFields  map[string]string
+	LockEA        string
+	LockTimeoutEA string
+	LockTokenEA   string
+	Config        EALockConfig
+
+	mu          sync.Mutex
+	token       int64
+	held        bool
+	lastRenewal time.Time
+	stopRenew   chan struct{}
+	renewDone   chan struct{}
+	lost        chan struct{}
+	metrics     EALockMetrics
+}
+
+// NewEALock returns a lock on the object of the given type which matches the search fields.
+func NewEALock(
+	objMgr *ObjectManager,
+	objectType string,
+	searchFields map[string]string,
+	lockEA string,
+	lockTimeoutEA string,
+	lockTokenEA string,
+	cfg EALockConfig) *EALock {
+
+	return &EALock{
+		ObjMgr:        objMgr,
+		ObjectType:    objectType,
+		SearchFields:  searchFields,
+		LockEA:        lockEA,
+		LockTimeoutEA: lockTimeoutEA,
+		LockTokenEA:   lockTokenEA,
+		Config:        cfg,
+	}
+}
+
+// NewNetworkViewEALock returns an EALock on the network view with the given name.
+func NewNetworkViewEALock(objMgr *ObjectManager, name string, lockEA string, lockTimeoutEA string, lockTokenEA string, cfg EALockConfig) *EALock {
+	return NewEALock(objMgr, "networkview", map[string]string{"name": name}, lockEA, lockTimeoutEA, lockTokenEA, cfg)
+}
+
+func (l *EALock) String() string {
+	return fmt.Sprintf("%s %v", l.ObjectType, l.SearchFields)
+}
+
+func (l *EALock) getObject() (*eaObject, error) {
+	var res []eaObject
+	err := l.ObjMgr.connector.GetObject(
+		newEAObject(l.ObjectType), "", NewQueryParams(false, l.SearchFields), &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, NewNotFoundError(fmt.Sprintf("object to lock (%s) not found", l))
+	}
+	if len(res) > 1 {
+		return nil, fmt.Errorf("search fields of the lock match %d objects of type %s, a single object is required", len(res), l.ObjectType)
+	}
+	return &res[0], nil
+}
+
+// missingEAs returns the lock EAs, in released state, which the object lacks.
+func (l *EALock) missingEAs(obj *eaObject) EA {
+	missing := make(EA)
+	if _, ok := obj.Ea[l.LockEA]; !ok {
+		missing[l.LockEA] = freeLockVal
+	}
+	if l.LockTokenEA != "" {
+		if _, ok := obj.Ea[l.LockTokenEA]; !ok {
+			missing[l.LockTokenEA] = 0
+		}
+	}
+	return missing
+}
+
+// createEnsureRequest builds a request which adds the missing lock EAs only
+// if the object still lacks them and its other lock EAs are unchanged, so
+// that a lock acquired in the meantime is not overwritten.
+func (l *EALock) createEnsureRequest(obj *eaObject, missing EA) *MultiRequest {
+	getData := make(map[string]interface{})
+	for k, v := range l.SearchFields {
+		getData[k] = v
+	}
+	eaAdd := make(map[string]interface{})
+	for _, ea := range []string{l.LockEA, l.LockTokenEA} {
+		if ea == "" {
+			continue
+		}
+		if v, ok := missing[ea]; ok {
+			getData["*"+ea+"!~"] = "."
+			eaAdd[ea] = map[string]interface{}{"value": v}
+		} else {
+			getData["*"+ea] = obj.Ea[ea]
+		}
+	}
+
+	return NewMultiRequest(
+		[]*RequestBody{
+			&RequestBody{
+				Method: "GET",
+				Object: l.ObjectType,
+				Data:   getData,
+				Args: map[string]string{
+					"_return_fields": "extattrs",
+				},
+				AssignState: map[string]string{
+					"LOCK_OBJ_REF": "_ref",
+				},
+				Discard: true,
+			},
+			&RequestBody{
+				Method: "PUT",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Data: map[string]interface{}{
+					"extattrs+": eaAdd,
+				},
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+		},
+	)
+}
+
+// ensureEAs adds the lock EAs, in released state, to the object if they
+// are missing. The object is read again afterwards, so that the lock EAs
+// added by someone else in the meantime are taken into account.
+func (l *EALock) ensureEAs(obj *eaObject) (*eaObject, error) {
+	missing := l.missingEAs(obj)
+	if len(missing) == 0 {
+		return obj, nil
+	}
+	_, err := l.ObjMgr.CreateMultiObject(l.createEnsureRequest(obj, missing))
+	if err != nil {
+		logrus.Debugf("Failed to add lock EAs to %s: %s\n", l, err)
+	}
+	cur, getErr := l.getObject()
+	if getErr != nil {
+		return nil, getErr
+	}
+	if len(l.missingEAs(cur)) > 0 {
+		if err == nil {
+			err = fmt.Errorf("they are still missing")
+		}
+		return nil, fmt.Errorf("failed to add lock EAs to %s: %s", l, err)
+	}
+	return cur, nil
+}
+
+// eaInt returns the integer value of the given EA, or 0 if it is not set.
+func eaInt(ea EA, name string) (int64, error) {
+	val, ok := ea[name]
+	if !ok || val == nil {
+		return 0, nil
+	}
+	return toInt64(val)
+}
+
+func toInt64(val interface{}) (int64, error) {
+	switch v := val.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	}
+	return 0, fmt.Errorf("value '%v' is not an integer", val)
+}
+
+// createAcquireRequest builds a request which sets the lock EAs only if
+// the object still carries the expected lock EA values.
+func (l *EALock) createAcquireRequest(expected map[string]interface{}, token int64) *MultiRequest {
+	getData := make(map[string]interface{})
+	for k, v := range l.SearchFields {
+		getData[k] = v
+	}
+	for k, v := range expected {
+		getData["*"+k] = v
+	}
+
+	eaAdd := map[string]interface{}{
+		l.LockEA: map[string]string{
+			"value": l.ObjMgr.tenantID,
+		},
+		l.LockTimeoutEA: map[string]int64{
+			"value": time.Now().Unix(),
+		},
+	}
+	assignState := map[string]string{
+		"LOCK_HOLDER": "*" + l.LockEA,
+	}
+	if l.LockTokenEA != "" {
+		eaAdd[l.LockTokenEA] = map[string]int64{
+			"value": token,
+		}
+		assignState["LOCK_TOKEN"] = "*" + l.LockTokenEA
+	}
+
+	return NewMultiRequest(
+		[]*RequestBody{
+			&RequestBody{
+				Method: "GET",
+				Object: l.ObjectType,
+				Data:   getData,
+				Args: map[string]string{
+					"_return_fields": "extattrs",
+				},
+				AssignState: map[string]string{
+					"LOCK_OBJ_REF": "_ref",
+				},
+				Discard: true,
+			},
+			&RequestBody{
+				Method: "PUT",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Data: map[string]interface{}{
+					"extattrs+": eaAdd,
+				},
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+			&RequestBody{
+				Method: "GET",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Args: map[string]string{
+					"_return_fields": "extattrs",
+				},
+				AssignState:        assignState,
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+			&RequestBody{
+				Method: "STATE:DISPLAY",
+			},
+		},
+	)
+}
+
+func (l *EALock) createRenewRequest(token int64) *MultiRequest {
+	getData := map[string]interface{}{
+		"*" + l.LockEA: l.ObjMgr.tenantID,
+	}
+	if l.LockTokenEA != "" {
+		getData["*"+l.LockTokenEA] = token
+	}
+	for k, v := range l.SearchFields {
+		getData[k] = v
+	}
+
+	return NewMultiRequest(
+		[]*RequestBody{
+			&RequestBody{
+				Method: "GET",
+				Object: l.ObjectType,
+				Data:   getData,
+				Args: map[string]string{
+					"_return_fields": "extattrs",
+				},
+				AssignState: map[string]string{
+					"LOCK_OBJ_REF": "_ref",
+				},
+				Discard: true,
+			},
+			&RequestBody{
+				Method: "PUT",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Data: map[string]interface{}{
+					"extattrs+": map[string]interface{}{
+						l.LockTimeoutEA: map[string]int64{
+							"value": time.Now().Unix(),
+						},
+					},
+				},
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+			&RequestBody{
+				Method: "STATE:DISPLAY",
+			},
+		},
+	)
+}
+
+func (l *EALock) createReleaseRequest(token int64, force bool) *MultiRequest {
+	getData := make(map[string]interface{})
+	for k, v := range l.SearchFields {
+		getData[k] = v
+	}
+	if !force {
+		getData["*"+l.LockEA] = l.ObjMgr.tenantID
+		if l.LockTokenEA != "" {
+			getData["*"+l.LockTokenEA] = token
+		}
+	}
+
+	return NewMultiRequest(
+		[]*RequestBody{
+			&RequestBody{
+				Method: "GET",
+				Object: l.ObjectType,
+				Data:   getData,
+				Args: map[string]string{
+					"_return_fields": "extattrs",
+				},
+				AssignState: map[string]string{
+					"LOCK_OBJ_REF": "_ref",
+				},
+				Discard: true,
+			},
+			&RequestBody{
+				Method: "PUT",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Data: map[string]interface{}{
+					"extattrs+": map[string]interface{}{
+						l.LockEA: map[string]string{
+							"value": freeLockVal,
+						},
+					},
+				},
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+			&RequestBody{
+				Method: "PUT",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Data: map[string]interface{}{
+					"extattrs-": map[string]interface{}{
+						l.LockTimeoutEA: map[string]interface{}{},
+					},
+				},
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+			&RequestBody{
+				Method: "GET",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Args: map[string]string{
+					"_return_fields": "extattrs",
+				},
+				AssignState: map[string]string{
+					"LOCK_HOLDER": "*" + l.LockEA,
+				},
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+			&RequestBody{
+				Method: "STATE:DISPLAY",
+			},
+		},
+	)
+}
+
+// tryAcquire makes a single attempt to get the lock. It returns false
+// without an error if the lock is held by someone else.
+func (l *EALock) tryAcquire(cfg EALockConfig) (bool, int64, error) {
+	obj, err := l.getObject()
+	if err != nil {
+		return false, 0, err
+	}
+	if obj, err = l.ensureEAs(obj); err != nil {
+		return false, 0, err
+	}
+	expected := make(map[string]interface{})
+	var token int64
+	if l.LockTokenEA != "" {
+		prevToken, err := eaInt(obj.Ea, l.LockTokenEA)
+		if err != nil {
+			return false, 0, fmt.Errorf("invalid fencing token on %s: %s", l, err)
+		}
+		expected[l.LockTokenEA] = prevToken
+		token = prevToken + 1
+	}
+	takeover := false
+	if holder := obj.Ea[l.LockEA]; holder != freeLockVal {
+		lockTime, err := eaInt(obj.Ea, l.LockTimeoutEA)
+		if err != nil {
+			return false, 0, fmt.Errorf("invalid lock time on %s: %s", l, err)
+		}
+		if time.Since(time.Unix(lockTime, 0)) <= cfg.TTL {
+			l.addMetrics(func(m *EALockMetrics) { m.Contended++ })
+			return false, 0, nil
+		}
+		logrus.Debugf("Lock on %s held by %v is timed out, taking it over\n", l, holder)
+		expected[l.LockEA] = holder
+		expected[l.LockTimeoutEA] = lockTime
+		takeover = true
+	} else {
+		expected[l.LockEA] = freeLockVal
+	}
+
+	res, err := l.ObjMgr.CreateMultiObject(l.createAcquireRequest(expected, token))
+	if err != nil {
+		// someone else changed the lock EAs between the read and the request
+		logrus.Debugf("Failed to create lock on %s: %s\n", l, err)
+		l.addMetrics(func(m *EALockMetrics) { m.Contended++ })
+		return false, 0, nil
+	}
+	if len(res) == 0 || res[0]["LOCK_HOLDER"] != l.ObjMgr.tenantID {
+		l.addMetrics(func(m *EALockMetrics) { m.Contended++ })
+		return false, 0, nil
+	}
+	if l.LockTokenEA != "" {
+		if gotToken, err := toInt64(res[0]["LOCK_TOKEN"]); err != nil || gotToken != token {
+			l.addMetrics(func(m *EALockMetrics) { m.Contended++ })
+			return false, 0, nil
+		}
+	}
+	if takeover {
+		l.addMetrics(func(m *EALockMetrics) { m.Takeovers++ })
+	}
+
+	return true, token, nil
+}
+
+// Lock acquires the lock, retrying with backoff while it is held by someone else.
+func (l *EALock) Lock() error {
+	_, err := l.LockWithContext(context.Background())
+	return err
+}
+
+// LockWithContext acquires the lock and returns its fencing token. The
+// token grows with every acquisition, so it can be passed to other systems
+// to reject writes from a holder whose lease has expired.
+func (l *EALock) LockWithContext(ctx context.Context) (int64, error) {
+	cfg := l.Config.withDefaults()
+	start := time.Now()
+	defer func() {
+		l.addMetrics(func(m *EALockMetrics) { m.WaitTime += time.Since(start) })
+	}()
+
+	for retry := 0; ; retry++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		l.addMetrics(func(m *EALockMetrics) { m.Attempts++ })
+		ok, token, err := l.tryAcquire(cfg)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			logrus.Debugf("Got the lock on %s with token %d\n", l, token)
+			l.addMetrics(func(m *EALockMetrics) { m.Acquired++ })
+			l.startHolding(token, cfg)
+			return token, nil
+		}
+
+		if retry >= cfg.RetryAttempts {
+			return 0, fmt.Errorf("failed to get lock on %s", l)
+		}
+		logrus.Debugf("Lock on %s not free. Retrying again %d out of %d.\n", l, retry+1, cfg.RetryAttempts)
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(cfg.backoff(retry + 1)):
+		}
+	}
+}
+
+func (l *EALock) startHolding(token int64, cfg EALockConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.token = token
+	l.held = true
+	l.lastRenewal = time.Now()
+	l.lost = make(chan struct{})
+	if cfg.RenewInterval > 0 {
+		l.stopRenew = make(chan struct{})
+		l.renewDone = make(chan struct{})
+		go l.renewLoop(cfg, l.stopRenew, l.renewDone, l.lost)
+	}
+}
+
+func (l *EALock) renewLoop(cfg EALockConfig, stop <-chan struct{}, done chan<- struct{}, lost chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(cfg.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := l.Renew(); err != nil {
+				logrus.Errorf("Failed to renew lock on %s: %s\n", l, err)
+				l.mu.Lock()
+				expired := time.Since(l.lastRenewal) > cfg.TTL
+				l.mu.Unlock()
+				if expired {
+					close(lost)
+					return
+				}
+			}
+		}
+	}
+}
+
+// Renew extends the lease of the held lock. It is called periodically
+// when RenewInterval is set, but may also be called explicitly.
+func (l *EALock) Renew() error {
+	l.mu.Lock()
+	held, token := l.held, l.token
+	l.mu.Unlock()
+	if !held {
+		return fmt.Errorf("lock on %s is not held", l)
+	}
+
+	if _, err := l.ObjMgr.CreateMultiObject(l.createRenewRequest(token)); err != nil {
+		l.addMetrics(func(m *EALockMetrics) { m.RenewalFailures++ })
+		return err
+	}
+
+	l.mu.Lock()
+	l.lastRenewal = time.Now()
+	l.mu.Unlock()
+	l.addMetrics(func(m *EALockMetrics) { m.Renewals++ })
+	return nil
+}
+
+// UnLock releases the lock. With force set the lock is released
+// regardless of its holder.
+func (l *EALock) UnLock(force bool) error {
+	l.mu.Lock()
+	token := l.token
+	stop, done := l.stopRenew, l.renewDone
+	l.stopRenew, l.renewDone = nil, nil
+	l.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+
+	res, err := l.ObjMgr.CreateMultiObject(l.createReleaseRequest(token, force))
+	if err != nil {
+		logrus.Errorf("Failed to release lock from %s: %s\n", l, err)
+		return fmt.Errorf("failed to release lock from %s: %s", l, err)
+	}
+	if len(res) == 0 || res[0]["LOCK_HOLDER"] != freeLockVal {
+		logrus.Errorf("Failed to release lock from %s\n", l)
+		return fmt.Errorf("failed to release lock from %s", l)
+	}
+
+	l.mu.Lock()
+	l.held = false
+	l.mu.Unlock()
+	logrus.Debugln("Removed the lock!")
+	return nil
+}
+
+// Token returns the fencing token of the last acquisition.
+func (l *EALock) Token() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.token
+}
+
+// Lost returns a channel which is closed when the lease could not be
+// renewed within TTL, i.e. the lock may have been taken over. It returns
+// nil if the lock was never acquired.
+func (l *EALock) Lost() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lost
+}
+
+// Metrics returns a snapshot of the contention statistics of the lock.
+func (l *EALock) Metrics() EALockMetrics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.metrics
+}
+
+func (l *EALock) addMetrics(update func(m *EALockMetrics)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	update(&l.metrics)
+}
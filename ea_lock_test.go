@@ -0,0 +1,115 @@
+package ibclient
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EA Lock", func() {
+	Describe("Config defaults", func() {
+		It("should default to the network view lock behaviour", func() {
+			cfg := EALockConfig{}.withDefaults()
+			Expect(cfg.TTL).To(Equal(60 * time.Second))
+			Expect(cfg.RetryAttempts).To(Equal(10))
+			Expect(cfg.RetryMinDelay).To(Equal(1 * time.Second))
+			Expect(cfg.RetryMaxDelay).To(Equal(10 * time.Second))
+			Expect(cfg.RenewInterval).To(BeZero())
+		})
+
+		It("should keep explicitly given values", func() {
+			cfg := EALockConfig{TTL: 5 * time.Minute, RetryAttempts: -1, RetryMinDelay: 20 * time.Second}.withDefaults()
+			Expect(cfg.TTL).To(Equal(5 * time.Minute))
+			Expect(cfg.RetryAttempts).To(Equal(0))
+			Expect(cfg.RetryMaxDelay).To(Equal(20 * time.Second))
+		})
+	})
+
+	Describe("Backoff", func() {
+		cfg := EALockConfig{RetryMinDelay: 100 * time.Millisecond, RetryMaxDelay: time.Second}.withDefaults()
+
+		It("should grow exponentially up to the maximum delay", func() {
+			Expect(cfg.backoff(1)).To(And(
+				BeNumerically(">=", 50*time.Millisecond), BeNumerically("<=", 100*time.Millisecond)))
+			Expect(cfg.backoff(3)).To(And(
+				BeNumerically(">=", 200*time.Millisecond), BeNumerically("<=", 400*time.Millisecond)))
+			Expect(cfg.backoff(10)).To(And(
+				BeNumerically(">=", 500*time.Millisecond), BeNumerically("<=", time.Second)))
+		})
+	})
+
+	Describe("Acquire request", func() {
+		objMgr := &ObjectManager{tenantID: "tenant-1"}
+		lock := NewEALock(objMgr, "networkcontainer", map[string]string{"network": "10.0.0.0/8"},
+			"Lock", "LockTime", "LockToken", EALockConfig{})
+
+		It("should guard the update on the expected lock EA values", func() {
+			req := lock.createAcquireRequest(map[string]interface{}{"Lock": freeLockVal, "LockToken": int64(4)}, 5)
+			Expect(req.Body).To(HaveLen(4))
+			Expect(req.Body[0].Object).To(Equal("networkcontainer"))
+			Expect(req.Body[0].Data).To(Equal(map[string]interface{}{
+				"network":    "10.0.0.0/8",
+				"*Lock":      freeLockVal,
+				"*LockToken": int64(4),
+			}))
+			eas := req.Body[1].Data["extattrs+"].(map[string]interface{})
+			Expect(eas["Lock"]).To(Equal(map[string]string{"value": "tenant-1"}))
+			Expect(eas["LockToken"]).To(Equal(map[string]int64{"value": 5}))
+		})
+	})
+
+	Describe("Ensure request", func() {
+		objMgr := &ObjectManager{tenantID: "tenant-1"}
+		lock := NewEALock(objMgr, "networkcontainer", map[string]string{"network": "10.0.0.0/8"},
+			"Lock", "LockTime", "LockToken", EALockConfig{})
+
+		It("should add the missing EAs only if they are still missing", func() {
+			obj := &eaObject{Ref: "networkcontainer/ZG5zLm5ldHdvcmskMTAuMC4wLjAvOC8w:10.0.0.0/8/default", Ea: EA{"Lock": freeLockVal}}
+			missing := lock.missingEAs(obj)
+			Expect(missing).To(Equal(EA{"LockToken": 0}))
+			req := lock.createEnsureRequest(obj, missing)
+			Expect(req.Body).To(HaveLen(2))
+			Expect(req.Body[0].Data).To(Equal(map[string]interface{}{
+				"network":      "10.0.0.0/8",
+				"*Lock":        freeLockVal,
+				"*LockToken!~": ".",
+			}))
+			Expect(req.Body[1].Object).To(Equal("##STATE:LOCK_OBJ_REF:##"))
+			Expect(req.Body[1].Data).To(Equal(map[string]interface{}{
+				"extattrs+": map[string]interface{}{"LockToken": map[string]interface{}{"value": 0}},
+			}))
+		})
+	})
+
+	Describe("Network view lock", func() {
+		objMgr := &ObjectManager{tenantID: "tenant-1"}
+		nvLock := &NetworkViewLock{Name: "default", ObjMgr: objMgr, LockEA: "Lock", LockTimeoutEA: "LockTime"}
+
+		It("should be an EA lock without fencing token", func() {
+			lock := nvLock.eaLock()
+			Expect(lock.ObjectType).To(Equal("networkview"))
+			Expect(lock.SearchFields).To(Equal(map[string]string{"name": "default"}))
+			Expect(lock.LockTokenEA).To(BeEmpty())
+			Expect(nvLock.eaLock()).To(BeIdenticalTo(lock))
+
+			req := lock.createAcquireRequest(map[string]interface{}{"Lock": freeLockVal}, 0)
+			eas := req.Body[1].Data["extattrs+"].(map[string]interface{})
+			Expect(eas).To(HaveLen(2))
+			Expect(eas).To(HaveKey("LockTime"))
+			Expect(req.Body[2].AssignState).To(Equal(map[string]string{"LOCK_HOLDER": "*Lock"}))
+		})
+	})
+
+	Describe("Integer EA values", func() {
+		It("should accept the representations returned by WAPI", func() {
+			for _, v := range []interface{}{7, int64(7), float64(7), "7"} {
+				i, err := toInt64(v)
+				Expect(err).To(BeNil())
+				Expect(i).To(Equal(int64(7)))
+			}
+			_, err := toInt64(Bool(true))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
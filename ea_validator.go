@@ -0,0 +1,326 @@
+package ibclient
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EAValidationError describes why the value of a single extensible attribute was rejected.
+type EAValidationError struct {
+	Attribute string
+	Reason    string
+}
+
+func (e *EAValidationError) Error() string {
+	return fmt.Sprintf("extensible attribute '%s': %s", e.Attribute, e.Reason)
+}
+
+// EAValidationErrors is the list of all the problems found in an EA map.
+type EAValidationErrors []*EAValidationError
+
+func (errs EAValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// EAValidator checks extensible attributes against the EA definitions of
+// the grid before they are sent to WAPI. The definitions are loaded on
+// first use and cached; with a zero CacheTTL they are never reloaded
+// unless Refresh is called.
+type EAValidator struct {
+	ObjMgr   IBObjectManager
+	CacheTTL time.Duration
+
+	mu       sync.Mutex
+	defs     map[string]EADefinition
+	loadedAt time.Time
+}
+
+func NewEAValidator(objMgr IBObjectManager, cacheTTL time.Duration) *EAValidator {
+	return &EAValidator{ObjMgr: objMgr, CacheTTL: cacheTTL}
+}
+
+// Refresh reloads the EA definitions from the grid.
+func (v *EAValidator) Refresh() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.load()
+}
+
+func (v *EAValidator) load() error {
+	res, err := v.ObjMgr.GetAllEADefinitions(nil)
+	if err != nil {
+		return fmt.Errorf("failed to load extensible attribute definitions: %s", err)
+	}
+	defs := make(map[string]EADefinition, len(res))
+	for _, d := range res {
+		if d.Name != nil {
+			defs[*d.Name] = d
+		}
+	}
+	v.defs = defs
+	v.loadedAt = time.Now()
+	return nil
+}
+
+func (v *EAValidator) definitions() (map[string]EADefinition, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.defs == nil || (v.CacheTTL > 0 && time.Since(v.loadedAt) > v.CacheTTL) {
+		if err := v.load(); err != nil {
+			return nil, err
+		}
+	}
+	return v.defs, nil
+}
+
+// Validate checks the EAs to be set on an object of the given WAPI type.
+// Mandatory EAs are only required when checkMandatory is set, which is
+// the case for object creation, and only for definitions which list the
+// object type in their allowed object types. All the problems found are
+// returned at once as EAValidationErrors.
+func (v *EAValidator) Validate(objectType string, ea EA, checkMandatory bool) error {
+	defs, err := v.definitions()
+	if err != nil {
+		return err
+	}
+
+	var errs EAValidationErrors
+	for name, value := range ea {
+		def, ok := defs[name]
+		if !ok {
+			errs = append(errs, &EAValidationError{name, "no such extensible attribute definition"})
+			continue
+		}
+		if reason := checkEAValue(objectType, def, value); reason != "" {
+			errs = append(errs, &EAValidationError{name, reason})
+		}
+	}
+	if checkMandatory {
+		for name, def := range defs {
+			if _, ok := ea[name]; ok {
+				continue
+			}
+			if eaDefFlags(def).Mandatory && eaAllowedExplicitly(def, objectType) {
+				errs = append(errs, &EAValidationError{name, fmt.Sprintf("value is mandatory for '%s' objects", objectType)})
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func eaDefFlags(def EADefinition) EADefFlags {
+	if def.Flags == nil {
+		return EADefFlags{}
+	}
+	f, _ := ParseEADefFlags(*def.Flags)
+	return f
+}
+
+// normalizeEAObjectType maps both WAPI object types ('record:a') and the
+// object type names used in EA definitions ('ARecord') to a common form.
+func normalizeEAObjectType(objectType string) string {
+	t := strings.ToLower(objectType)
+	if strings.HasPrefix(t, "record:") {
+		t = strings.TrimPrefix(t, "record:") + "record"
+	}
+	return strings.NewReplacer("_", "", ":", "").Replace(t)
+}
+
+func eaAllowedExplicitly(def EADefinition, objectType string) bool {
+	nt := normalizeEAObjectType(objectType)
+	for _, t := range def.AllowedObjectTypes {
+		if normalizeEAObjectType(t) == nt {
+			return true
+		}
+	}
+	return false
+}
+
+// checkEAValue returns the reason why value is not valid for the definition,
+// or an empty string if it is.
+func checkEAValue(objectType string, def EADefinition, value interface{}) string {
+	if len(def.AllowedObjectTypes) > 0 && !eaAllowedExplicitly(def, objectType) {
+		return fmt.Sprintf("not allowed for '%s' objects", objectType)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice {
+		if !eaDefFlags(def).MultipleValues {
+			return "multiple values are not allowed"
+		}
+		if rv.Len() == 0 {
+			return "empty list of values"
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if reason := checkEAScalar(def, rv.Index(i).Interface()); reason != "" {
+				return fmt.Sprintf("value #%d: %s", i+1, reason)
+			}
+		}
+		return ""
+	}
+	return checkEAScalar(def, value)
+}
+
+func checkEAScalar(def EADefinition, value interface{}) string {
+	eaType := strings.ToUpper(def.Type)
+	if eaType == "INTEGER" {
+		i, ok := eaIntValue(value)
+		if !ok {
+			return fmt.Sprintf("expected an integer, got %T", value)
+		}
+		if def.Min != nil && i < int64(*def.Min) {
+			return fmt.Sprintf("value %d is below the minimum %d", i, *def.Min)
+		}
+		if def.Max != nil && i > int64(*def.Max) {
+			return fmt.Sprintf("value %d is above the maximum %d", i, *def.Max)
+		}
+		return ""
+	}
+
+	if eaType == "DATE" {
+		if _, ok := eaIntValue(value); ok {
+			return ""
+		}
+	}
+	s, ok := value.(string)
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Bool && (eaType == "STRING" || eaType == "ENUM") {
+		// booleans, such as the Bool values read by EA.UnmarshalJSON, are kept as 'True' and 'False'
+		s, ok = "False", true
+		if rv.Bool() {
+			s = "True"
+		}
+	}
+	if !ok {
+		return fmt.Sprintf("expected a string, got %T", value)
+	}
+	switch eaType {
+	case "ENUM":
+		for _, lv := range def.ListValues {
+			if lv != nil && lv.Value == s {
+				return ""
+			}
+		}
+		return fmt.Sprintf("value '%s' is not in the list of allowed values", s)
+	case "EMAIL":
+		if _, err := mail.ParseAddress(s); err != nil {
+			return fmt.Sprintf("value '%s' is not a valid e-mail address", s)
+		}
+	case "URL":
+		if u, err := url.Parse(s); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Sprintf("value '%s' is not a valid URL", s)
+		}
+	case "DATE":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			if _, err = time.Parse("2006-01-02", s); err != nil {
+				return fmt.Sprintf("value '%s' is not a valid date", s)
+			}
+		}
+	}
+	return ""
+}
+
+func eaIntValue(value interface{}) (int64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+// objectEAs returns the EAs to be set by obj: the value of its 'extattrs'
+// or 'extattrs+' field.
+func objectEAs(obj IBObject) EA {
+	rv := reflect.Indirect(reflect.ValueOf(obj))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < rv.NumField(); i++ {
+		tag := strings.Split(rv.Type().Field(i).Tag.Get("json"), ",")[0]
+		if tag != "extattrs" && tag != "extattrs+" {
+			continue
+		}
+		if ea, ok := rv.Field(i).Interface().(EA); ok {
+			return ea
+		}
+	}
+	return nil
+}
+
+// EAValidatingConnector wraps a connector and validates the EAs of every
+// object before it is created or updated. ObjectManager methods which
+// require a *Connector, such as CreateMultiObject, reach the wrapped one
+// through Unwrap; the objects of guarded updates are validated as well,
+// the bodies of multi-requests are passed on as they are.
+type EAValidatingConnector struct {
+	IBConnector
+	Validator *EAValidator
+}
+
+func NewEAValidatingConnector(conn IBConnector, validator *EAValidator) *EAValidatingConnector {
+	return &EAValidatingConnector{IBConnector: conn, Validator: validator}
+}
+
+// Unwrap returns the wrapped connector.
+func (c *EAValidatingConnector) Unwrap() IBConnector {
+	return c.IBConnector
+}
+
+// validateObjectEAs validates the EAs of obj when the connector of the
+// object manager is an EAValidatingConnector, for the requests made on
+// the *Connector it wraps.
+func (objMgr *ObjectManager) validateObjectEAs(obj IBObject, create bool) error {
+	if c, ok := objMgr.connector.(*EAValidatingConnector); ok {
+		return c.Validator.Validate(obj.ObjectType(), objectEAs(obj), create)
+	}
+	return nil
+}
+
+func (c *EAValidatingConnector) CreateObject(obj IBObject) (string, error) {
+	if err := c.Validator.Validate(obj.ObjectType(), objectEAs(obj), true); err != nil {
+		return "", err
+	}
+	return c.IBConnector.CreateObject(obj)
+}
+
+func (c *EAValidatingConnector) UpdateObject(obj IBObject, ref string) (string, error) {
+	if err := c.Validator.Validate(obj.ObjectType(), objectEAs(obj), false); err != nil {
+		return "", err
+	}
+	return c.IBConnector.UpdateObject(obj, ref)
+}
+
+// CallFunction passes the call on to the wrapped connector, if it supports
+// WAPI function calls.
+func (c *EAValidatingConnector) CallFunction(ref string, function string, args interface{}, res interface{}) error {
+	caller, ok := c.IBConnector.(IBFunctionCaller)
+	if !ok {
+		return fmt.Errorf("the connector does not support WAPI function calls")
+	}
+	return caller.CallFunction(ref, function, args, res)
+}
+
+// UploadFile passes the upload on to the wrapped connector, if it supports
+// file uploads.
+func (c *EAValidatingConnector) UploadFile(filename string, data []byte) (string, error) {
+	uploader, ok := c.IBConnector.(IBFileUploader)
+	if !ok {
+		return "", fmt.Errorf("the connector does not support file uploads")
+	}
+	return uploader.UploadFile(filename, data)
+}
@@ -0,0 +1,116 @@
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EA Validator", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	siteName := "Site"
+	vlanName := "VLAN"
+	ownerName := "Owner"
+	tagsName := "Tags"
+	managedName := "Managed"
+	mandatory := "M"
+	multiple := "V"
+	min := uint32(1)
+	max := uint32(4094)
+	defs := []EADefinition{
+		{Name: &siteName, Type: "ENUM", ListValues: []*EADefListValue{{"Blr"}, {"Hyd"}}},
+		{Name: &vlanName, Type: "INTEGER", Min: &min, Max: &max},
+		{Name: &ownerName, Type: "EMAIL", Flags: &mandatory, AllowedObjectTypes: []string{"Network"}},
+		{Name: &tagsName, Type: "STRING", Flags: &multiple},
+		{Name: &managedName, Type: "ENUM", ListValues: []*EADefListValue{{"True"}, {"False"}}},
+	}
+	conn := &fakeConnector{
+		getObjectObj:         NewEmptyEADefinition(),
+		getObjectQueryParams: (*QueryParams)(nil),
+		getObjectRef:         "",
+		resultObject:         defs,
+	}
+	objMgr := NewObjectManager(conn, cmpType, tenantID)
+	validator := NewEAValidator(objMgr, 0)
+
+	It("should accept valid EAs", func() {
+		err := validator.Validate("network", EA{
+			"Site":  "Blr",
+			"VLAN":  100,
+			"Owner": "netops@example.com",
+			"Tags":  []string{"prod", "dmz"},
+		}, true)
+		Expect(err).To(BeNil())
+	})
+
+	It("should report every invalid attribute", func() {
+		err := validator.Validate("network", EA{
+			"Site": "Pune",
+			"VLAN": "100",
+			"Zone": "dmz",
+		}, true)
+		Expect(err).To(ConsistOf(
+			&EAValidationError{"Site", "value 'Pune' is not in the list of allowed values"},
+			&EAValidationError{"VLAN", "expected an integer, got string"},
+			&EAValidationError{"Zone", "no such extensible attribute definition"},
+			&EAValidationError{"Owner", "value is mandatory for 'network' objects"},
+		))
+	})
+
+	It("should check ranges, multiple values and allowed object types", func() {
+		err := validator.Validate("record:a", EA{
+			"VLAN":  5000,
+			"Site":  []string{"Blr", "Hyd"},
+			"Owner": "netops@example.com",
+		}, true)
+		Expect(err).To(ConsistOf(
+			&EAValidationError{"VLAN", "value 5000 is above the maximum 4094"},
+			&EAValidationError{"Site", "multiple values are not allowed"},
+			&EAValidationError{"Owner", "not allowed for 'record:a' objects"},
+		))
+	})
+
+	It("should accept booleans for STRING and ENUM attributes", func() {
+		err := validator.Validate("network", EA{
+			"Owner":   "netops@example.com",
+			"Tags":    true,
+			"Managed": Bool(false),
+		}, true)
+		Expect(err).To(BeNil())
+		err = validator.Validate("network", EA{"Owner": "netops@example.com", "Site": true}, true)
+		Expect(err).To(ConsistOf(&EAValidationError{"Site", "value 'True' is not in the list of allowed values"}))
+	})
+
+	It("should let the object manager reach the wrapped connector", func() {
+		ref := "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default"
+		fhr := &fakeSequenceRequestor{res: [][]byte{
+			[]byte(`[{"LOCK_HOLDER":"Available"}]`),
+			[]byte(`{"_ref":"` + ref + `","comment":"old"}`),
+		}}
+		wrb, _ := NewWapiRequestBuilder(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+			AuthConfig{Username: "admin", Password: "infoblox"})
+		OrigValidateConnector := ValidateConnector
+		ValidateConnector = MockValidateConnector
+		defer func() { ValidateConnector = OrigValidateConnector }()
+		conn, err := NewConnector(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+			AuthConfig{Username: "admin", Password: "infoblox"}, NewTransportConfig("false", 20, 10), wrb, fhr)
+		Expect(err).To(BeNil())
+		vObjMgr := NewObjectManager(NewEAValidatingConnector(conn, validator), cmpType, tenantID)
+
+		res, err := vObjMgr.(*ObjectManager).CreateMultiObject(NewMultiRequest([]*RequestBody{{Method: "STATE:DISPLAY"}}))
+		Expect(err).To(BeNil())
+		Expect(res).To(HaveLen(1))
+
+		prev := NewNetwork("default", "10.0.0.0/24", false, "old", nil)
+		_, err = vObjMgr.UpdateObjectIfUnchanged(prev, ref, []string{"comment"},
+			NewNetwork("default", "10.0.0.0/24", false, "new", EA{"VLAN": 0}))
+		Expect(err).To(Equal(EAValidationErrors{&EAValidationError{"VLAN", "value 0 is below the minimum 1"}}))
+		Expect(fhr.reqs).To(HaveLen(2))
+	})
+
+	It("should validate objects passed to the wrapped connector", func() {
+		vconn := NewEAValidatingConnector(&fakeConnector{}, validator)
+		_, err := vconn.UpdateObject(NewNetwork("default", "10.0.0.0/24", false, "", EA{"VLAN": 0}), "network/ref")
+		Expect(err).To(Equal(EAValidationErrors{&EAValidationError{"VLAN", "value 0 is below the minimum 1"}}))
+	})
+})
@@ -0,0 +1,271 @@
+package ibclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LockInfo describes the state of a lock kept in extensible attributes of an object.
+type LockInfo struct {
+	ObjectType string
+	Ref        string
+	Held       bool
+	Holder     string    // tenant ID of the holder, empty if the lock is free
+	AcquiredAt time.Time // time of acquisition or last renewal, zero if unknown
+	Token      int64     // fencing token, if the lock is an EALock
+}
+
+// Age returns how long the lock has been held without renewal.
+func (li LockInfo) Age() time.Duration {
+	if !li.Held || li.AcquiredAt.IsZero() {
+		return 0
+	}
+	return time.Since(li.AcquiredAt)
+}
+
+// LockBreakEvent is the audit record of a forcibly broken lock.
+type LockBreakEvent struct {
+	Lock     LockInfo
+	BrokenAt time.Time
+	BrokenBy string // tenant ID of the object manager which broke the lock
+	Reason   string
+}
+
+// LockAuditor receives an event for every lock broken by BreakLock or BreakStaleLocks.
+type LockAuditor func(event LockBreakEvent)
+
+func newLockInfo(obj *eaObject, lockEA string, lockTimeoutEA string, lockTokenEA string) (LockInfo, error) {
+	info := LockInfo{
+		ObjectType: obj.objectType,
+		Ref:        obj.Ref,
+	}
+	if holder, ok := obj.Ea[lockEA]; ok && holder != freeLockVal {
+		info.Held = true
+		info.Holder = fmt.Sprintf("%v", holder)
+		lockTime, err := eaInt(obj.Ea, lockTimeoutEA)
+		if err != nil {
+			return info, fmt.Errorf("invalid lock time on '%s': %s", obj.Ref, err)
+		}
+		if lockTime > 0 {
+			info.AcquiredAt = time.Unix(lockTime, 0)
+		}
+	}
+	if lockTokenEA != "" {
+		token, err := eaInt(obj.Ea, lockTokenEA)
+		if err != nil {
+			return info, fmt.Errorf("invalid fencing token on '%s': %s", obj.Ref, err)
+		}
+		info.Token = token
+	}
+	return info, nil
+}
+
+// ListLocks returns the state of the locks on all objects of the given type
+// which carry the lock EA. lockTokenEA may be empty for locks without
+// fencing tokens, such as NetworkViewLock.
+func (objMgr *ObjectManager) ListLocks(objectType string, lockEA string, lockTimeoutEA string, lockTokenEA string) ([]LockInfo, error) {
+	if objectType == "" || lockEA == "" || lockTimeoutEA == "" {
+		return nil, fmt.Errorf("object type, lock EA and lock timeout EA are required to list locks")
+	}
+	var res []eaObject
+	sf := map[string]string{
+		fmt.Sprintf("*%s~", lockEA): ".",
+	}
+	err := objMgr.connector.GetObject(newEAObject(objectType), "", NewQueryParams(false, sf), &res)
+	if err != nil {
+		if _, ok := err.(*NotFoundError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	locks := make([]LockInfo, 0, len(res))
+	for i := range res {
+		res[i].objectType = objectType
+		info, err := newLockInfo(&res[i], lockEA, lockTimeoutEA, lockTokenEA)
+		if err != nil {
+			return nil, err
+		}
+		locks = append(locks, info)
+	}
+	return locks, nil
+}
+
+// createBreakRequest builds a request which frees the lock and removes its
+// timeout EA, only if the lock EAs of the object still carry the values
+// described by info. The object is searched by its type, its identifying
+// fields idFields and the values of the lock EAs.
+func createBreakRequest(info LockInfo, idFields map[string]interface{}, lockEA string, lockTimeoutEA string, lockTokenEA string) *MultiRequest {
+	getData := map[string]interface{}{
+		"*" + lockEA: info.Holder,
+	}
+	for f, v := range idFields {
+		if f != "_ref" && isSearchValue(v) {
+			getData[f] = v
+		}
+	}
+	if !info.AcquiredAt.IsZero() {
+		getData["*"+lockTimeoutEA] = info.AcquiredAt.Unix()
+	}
+	if lockTokenEA != "" {
+		getData["*"+lockTokenEA] = info.Token
+	}
+
+	return NewMultiRequest(
+		[]*RequestBody{
+			&RequestBody{
+				Method: "GET",
+				Object: info.ObjectType,
+				Data:   getData,
+				Args: map[string]string{
+					"_return_fields": "extattrs",
+				},
+				AssignState: map[string]string{
+					"LOCK_OBJ_REF": "_ref",
+				},
+				Discard: true,
+			},
+			&RequestBody{
+				Method: "PUT",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Data: map[string]interface{}{
+					"extattrs+": map[string]interface{}{
+						lockEA: map[string]string{
+							"value": freeLockVal,
+						},
+					},
+				},
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+			&RequestBody{
+				Method: "PUT",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Data: map[string]interface{}{
+					"extattrs-": map[string]interface{}{
+						lockTimeoutEA: map[string]interface{}{},
+					},
+				},
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+			&RequestBody{
+				Method: "GET",
+				Object: "##STATE:LOCK_OBJ_REF:##",
+				Args: map[string]string{
+					"_return_fields": "extattrs",
+				},
+				AssignState: map[string]string{
+					"LOCK_HOLDER": "*" + lockEA,
+				},
+				EnableSubstitution: true,
+				Discard:            true,
+			},
+			&RequestBody{
+				Method: "STATE:DISPLAY",
+			},
+		},
+	)
+}
+
+// BreakLock forcibly releases the given lock, provided that the lock EAs
+// of the object, including the fencing token when lockTokenEA is set, have
+// not changed since info was read; otherwise a ConflictError is returned.
+// The check and the release are made in a single request. The break is
+// logged and reported to audit, which may be nil.
+func (objMgr *ObjectManager) BreakLock(info LockInfo, lockEA string, lockTimeoutEA string, lockTokenEA string, reason string, audit LockAuditor) (*LockBreakEvent, error) {
+	if !info.Held {
+		return nil, fmt.Errorf("lock on '%s' is not held", info.Ref)
+	}
+
+	// the fields WAPI returns by default identify the object
+	idFields, err := objMgr.getGuardedFields(info.ObjectType, info.Ref, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := objMgr.CreateMultiObject(createBreakRequest(info, idFields, lockEA, lockTimeoutEA, lockTokenEA))
+	if err != nil {
+		// the guard fails the request when the lock changed, tell it apart from other failures
+		cur := newEAObject(info.ObjectType)
+		if getErr := objMgr.connector.GetObject(cur, info.Ref, NewQueryParams(false, nil), &cur); getErr != nil {
+			return nil, err
+		}
+		cur.objectType = info.ObjectType
+		current, infoErr := newLockInfo(cur, lockEA, lockTimeoutEA, lockTokenEA)
+		if infoErr != nil || !current.Held || current.Holder != info.Holder ||
+			!current.AcquiredAt.Equal(info.AcquiredAt) || current.Token != info.Token {
+			return nil, NewConflictError(info.Ref, []string{"extattrs"})
+		}
+		return nil, err
+	}
+	if len(res) == 0 || res[0]["LOCK_HOLDER"] != freeLockVal {
+		return nil, fmt.Errorf("failed to break lock on '%s'", info.Ref)
+	}
+
+	event := LockBreakEvent{
+		Lock:     info,
+		BrokenAt: time.Now(),
+		BrokenBy: objMgr.tenantID,
+		Reason:   reason,
+	}
+	logrus.Warnf("Lock on '%s' held by '%s' since %s was broken by '%s': %s\n",
+		info.Ref, info.Holder, info.AcquiredAt.Format(time.RFC3339), event.BrokenBy, reason)
+	if audit != nil {
+		audit(event)
+	}
+	return &event, nil
+}
+
+// BreakStaleLocks forcibly releases the locks on objects of the given type
+// which have been held without renewal for longer than olderThan.
+// It returns the audit records of the broken locks; locks which changed
+// while being broken are skipped. lockTokenEA may be empty for locks
+// without fencing tokens.
+func (objMgr *ObjectManager) BreakStaleLocks(objectType string, lockEA string, lockTimeoutEA string, lockTokenEA string, olderThan time.Duration, audit LockAuditor) ([]LockBreakEvent, error) {
+	if olderThan <= 0 {
+		return nil, fmt.Errorf("a positive age threshold is required to break stale locks")
+	}
+	locks, err := objMgr.ListLocks(objectType, lockEA, lockTimeoutEA, lockTokenEA)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []LockBreakEvent
+	for _, info := range locks {
+		if !info.Held || info.AcquiredAt.IsZero() || info.Age() <= olderThan {
+			continue
+		}
+		reason := fmt.Sprintf("lock age %s exceeds %s", info.Age().Truncate(time.Second), olderThan)
+		event, err := objMgr.BreakLock(info, lockEA, lockTimeoutEA, lockTokenEA, reason, audit)
+		if err != nil {
+			if _, ok := err.(*ConflictError); ok {
+				logrus.Debugf("Lock on '%s' changed while being broken, skipping it\n", info.Ref)
+				continue
+			}
+			return events, err
+		}
+		events = append(events, *event)
+	}
+	return events, nil
+}
+
+// Holder returns the current state of the lock.
+func (l *EALock) Holder() (*LockInfo, error) {
+	obj, err := l.getObject()
+	if err != nil {
+		return nil, err
+	}
+	obj.objectType = l.ObjectType
+	info, err := newLockInfo(obj, l.LockEA, l.LockTimeoutEA, l.LockTokenEA)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Holder returns the current state of the lock.
+func (l *NetworkViewLock) Holder() (*LockInfo, error) {
+	return l.eaLock().Holder()
+}
@@ -0,0 +1,150 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lock administration", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	lockEA := "Docker-Plugin-Lock"
+	lockTimeoutEA := "Docker-Plugin-Lock-Time"
+	staleTime := time.Now().Add(-2 * time.Hour).Unix()
+	freshTime := time.Now().Add(-10 * time.Second).Unix()
+	staleRef := "networkview/ZG5zLm5ldHdvcmtfdmlldyQx:stale_view/false"
+	freshRef := "networkview/ZG5zLm5ldHdvcmtfdmlldyQy:fresh_view/false"
+	freeRef := "networkview/ZG5zLm5ldHdvcmtfdmlldyQz:free_view/false"
+
+	Describe("List locks", func() {
+		conn := &fakeConnector{
+			getObjectObj: newEAObject("networkview"),
+			getObjectQueryParams: NewQueryParams(false, map[string]string{
+				fmt.Sprintf("*%s~", lockEA): ".",
+			}),
+			getObjectRef: "",
+			resultObject: []eaObject{
+				{Ref: staleRef, Ea: EA{lockEA: "tenant-1", lockTimeoutEA: int(staleTime)}},
+				{Ref: freeRef, Ea: EA{lockEA: freeLockVal}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should report holder and acquisition time of every lock", func() {
+			locks, err := objMgr.ListLocks("networkview", lockEA, lockTimeoutEA, "")
+			Expect(err).To(BeNil())
+			Expect(locks).To(Equal([]LockInfo{
+				{ObjectType: "networkview", Ref: staleRef, Held: true, Holder: "tenant-1", AcquiredAt: time.Unix(staleTime, 0)},
+				{ObjectType: "networkview", Ref: freeRef},
+			}))
+			Expect(locks[0].Age()).To(BeNumerically(">", time.Hour))
+			Expect(locks[1].Age()).To(BeZero())
+		})
+	})
+
+	Describe("Break a lock", func() {
+		lockTokenEA := "Docker-Plugin-Lock-Token"
+		info := LockInfo{ObjectType: "networkview", Ref: staleRef, Held: true, Holder: "tenant-1", AcquiredAt: time.Unix(staleTime, 0), Token: 7}
+
+		newObjMgr := func(fhr *fakeSequenceRequestor) IBObjectManager {
+			wrb, _ := NewWapiRequestBuilder(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"})
+			OrigValidateConnector := ValidateConnector
+			ValidateConnector = MockValidateConnector
+			defer func() { ValidateConnector = OrigValidateConnector }()
+			conn, err := NewConnector(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"}, NewTransportConfig("false", 20, 10), wrb, fhr)
+			if err != nil {
+				Fail("Error creating Connector")
+			}
+			return NewObjectManager(conn, cmpType, tenantID)
+		}
+
+		defaults := []byte(`{"_ref":"` + staleRef + `","name":"stale_view","is_default":false}`)
+
+		It("should release the lock guarded by its EAs and audit the break", func() {
+			fhr := &fakeSequenceRequestor{res: [][]byte{defaults, []byte(`[{"LOCK_HOLDER":"` + freeLockVal + `"}]`)}}
+			var audited []LockBreakEvent
+			event, err := newObjMgr(fhr).BreakLock(info, lockEA, lockTimeoutEA, lockTokenEA, "plugin crashed", func(e LockBreakEvent) {
+				audited = append(audited, e)
+			})
+			Expect(err).To(BeNil())
+			Expect(event.Lock).To(Equal(info))
+			Expect(event.BrokenBy).To(Equal(tenantID))
+			Expect(event.Reason).To(Equal("plugin crashed"))
+			Expect(audited).To(Equal([]LockBreakEvent{*event}))
+
+			Expect(fhr.reqs).To(HaveLen(2))
+			var body []map[string]interface{}
+			data, _ := ioutil.ReadAll(fhr.reqs[1].Body)
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			Expect(body[0]["object"]).To(Equal("networkview"))
+			Expect(body[0]["data"]).To(Equal(map[string]interface{}{
+				"name":              "stale_view",
+				"is_default":        false,
+				"*" + lockEA:        "tenant-1",
+				"*" + lockTimeoutEA: float64(staleTime),
+				"*" + lockTokenEA:   float64(7),
+			}))
+			Expect(body[1]["data"]).To(Equal(map[string]interface{}{
+				"extattrs+": map[string]interface{}{lockEA: map[string]interface{}{"value": freeLockVal}},
+			}))
+			Expect(body[2]["data"]).To(Equal(map[string]interface{}{
+				"extattrs-": map[string]interface{}{lockTimeoutEA: map[string]interface{}{}},
+			}))
+		})
+
+		It("should refuse to break a lock taken over in the meantime", func() {
+			wapiErr := fmt.Errorf("WAPI request error: 400('400 Bad Request')")
+			current := fmt.Sprintf(`{"_ref":"%s","extattrs":{"%s":{"value":"tenant-2"},"%s":{"value":%d},"%s":{"value":8}}}`,
+				staleRef, lockEA, lockTimeoutEA, freshTime, lockTokenEA)
+			fhr := &fakeSequenceRequestor{
+				res:  [][]byte{defaults, nil, nil, []byte(current)},
+				errs: []error{nil, wapiErr, wapiErr},
+			}
+			_, err := newObjMgr(fhr).BreakLock(info, lockEA, lockTimeoutEA, lockTokenEA, "plugin crashed", nil)
+			Expect(err).To(Equal(NewConflictError(staleRef, []string{"extattrs"})))
+		})
+
+		It("should refuse to break a lock whose token changed", func() {
+			wapiErr := fmt.Errorf("WAPI request error: 400('400 Bad Request')")
+			current := fmt.Sprintf(`{"_ref":"%s","extattrs":{"%s":{"value":"tenant-1"},"%s":{"value":%d},"%s":{"value":8}}}`,
+				staleRef, lockEA, lockTimeoutEA, staleTime, lockTokenEA)
+			fhr := &fakeSequenceRequestor{
+				res:  [][]byte{defaults, nil, nil, []byte(current)},
+				errs: []error{nil, wapiErr, wapiErr},
+			}
+			_, err := newObjMgr(fhr).BreakLock(info, lockEA, lockTimeoutEA, lockTokenEA, "plugin crashed", nil)
+			Expect(err).To(Equal(NewConflictError(staleRef, []string{"extattrs"})))
+
+			var body []map[string]interface{}
+			data, _ := ioutil.ReadAll(fhr.reqs[1].Body)
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			Expect(body[0]["object"]).To(Equal("networkview"))
+			Expect(body[0]["data"]).To(HaveKeyWithValue("*"+lockTokenEA, float64(7)))
+		})
+	})
+
+	Describe("Negative case: break stale locks without a threshold", func() {
+		objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+
+		It("should return an error", func() {
+			_, err := objMgr.BreakStaleLocks("networkview", lockEA, lockTimeoutEA, "", 0, nil)
+			Expect(err).To(Equal(fmt.Errorf("a positive age threshold is required to break stale locks")))
+		})
+	})
+
+	Describe("Lock info of a fresh lock", func() {
+		It("should not be older than the threshold", func() {
+			info, err := newLockInfo(&eaObject{objectType: "networkview", Ref: freshRef, Ea: EA{lockEA: "tenant-2", lockTimeoutEA: int(freshTime)}},
+				lockEA, lockTimeoutEA, "")
+			Expect(err).To(BeNil())
+			Expect(info.Age()).To(BeNumerically("<", time.Hour))
+		})
+	})
+})
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // Compile-time interface checks
@@ -13,9 +15,13 @@ var _ IBObjectManager = new(ObjectManager)
 
 type IBObjectManager interface {
 	GetDNSView(name string) (*View, error)
+	GetDNSViewByRef(ref string) (*View, error)
 	AllocateIP(netview string, cidr string, ipAddr string, isIPv6 bool, macOrDuid string, name string, comment string, eas EA, clients string, agentCircuitId string, agentRemoteId string, clientIdentifierPrependZero *bool, dhcpClientIdentifier string, disable bool, Options []*Dhcpoption, useOptions bool) (*FixedAddress, error)
+	AllocateIpv6FixedAddress(netview string, cidr string, addressType string, ipAddr string, ipv6Prefix string, ipv6PrefixBits uint32, duid string, name string, comment string, eas EA, disable bool, options []*Dhcpoption, useOptions bool) (*FixedAddress, error)
 	AllocateNextAvailableIp(name string, objectType string, objectParams map[string]string, params map[string][]string, useEaInheritance bool, ea EA, comment string, disable bool, n *int, ipAddrType string,
 		enableDns bool, enableDhcp bool, macAddr string, duid string, networkView string, dnsView string, useTtl bool, ttl uint32, aliases []string) (interface{}, error)
+	AllocateNextAvailableIps(req NextAvailableIpRequest, name string, comment string, eas EA) (*NextAvailableIpResult, error)
+	AllocateNextAvailableNetwork(req NextAvailableNetworkRequest, comment string, eas EA) (*NextAvailableNetworkResult, error)
 	AllocateNetwork(netview string, cidr string, isIPv6 bool, prefixLen uint, comment string, eas EA) (network *Network, err error)
 	AllocateNetworkByEA(netview string, isIPv6 bool, comment string, eas EA, eaMap map[string]string, prefixLen uint, object string) (network *Network, err error)
 	AllocateNetworkContainer(netview string, cidr string, isIPv6 bool, prefixLen uint, comment string, eas EA) (netContainer *NetworkContainer, err error)
@@ -23,26 +29,58 @@ type IBObjectManager interface {
 	CreateARecord(netView string, dnsView string, name string, cidr string, ipAddr string, ttl uint32, useTTL bool, comment string, ea EA) (*RecordA, error)
 	CreateAAAARecord(netView string, dnsView string, recordName string, cidr string, ipAddr string, useTtl bool, ttl uint32, comment string, eas EA) (*RecordAAAA, error)
 	CreateIpv4SharedNetwork(name string, networks []string, networkView string, eas EA, comment string, disable bool, useOptions bool, options []*Dhcpoption) (*SharedNetwork, error)
+	CreateIpv6SharedNetwork(name string, networks []string, networkView string, eas EA, comment string, disable bool, useOptions bool, options []*Dhcpoption) (*IPv6SharedNetwork, error)
 	CreateAliasRecord(name string, dnsView string, targetName string, targetType string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordAlias, error)
 	CreateDtcPool(comment string, name string, lbPreferredMethod string, lbDynamicRatioPreferred map[string]interface{}, servers []*DtcServerLink, monitors []Monitor, lbPreferredTopology *string, lbAlternateMethod string, lbAlternateTopology *string, lbDynamicRatioAlternate map[string]interface{}, eas EA, autoConsolidatedMonitors bool, userMonitors []map[string]interface{}, availability string, ttl uint32, useTTL bool, disable bool, quorum uint32) (*DtcPool, error)
 	CreateDtcServer(comment string, name string, host string, autoCreateHostRecord bool, disable bool, ea EA, monitors []map[string]interface{}, sniHostname string, useSniHostname bool) (*DtcServer, error)
+	CreateDtcMonitor(monitor IBObject) (IBObject, error)
+	CreateDtcRecord(server string, record IBObject) (IBObject, error)
+	UploadDtcCertificate(pemData []byte, member string) (*DtcCertificateInfo, error)
+	CreateDtcTopology(name string, comment string, rules []DtcTopologyRuleParams, ea EA) (*DtcTopology, error)
+	AddDtcTopologyRule(topology string, rule DtcTopologyRuleParams, position int) (*DtcTopology, error)
 	CreateNSRecord(name string, nameServer string, dnsView string, addresses []*ZoneNameServer, msDelegationName string) (*RecordNS, error)
 	CreateZoneAuth(fqdn string, ea EA) (*ZoneAuth, error)
+	CreateZoneAuthWithParams(params ZoneAuthParams) (*ZoneAuth, error)
+	CreateRpzZone(params RpzZoneParams) (*ZoneRp, error)
+	CreateRpzRule(rule RpzRule) (*RpzRule, error)
+	CreateDNSView(params DNSViewParams) (*View, error)
+	CreateNsgroup(name string, comment string, gridPrimary []*Memberserver, gridSecondaries []*Memberserver, externalPrimaries []NameServer, externalSecondaries []NameServer, useExternalPrimary bool, isGridDefault bool, ea EA) (*Nsgroup, error)
+	CreateNsgroupDelegation(name string, delegateTo []NameServer, comment string, ea EA) (*NsgroupDelegation, error)
+	CreateNsgroupForwardingMember(name string, forwardingServers []*Forwardingmemberserver, comment string, ea EA) (*NsgroupForwardingmember, error)
+	CreateNsgroupForwardStubServer(name string, externalServers []NameServer, comment string, ea EA) (*NsgroupForwardstubserver, error)
+	CreateNsgroupStubMember(name string, stubMembers []*Memberserver, comment string, ea EA) (*NsgroupStubmember, error)
 	CreateCNAMERecord(dnsview string, canonical string, recordname string, useTtl bool, ttl uint32, comment string, eas EA) (*RecordCNAME, error)
+	CreateCAARecord(name string, dnsView string, caFlag uint32, caTag string, caValue string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordCaa, error)
+	CreateNAPTRRecord(name string, dnsView string, order uint32, preference uint32, flags string, services string, regexp string, replacement string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordNaptr, error)
+	CreateTLSARecord(name string, dnsView string, certificateUsage uint32, selector uint32, matchedType uint32, certificateData string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordTlsa, error)
+	CreateDNAMERecord(name string, dnsView string, target string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordDname, error)
+	CreateUnknownRecord(name string, dnsView string, recordType string, subfieldValues []*Rdatasubfield, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordUnknown, error)
 	CreateDefaultNetviews(globalNetview string, localNetview string) (globalNetviewRef string, localNetviewRef string, err error)
 	CreateDtcLbdn(name string, authZones []AuthZonesLink, comment string, disable bool, autoConsolidatedMonitors bool, ea EA,
 		lbMethod string, patterns []string, persistence uint32, pools []*DtcPoolLink, priority uint32, topology *string, types []string, ttl uint32, usettl bool) (*DtcLbdn, error)
 	CreateZoneForward(comment string, disable bool, eas EA, forwardTo NullableNameServers, forwardersOnly bool, forwardingServers *NullableForwardingServers, fqdn string, nsGroup string, view string, zoneFormat string, externalNsGroup string) (*ZoneForward, error)
+	CreateZoneStub(comment string, disable bool, locked bool, eas EA, stubFrom []NameServer, stubMembers []*Memberserver, fqdn string, nsGroup string, externalNsGroup string, view string, zoneFormat string, prefix string) (*ZoneStub, error)
 	CreateEADefinition(eadef EADefinition) (*EADefinition, error)
+	UpdateEADefinition(ref string, eadef EADefinition) (*EADefinition, error)
+	UpdateEADefinitionListValues(ref string, add []string, remove []string) (*EADefinition, error)
+	DeleteEADefinition(ref string) (string, error)
+	GetAllEADefinitions(queryParams *QueryParams) ([]EADefinition, error)
+	GetEADefinitionByRef(ref string) (*EADefinition, error)
+	MigrateEA(oldName string, newName string, objectTypes []string, removeOld bool) (int, error)
 	CreateHostRecord(enabledns bool, enabledhcp bool, recordName string, netview string, dnsview string, ipv4cidr string, ipv6cidr string, ipv4Addr string, ipv6Addr string, macAddr string, duid string, useTtl bool, ttl uint32, comment string, eas EA, aliases []string, disable bool) (*HostRecord, error)
 	CreateMXRecord(dnsView string, fqdn string, mx string, preference uint32, ttl uint32, useTtl bool, comment string, eas EA) (*RecordMX, error)
 	CreateNetwork(netview string, cidr string, isIPv6 bool, comment string, eas EA) (*Network, error)
 	CreateNetworkContainer(netview string, cidr string, isIPv6 bool, comment string, eas EA) (*NetworkContainer, error)
 	CreateNetworkView(name string, comment string, setEas EA) (*NetworkView, error)
 	CreateNetworkRange(comment string, name string, network string, networkView string, startAddr string, endAddr string, disable bool, eas EA, member *Dhcpmember, failOverAssociation string, options []*Dhcpoption, useOptions bool, serverAssociation string, template string, msServer string) (*Range, error)
+	CreateIpv6NetworkRange(comment string, name string, network string, networkView string, addressType string, startAddr string, endAddr string, ipv6StartPrefix string, ipv6EndPrefix string, ipv6PrefixBits uint32, disable bool, eas EA, member *Dhcpmember, serverAssociationType string, template string) (*IPv6Range, error)
 	CreatePTRRecord(networkView string, dnsView string, ptrdname string, recordName string, cidr string, ipAddr string, useTtl bool, ttl uint32, comment string, eas EA) (*RecordPTR, error)
 	CreateRangeTemplate(name string, numberOfAdresses uint32, offset uint32, comment string, ea EA,
 		options []*Dhcpoption, useOption bool, serverAssociationType string, failOverAssociation string, member *Dhcpmember, cloudApiCompatible bool, msServer string) (*Rangetemplate, error)
+	CreateIpv6RangeTemplate(name string, numberOfAddresses uint32, offset uint32, comment string,
+		serverAssociationType string, member *Dhcpmember, delegatedMember *Dhcpmember, cloudApiCompatible bool) (*Ipv6rangetemplate, error)
+	CreateIpv6FixedAddressTemplate(name string, numberOfAddresses uint32, offset uint32, comment string, ea EA,
+		options []*Dhcpoption, useOptions bool, domainName string) (*Ipv6fixedaddresstemplate, error)
 	CreateSRVRecord(dnsView string, name string, priority uint32, weight uint32, port uint32, target string, ttl uint32, useTtl bool, comment string, eas EA) (*RecordSRV, error)
 	CreateTXTRecord(dnsView string, recordName string, text string, ttl uint32, useTtl bool, comment string, eas EA) (*RecordTXT, error)
 	CreateZoneDelegated(fqdn string, delegateTo NullableNameServers, comment string, disable bool, locked bool, nsGroup string, delegatedTtl uint32, useDelegatedTtl bool, ea EA, view string, zoneFormat string) (*ZoneDelegated, error)
@@ -52,11 +90,32 @@ type IBObjectManager interface {
 	DeleteAliasRecord(ref string) (string, error)
 	DeleteDtcLbdn(ref string) (string, error)
 	DeleteIpv4SharedNetwork(ref string) (string, error)
+	DeleteIpv6SharedNetwork(ref string) (string, error)
 	DeleteDtcPool(ref string) (string, error)
 	DeleteDtcServer(ref string) (string, error)
+	DeleteDtcMonitor(ref string) (string, error)
+	DeleteDtcRecord(ref string) (string, error)
+	DeleteDtcCertificate(ref string) (string, error)
+	DeleteDtcTopology(ref string) (string, error)
+	DeleteDtcTopologyRule(topology string, position int) (*DtcTopology, error)
 	DeleteZoneAuth(ref string) (string, error)
+	DeleteRpzZone(ref string) (string, error)
+	DeleteRpzRule(ref string) (string, error)
+	DeleteDNSView(ref string) (string, error)
+	DeleteNsgroup(ref string) (string, error)
+	DeleteNsgroupDelegation(ref string) (string, error)
+	DeleteNsgroupForwardingMember(ref string) (string, error)
+	DeleteNsgroupForwardStubServer(ref string) (string, error)
+	DeleteNsgroupStubMember(ref string) (string, error)
 	DeleteZoneForward(ref string) (string, error)
+	DeleteZoneStub(ref string) (string, error)
 	DeleteCNAMERecord(ref string) (string, error)
+	DeleteCAARecord(ref string) (string, error)
+	DeleteNAPTRRecord(ref string) (string, error)
+	DeleteTLSARecord(ref string) (string, error)
+	DeleteDNAMERecord(ref string) (string, error)
+	DeleteDHCIDRecord(ref string) (string, error)
+	DeleteUnknownRecord(ref string) (string, error)
 	DeleteFixedAddress(ref string) (string, error)
 	DeleteHostRecord(ref string) (string, error)
 	DeleteMXRecord(ref string) (string, error)
@@ -65,10 +124,13 @@ type IBObjectManager interface {
 	DeleteNetworkView(ref string) (string, error)
 	DeletePTRRecord(ref string) (string, error)
 	DeleteRangeTemplate(ref string) (string, error)
+	DeleteIpv6RangeTemplate(ref string) (string, error)
+	DeleteIpv6FixedAddressTemplate(ref string) (string, error)
 	DeleteSRVRecord(ref string) (string, error)
 	DeleteTXTRecord(ref string) (string, error)
 	DeleteZoneDelegated(ref string) (string, error)
 	DeleteNetworkRange(ref string) (string, error)
+	DeleteIpv6NetworkRange(ref string) (string, error)
 	GetARecordByRef(ref string) (*RecordA, error)
 	GetARecord(dnsview string, recordName string, ipAddr string) (*RecordA, error)
 	GetAAAARecord(dnsview string, recordName string, ipAddr string) (*RecordAAAA, error)
@@ -79,6 +141,18 @@ type IBObjectManager interface {
 	GetCNAMERecordByRef(ref string) (*RecordCNAME, error)
 	GetNSRecordByRef(ref string) (*RecordNS, error)
 	GetAllRecordNS(queryParams *QueryParams) ([]RecordNS, error)
+	GetCAARecordByRef(ref string) (*RecordCaa, error)
+	GetAllCAARecord(queryParams *QueryParams) ([]RecordCaa, error)
+	GetNAPTRRecordByRef(ref string) (*RecordNaptr, error)
+	GetAllNAPTRRecord(queryParams *QueryParams) ([]RecordNaptr, error)
+	GetTLSARecordByRef(ref string) (*RecordTlsa, error)
+	GetAllTLSARecord(queryParams *QueryParams) ([]RecordTlsa, error)
+	GetDNAMERecordByRef(ref string) (*RecordDname, error)
+	GetAllDNAMERecord(queryParams *QueryParams) ([]RecordDname, error)
+	GetDHCIDRecordByRef(ref string) (*RecordDhcid, error)
+	GetAllDHCIDRecord(queryParams *QueryParams) ([]RecordDhcid, error)
+	GetUnknownRecordByRef(ref string) (*RecordUnknown, error)
+	GetAllUnknownRecord(queryParams *QueryParams) ([]RecordUnknown, error)
 	GetAllDtcPool(queryParams *QueryParams) ([]DtcPool, error)
 	GetDtcPool(name string) (*DtcPool, error)
 	GetAllDtcServer(queryParams *QueryParams) ([]DtcServer, error)
@@ -88,15 +162,38 @@ type IBObjectManager interface {
 	GetDtcLbdnByRef(ref string) (*DtcLbdn, error)
 	GetDtcPoolByRef(ref string) (*DtcPool, error)
 	GetDtcServerByRef(ref string) (*DtcServer, error)
+	GetAllDtcMonitors(monitorType string, queryParams *QueryParams) ([]IBObject, error)
+	GetDtcMonitor(monitorType string, name string) (IBObject, error)
+	GetDtcMonitorByRef(ref string) (IBObject, error)
+	GetDtcRecords(server string, recordType string) ([]IBObject, error)
+	GetDtcRecordByRef(ref string) (IBObject, error)
+	GetDtcCertificates() ([]DtcCertificateInfo, error)
+	GetDtcCertificateByRef(ref string) (*DtcCertificateInfo, error)
+	GetExpiringDtcCertificates(within time.Duration) ([]DtcCertificateInfo, error)
+	GetAllDtcTopology(queryParams *QueryParams) ([]DtcTopology, error)
+	GetDtcTopology(name string) (*DtcTopology, error)
+	GetDtcTopologyByRef(ref string) (*DtcTopology, error)
+	GetDtcTopologyRules(topology string) ([]*DtcTopologyRule, error)
+	GetDtcTopologyLabels(field string) ([]DtcTopologyLabel, error)
+	GetDtcObjectHealth(ref string) (*DtcObjectHealth, error)
+	ResolveDtcLinks(links []DtcLink) ([]string, error)
 	GetNetworkRangeByRef(ref string) (*Range, error)
 	GetNetworkRange(queryParams *QueryParams) ([]Range, error)
+	GetIpv6NetworkRangeByRef(ref string) (*IPv6Range, error)
+	GetIpv6NetworkRange(queryParams *QueryParams) ([]IPv6Range, error)
 	GetEADefinition(name string) (*EADefinition, error)
 	GetFixedAddress(netview string, cidr string, ipAddr string, isIPv6 bool, macOrDuid string) (*FixedAddress, error)
 	GetFixedAddressByRef(ref string) (*FixedAddress, error)
 	GetAllFixedAddress(queryParams *QueryParams, isIpv6 bool) ([]FixedAddress, error)
+	GetIpv6FixedAddresses(netview string, duid string, addressType string) ([]FixedAddress, error)
+	GetIpv4Addresses(netview string, cidr string, filters IPAddressFilters) ([]IPv4Address, error)
+	GetIpv6Addresses(netview string, cidr string, filters IPAddressFilters) ([]IPv6Address, error)
+	ClearIPAddress(netview string, ipAddr string) (string, error)
 	GetHostRecord(netview string, dnsview string, recordName string, ipv4addr string, ipv6addr string) (*HostRecord, error)
 	GetIpv4SharedNetworkByRef(ref string) (*SharedNetwork, error)
 	GetAllIpv4SharedNetwork(queryParams *QueryParams) ([]SharedNetwork, error)
+	GetIpv6SharedNetworkByRef(ref string) (*IPv6SharedNetwork, error)
+	GetAllIpv6SharedNetwork(queryParams *QueryParams) ([]IPv6SharedNetwork, error)
 	SearchHostRecordByAltId(internalId string, ref string, eaNameForInternalId string) (*HostRecord, error)
 	GetHostRecordByRef(ref string) (*HostRecord, error)
 	GetIpAddressFromHostRecord(host HostRecord) (string, error)
@@ -112,33 +209,76 @@ type IBObjectManager interface {
 	GetPTRRecordByRef(ref string) (*RecordPTR, error)
 	GetAllRangeTemplate(queryParams *QueryParams) ([]Rangetemplate, error)
 	GetRangeTemplateByRef(ref string) (*Rangetemplate, error)
+	GetAllIpv6RangeTemplate(queryParams *QueryParams) ([]Ipv6rangetemplate, error)
+	GetIpv6RangeTemplateByRef(ref string) (*Ipv6rangetemplate, error)
+	GetAllIpv6FixedAddressTemplate(queryParams *QueryParams) ([]Ipv6fixedaddresstemplate, error)
+	GetIpv6FixedAddressTemplateByRef(ref string) (*Ipv6fixedaddresstemplate, error)
 	GetSRVRecord(dnsView string, name string, target string, port uint32) (*RecordSRV, error)
 	GetSRVRecordByRef(ref string) (*RecordSRV, error)
 	GetTXTRecord(dnsview string, name string) (*RecordTXT, error)
 	GetTXTRecordByRef(ref string) (*RecordTXT, error)
+	ExportZone(view string, fqdn string) (io.Reader, error)
+	ImportZone(view string, fqdn string, r io.Reader, opts ZoneImportOptions) (*ZoneDiff, error)
+	ExportZoneDS(fqdn string, view string, digestType uint8) ([]ZoneDS, error)
+	ExportDtcBundle(lbdnName string) (*DtcBundle, error)
+	ImportDtcBundle(bundle *DtcBundle) ([]DtcBundleApplied, error)
+	GetZoneAuth() ([]ZoneAuth, error)
 	GetZoneAuthByRef(ref string) (*ZoneAuth, error)
+	GetZoneAuthByFqdn(fqdn string, view string) (*ZoneAuth, error)
+	GetZoneAuthRecords(fqdn string, view string) ([]Allrecords, error)
+	ListZoneRecords(view string, zone string, filters ZoneRecordFilters) ([]ZoneRecordEntry, error)
+	GetZoneDnssecStatus(ref string) (*ZoneDnssecStatus, error)
+	GetZoneTrustAnchors(fqdn string, view string) ([]*Dnssectrustedkey, error)
+	GetRpzZone(fqdn string, view string) (*ZoneRp, error)
+	GetAllRpzZones(view string) ([]ZoneRp, error)
+	GetRpzZoneOrder(view string) ([]string, error)
+	GetRpzRules(rpZone string, view string) ([]RpzRule, error)
+	GetNsgroupByRef(ref string) (*Nsgroup, error)
+	GetAllNsgroup(queryParams *QueryParams) ([]Nsgroup, error)
+	GetNsgroupDelegationByRef(ref string) (*NsgroupDelegation, error)
+	GetAllNsgroupDelegation(queryParams *QueryParams) ([]NsgroupDelegation, error)
+	GetNsgroupForwardingMemberByRef(ref string) (*NsgroupForwardingmember, error)
+	GetAllNsgroupForwardingMember(queryParams *QueryParams) ([]NsgroupForwardingmember, error)
+	GetNsgroupForwardStubServerByRef(ref string) (*NsgroupForwardstubserver, error)
+	GetAllNsgroupForwardStubServer(queryParams *QueryParams) ([]NsgroupForwardstubserver, error)
+	GetNsgroupStubMemberByRef(ref string) (*NsgroupStubmember, error)
+	GetAllNsgroupStubMember(queryParams *QueryParams) ([]NsgroupStubmember, error)
 	GetZoneDelegated(fqdn string) (*ZoneDelegated, error)
 	GetZoneDelegatedByFilters(queryParams *QueryParams) ([]ZoneDelegated, error)
 	GetZoneDelegatedByRef(ref string) (*ZoneDelegated, error)
 	GetZoneForwardByRef(ref string) (*ZoneForward, error)
 	GetZoneForwardFilters(queryParams *QueryParams) ([]ZoneForward, error)
+	GetZoneStubByRef(ref string) (*ZoneStub, error)
+	GetZoneStubFilters(queryParams *QueryParams) ([]ZoneStub, error)
 	GetCapacityReport(name string) ([]CapacityReport, error)
 	GetUpgradeStatus(statusType string) ([]UpgradeStatus, error)
 	GetAllMembers() ([]Member, error)
+	Search(params SearchParams) ([]SearchResult, error)
 	GetGridInfo() ([]Grid, error)
 	GetGridLicense() ([]License, error)
 	SearchObjectByAltId(objType string, internalId string, ref string, eaNameForInternalId string) (interface{}, error)
 	ReleaseIP(netview string, cidr string, ipAddr string, isIPv6 bool, macAddr string) (string, error)
 	UpdateAAAARecord(ref string, netView string, recordName string, cidr string, ipAddr string, useTtl bool, ttl uint32, comment string, setEas EA) (*RecordAAAA, error)
 	UpdateAliasRecord(ref string, name string, dnsView string, targetName string, targetType string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordAlias, error)
+	UpdateCAARecord(ref string, name string, caFlag uint32, caTag string, caValue string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordCaa, error)
+	UpdateNAPTRRecord(ref string, name string, order uint32, preference uint32, flags string, services string, regexp string, replacement string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordNaptr, error)
+	UpdateTLSARecord(ref string, name string, certificateUsage uint32, selector uint32, matchedType uint32, certificateData string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordTlsa, error)
+	UpdateDNAMERecord(ref string, name string, target string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordDname, error)
+	UpdateUnknownRecord(ref string, name string, recordType string, subfieldValues []*Rdatasubfield, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordUnknown, error)
 	UpdateDtcPool(ref string, comment string, name string, lbPreferredMethod string, lbDynamicRatioPreferred map[string]interface{}, servers []*DtcServerLink, monitors []Monitor, lbPreferredTopology *string, lbAlternateMethod string, lbAlternateTopology *string, lbDynamicRatioAlternate map[string]interface{}, eas EA, autoConsolidatedMonitors bool, availability string, consolidatedMonitors []map[string]interface{}, ttl uint32, useTTL bool, disable bool, quorum uint32) (*DtcPool, error)
 	UpdateDtcServer(ref string, comment string, name string, host string, autoCreateHostRecord bool, disable bool, ea EA, monitors []map[string]interface{}, sniHostName string, useSniHostName bool) (*DtcServer, error)
+	UpdateDtcMonitor(ref string, monitor IBObject) (IBObject, error)
+	UpdateDtcRecord(ref string, record IBObject) (IBObject, error)
+	SetDtcMonitorClientCertificate(monitor Monitor, certificate string) (IBObject, error)
+	UpdateDtcTopology(ref string, name string, comment string, rules []DtcTopologyRuleParams, ea EA) (*DtcTopology, error)
+	MoveDtcTopologyRule(topology string, from int, to int) (*DtcTopology, error)
 	UpdateCNAMERecord(ref string, canonical string, recordName string, useTtl bool, ttl uint32, comment string, setEas EA) (*RecordCNAME, error)
 	UpdateDtcLbdn(ref string, name string, authZones []AuthZonesLink, comment string, disable bool, autoConsolidatedMonitors bool, ea EA,
 		lbMethod string, patterns []string, persistence uint32, pools []*DtcPoolLink, priority uint32, topology *string, types []string, ttl uint32, usettl bool) (*DtcLbdn, error)
 	UpdateFixedAddress(fixedAddrRef string, netview string, name string, cidr string, ipAddr string, matchclient string, macOrDuid string, comment string, eas EA, agentCircuitId string, agentRemoteId string, clientIdentifierPrependZero *bool, dhcpClientIdentifier string, disable bool, Options []*Dhcpoption, useOptions bool) (*FixedAddress, error)
 	UpdateHostRecord(hostRref string, enabledns bool, enabledhcp bool, name string, netview string, dnsView string, ipv4cidr string, ipv6cidr string, ipv4Addr string, ipv6Addr string, macAddress string, duid string, useTtl bool, ttl uint32, comment string, eas EA, aliases []string, disable bool) (*HostRecord, error)
 	UpdateIpv4SharedNetwork(ref string, name string, networks []string, networkView string, comment string, eas EA, disable bool, useOptions bool, options []*Dhcpoption) (*SharedNetwork, error)
+	UpdateIpv6SharedNetwork(ref string, name string, networks []string, networkView string, comment string, eas EA, disable bool, useOptions bool, options []*Dhcpoption) (*IPv6SharedNetwork, error)
 	UpdateMXRecord(ref string, dnsView string, fqdn string, mx string, preference uint32, ttl uint32, useTtl bool, comment string, eas EA) (*RecordMX, error)
 	UpdateNetwork(ref string, setEas EA, comment string) (*Network, error)
 	UpdateNetworkContainer(ref string, setEas EA, comment string) (*NetworkContainer, error)
@@ -147,12 +287,37 @@ type IBObjectManager interface {
 	UpdatePTRRecord(ref string, netview string, ptrdname string, name string, cidr string, ipAddr string, useTtl bool, ttl uint32, comment string, setEas EA) (*RecordPTR, error)
 	UpdateRangeTemplate(ref string, name string, numberOfAddresses uint32, offset uint32, comment string, ea EA,
 		options []*Dhcpoption, useOption bool, serverAssociationType string, failOverAssociation string, member *Dhcpmember, cloudApiCompatible bool, msServer string) (*Rangetemplate, error)
+	UpdateIpv6FixedAddressTemplate(ref string, name string, numberOfAddresses uint32, offset uint32, comment string, ea EA,
+		options []*Dhcpoption, useOptions bool, domainName string) (*Ipv6fixedaddresstemplate, error)
+	UpdateIpv6NetworkRange(ref string, comment string, name string, network string, networkView string, addressType string, startAddr string, endAddr string, ipv6StartPrefix string, ipv6EndPrefix string, ipv6PrefixBits uint32, disable bool, eas EA, member *Dhcpmember, serverAssociationType string) (*IPv6Range, error)
+	UpdateIpv6RangeTemplate(ref string, name string, numberOfAddresses uint32, offset uint32, comment string,
+		serverAssociationType string, member *Dhcpmember, delegatedMember *Dhcpmember, cloudApiCompatible bool) (*Ipv6rangetemplate, error)
 	UpdateSRVRecord(ref string, name string, priority uint32, weight uint32, port uint32, target string, ttl uint32, useTtl bool, comment string, eas EA) (*RecordSRV, error)
 	UpdateTXTRecord(ref string, recordName string, text string, ttl uint32, useTtl bool, comment string, eas EA) (*RecordTXT, error)
 	UpdateARecord(ref string, name string, ipAddr string, cidr string, netview string, ttl uint32, useTTL bool, comment string, eas EA) (*RecordA, error)
 	UpdateZoneDelegated(ref string, delegateTo NullableNameServers, comment string, disable bool, locked bool, nsGroup string, delegatedTtl uint32, useDelegatedTtl bool, ea EA) (*ZoneDelegated, error)
 	UpdateNSRecord(ref string, name string, nameServer string, dnsView string, addresses []*ZoneNameServer, msDelegationName string) (*RecordNS, error)
+	UpdateZoneAuth(ref string, upd ZoneAuthUpdate) (*ZoneAuth, error)
+	SignZone(ref string, keyParams *Dnsseckeyparams) error
+	UnsignZone(ref string) error
+	ResignZone(ref string) error
+	RolloverZoneKey(ref string, keyType string) error
+	SetRpzZonePolicy(ref string, policy string, substituteName string) (*ZoneRp, error)
+	SetRpzZoneOrder(view string, zones []string) (*Orderedresponsepolicyzones, error)
+	UpdateDNSView(ref string, upd DNSViewUpdate) (*View, error)
+	CloneDNSView(srcView string, dstView string) (*DNSViewCloneResult, error)
+	UpdateNsgroup(ref string, name string, comment string, gridPrimary []*Memberserver, gridSecondaries []*Memberserver, externalPrimaries []NameServer, externalSecondaries []NameServer, useExternalPrimary bool, isGridDefault bool, ea EA) (*Nsgroup, error)
+	UpdateNsgroupDelegation(ref string, name string, delegateTo []NameServer, comment string, ea EA) (*NsgroupDelegation, error)
+	UpdateNsgroupForwardingMember(ref string, name string, forwardingServers []*Forwardingmemberserver, comment string, ea EA) (*NsgroupForwardingmember, error)
+	UpdateNsgroupForwardStubServer(ref string, name string, externalServers []NameServer, comment string, ea EA) (*NsgroupForwardstubserver, error)
+	UpdateNsgroupStubMember(ref string, name string, stubMembers []*Memberserver, comment string, ea EA) (*NsgroupStubmember, error)
 	UpdateZoneForward(ref string, comment string, disable bool, eas EA, forwardTo NullableNameServers, forwardersOnly bool, forwardingServers *NullableForwardingServers, nsGroup string, externalNsGroup string) (*ZoneForward, error)
+	UpdateZoneStub(ref string, comment string, disable bool, locked bool, eas EA, stubFrom []NameServer, stubMembers []*Memberserver, nsGroup string, externalNsGroup string) (*ZoneStub, error)
+	UpdateObjectIfUnchanged(prev IBObject, ref string, fields []string, obj IBObject) (string, error)
+	ListLocks(objectType string, lockEA string, lockTimeoutEA string, lockTokenEA string) ([]LockInfo, error)
+	BreakLock(info LockInfo, lockEA string, lockTimeoutEA string, lockTokenEA string, reason string, audit LockAuditor) (*LockBreakEvent, error)
+	BreakStaleLocks(objectType string, lockEA string, lockTimeoutEA string, lockTokenEA string, olderThan time.Duration, audit LockAuditor) ([]LockBreakEvent, error)
+	WatchDtcObjectHealth(refs []string, interval time.Duration, stop <-chan struct{}) (<-chan DtcHealthChange, error)
 	GetDnsMember(ref string) ([]Dns, error)
 	UpdateDnsStatus(ref string, status bool) (Dns, error)
 	GetDhcpMember(ref string) ([]Dhcp, error)
@@ -613,10 +778,29 @@ func NewObjectManager(connector IBConnector, cmpType string, tenantID string) IB
 	return objMgr
 }
 
+// wapiConnector returns the *Connector of the object manager, looking
+// through the connectors which wrap it, such as EAValidatingConnector.
+func (objMgr *ObjectManager) wapiConnector() (*Connector, bool) {
+	conn := objMgr.connector
+	for {
+		switch c := conn.(type) {
+		case *Connector:
+			return c, true
+		case interface{ Unwrap() IBConnector }:
+			conn = c.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}
+
 // CreateMultiObject unmarshals the result into slice of maps
 func (objMgr *ObjectManager) CreateMultiObject(req *MultiRequest) ([]map[string]interface{}, error) {
 
-	conn := objMgr.connector.(*Connector)
+	conn, ok := objMgr.wapiConnector()
+	if !ok {
+		return nil, fmt.Errorf("the connector does not support multi-requests")
+	}
 	queryParams := NewQueryParams(false, nil)
 	res, err := conn.makeRequest(CREATE, req, "", queryParams)
 
@@ -0,0 +1,272 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ZoneRecordFilters restricts the records returned by ListZoneRecords.
+type ZoneRecordFilters struct {
+	Types     []string // allrecords types, such as record:a or record:host_ipv4addr
+	NameRegex string
+	Creator   string // STATIC, DYNAMIC or SYSTEM
+}
+
+// ZoneRecordEntry is a record listed by ListZoneRecords. Object holds the
+// record the entry refers to, such as a *RecordA or a *HostRecord, or nil
+// if its type is not supported.
+type ZoneRecordEntry struct {
+	Allrecords
+	Object IBObject
+}
+
+func validateZoneRecordFilters(filters ZoneRecordFilters) error {
+	switch filters.Creator {
+	case "", "STATIC", "DYNAMIC", "SYSTEM":
+	default:
+		return fmt.Errorf("invalid creator '%s', must be STATIC, DYNAMIC or SYSTEM", filters.Creator)
+	}
+	if strings.Contains(filters.NameRegex, ",") {
+		return fmt.Errorf("the name regular expression must not contain a comma")
+	}
+	for _, t := range filters.Types {
+		if t == "" || strings.Contains(t, ",") {
+			return fmt.Errorf("invalid record type '%s'", t)
+		}
+	}
+	return nil
+}
+
+func withReturnFields(obj IBObject, fields ...string) IBObject {
+	obj.SetReturnFields(append(obj.ReturnFields(), fields...))
+	return obj
+}
+
+// zoneRecordObjectTypes holds the record objects ListZoneRecords refers to,
+// with the return fields of all their settings.
+var zoneRecordObjectTypes = map[string]func() IBObject{
+	"record:a":       func() IBObject { return NewEmptyRecordA() },
+	"record:aaaa":    func() IBObject { return NewEmptyRecordAAAA() },
+	"record:caa":     func() IBObject { return NewEmptyRecordCAA() },
+	"record:cname":   func() IBObject { return NewEmptyRecordCNAME() },
+	"record:host":    func() IBObject { return NewEmptyHostRecord() },
+	"record:mx":      func() IBObject { return NewEmptyRecordMX() },
+	"record:naptr":   func() IBObject { return NewEmptyRecordNAPTR() },
+	"record:ns":      func() IBObject { return NewEmptyRecordNS() },
+	"record:ptr":     func() IBObject { return NewEmptyRecordPTR() },
+	"record:srv":     func() IBObject { return NewEmptyRecordSRV() },
+	"record:txt":     func() IBObject { return NewEmptyRecordTXT() },
+	"record:unknown": func() IBObject { return NewEmptyRecordUnknown() },
+	"record:alias": func() IBObject {
+		return withReturnFields(&RecordAlias{}, "comment", "creator", "disable", "extattrs", "ttl", "use_ttl", "zone")
+	},
+	"record:dhcid": func() IBObject {
+		return withReturnFields(&RecordDhcid{}, "creation_time", "creator", "dhcid", "ttl", "use_ttl", "zone")
+	},
+	"record:dname": func() IBObject {
+		return withReturnFields(&RecordDname{}, "comment", "creator", "disable", "extattrs", "ttl", "use_ttl", "zone")
+	},
+	"record:tlsa": func() IBObject {
+		return withReturnFields(&RecordTlsa{}, "certificate_data", "certificate_usage", "comment", "creator", "disable",
+			"extattrs", "matched_type", "selector", "ttl", "use_ttl", "zone")
+	},
+	"sharedrecord:a": func() IBObject {
+		return withReturnFields(&SharedRecordA{}, "comment", "disable", "extattrs", "ttl", "use_ttl")
+	},
+	"sharedrecord:aaaa": func() IBObject {
+		return withReturnFields(&SharedRecordAAAA{}, "comment", "disable", "extattrs", "ttl", "use_ttl")
+	},
+	"sharedrecord:mx": func() IBObject {
+		return withReturnFields(&SharedRecordMX{}, "comment", "disable", "extattrs", "ttl", "use_ttl")
+	},
+	"sharedrecord:txt": func() IBObject {
+		return withReturnFields(&SharedRecordTXT{}, "comment", "disable", "extattrs", "ttl", "use_ttl")
+	},
+}
+
+func newZoneRecordObject(ref string) (IBObject, bool) {
+	newObj, ok := zoneRecordObjectTypes[strings.SplitN(ref, "/", 2)[0]]
+	if !ok {
+		return nil, false
+	}
+	return newObj(), true
+}
+
+// getZoneRecordObjects returns the records of the given type in the zone, by reference.
+func (objMgr *ObjectManager) getZoneRecordObjects(objType string, view string, zone string) (map[string]IBObject, error) {
+	found, err := objMgr.getPagedObjects(zoneRecordObjectTypes[objType](), map[string]string{
+		"zone": zone,
+		"view": view,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]IBObject, len(found))
+	for _, raw := range found {
+		obj := zoneRecordObjectTypes[objType]()
+		if err = json.Unmarshal(raw, obj); err != nil {
+			return nil, fmt.Errorf("cannot decode the %s object: %s", objType, err)
+		}
+		res[reflect.ValueOf(obj).Elem().FieldByName("Ref").String()] = obj
+	}
+	return res, nil
+}
+
+// getRecordObjectsByRef returns the record objects by reference. They are
+// read by batches of wapiPageSize with multi-requests, or one by one
+// if the connector does not support them.
+func (objMgr *ObjectManager) getRecordObjectsByRef(refs []string) (map[string]IBObject, error) {
+	res := make(map[string]IBObject, len(refs))
+	conn, ok := objMgr.wapiConnector()
+	if !ok {
+		for _, ref := range refs {
+			obj, _ := newZoneRecordObject(ref)
+			if err := objMgr.connector.GetObject(obj, ref, NewQueryParams(false, nil), obj); err != nil {
+				return nil, err
+			}
+			res[ref] = obj
+		}
+		return res, nil
+	}
+
+	for start := 0; start < len(refs); start += wapiPageSize {
+		batch := refs[start:]
+		if len(batch) > wapiPageSize {
+			batch = batch[:wapiPageSize]
+		}
+		body := make([]*RequestBody, 0, len(batch))
+		for _, ref := range batch {
+			obj, _ := newZoneRecordObject(ref)
+			body = append(body, &RequestBody{
+				Method: "GET",
+				Object: ref,
+				Args: map[string]string{
+					"_return_fields": strings.Join(obj.ReturnFields(), ","),
+				},
+			})
+		}
+		raw, err := conn.makeRequest(CREATE, NewMultiRequest(body), "", NewQueryParams(false, nil))
+		if err != nil {
+			return nil, fmt.Errorf("error getting the records, err: %s", err)
+		}
+		var results []json.RawMessage
+		if err = json.Unmarshal(raw, &results); err != nil {
+			return nil, err
+		}
+		if len(results) != len(batch) {
+			return nil, fmt.Errorf("error getting the records, %d results received for %d records", len(results), len(batch))
+		}
+		for i, ref := range batch {
+			obj, _ := newZoneRecordObject(ref)
+			if err = json.Unmarshal(results[i], obj); err != nil {
+				return nil, fmt.Errorf("cannot decode the record '%s': %s", ref, err)
+			}
+			res[ref] = obj
+		}
+	}
+	return res, nil
+}
+
+// ListZoneRecords returns every record of the zone, including the ones of
+// host records and of shared record groups, along with the record objects
+// they refer to. Without a name or creator filter the records of each type
+// are fetched at once; the records matching a filter, the shared ones and
+// the ones not found this way are fetched by batches.
+func (objMgr *ObjectManager) ListZoneRecords(view string, zone string, filters ZoneRecordFilters) ([]ZoneRecordEntry, error) {
+	if zone == "" {
+		return nil, fmt.Errorf("FQDN of the zone is required")
+	}
+	if view == "" {
+		view = "default"
+	}
+	if err := validateZoneRecordFilters(filters); err != nil {
+		return nil, err
+	}
+
+	sf := map[string]string{
+		"zone": zone,
+		"view": view,
+	}
+	if len(filters.Types) == 1 {
+		sf["type"] = filters.Types[0]
+	}
+	if filters.NameRegex != "" {
+		sf["name~"] = filters.NameRegex
+	}
+	if filters.Creator != "" {
+		sf["creator"] = filters.Creator
+	}
+	allRecords := &Allrecords{}
+	allRecords.SetReturnFields(append(allRecords.ReturnFields(),
+		"address", "creator", "disable", "record", "ttl"))
+	found, err := objMgr.getPagedObjects(allRecords, sf)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]bool)
+	for _, t := range filters.Types {
+		types[t] = true
+	}
+	var res []ZoneRecordEntry
+	recordTypes := make(map[string]bool)
+	for _, raw := range found {
+		var entry ZoneRecordEntry
+		if err := json.Unmarshal(raw, &entry.Allrecords); err != nil {
+			return nil, err
+		}
+		if len(types) > 1 && !types[entry.Type] {
+			continue
+		}
+		res = append(res, entry)
+		// shared records cannot be searched by zone
+		objType := strings.SplitN(entry.Record, "/", 2)[0]
+		if _, ok := zoneRecordObjectTypes[objType]; ok && strings.HasPrefix(objType, "record:") {
+			recordTypes[objType] = true
+		}
+	}
+
+	objects := make(map[string]IBObject)
+	// the names of the records the zone lists are relative to the zone,
+	// the filtered records are only read by reference
+	if filters.NameRegex != "" || filters.Creator != "" {
+		recordTypes = nil
+	}
+	for objType := range recordTypes {
+		typeObjects, err := objMgr.getZoneRecordObjects(objType, view, zone)
+		if err != nil {
+			return nil, err
+		}
+		for ref, obj := range typeObjects {
+			objects[ref] = obj
+		}
+	}
+	var missing []string
+	for i := range res {
+		ref := res[i].Record
+		if _, ok := zoneRecordObjectTypes[strings.SplitN(ref, "/", 2)[0]]; !ok {
+			continue
+		}
+		if _, ok := objects[ref]; !ok {
+			missing = append(missing, ref)
+			objects[ref] = nil
+		}
+	}
+	if len(missing) > 0 {
+		found, err := objMgr.getRecordObjectsByRef(missing)
+		if err != nil {
+			return nil, err
+		}
+		for ref, obj := range found {
+			objects[ref] = obj
+		}
+	}
+	for i := range res {
+		if obj, ok := objects[res[i].Record]; ok {
+			res[i].Object = obj
+		}
+	}
+	return res, nil
+}
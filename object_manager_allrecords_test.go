@@ -0,0 +1,173 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: zone records listing", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	aRef := "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUsd3d3LDEwLjAuMC4x:www.example.com/default"
+	hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLmFwcA:app.example.com/default"
+	sharedRef := "sharedrecord:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuc3JnLm1haWw:mail/srg"
+
+	Describe("List records of every kind", func() {
+		conn := &fakeConnector{
+			getObjectObj: map[string]interface{}{"Allrecords": nil},
+			resultObject: map[string]interface{}{
+				"Allrecords": wapiPage{Result: []json.RawMessage{
+					json.RawMessage(`{"name":"www","type":"record:a","record":"` + aRef + `","creator":"STATIC"}`),
+					json.RawMessage(`{"name":"app","type":"record:host_ipv4addr","record":"` + hostRef + `","creator":"STATIC"}`),
+					json.RawMessage(`{"name":"mail","type":"sharedrecord:a","record":"` + sharedRef + `","creator":"STATIC"}`),
+					json.RawMessage(`{"name":"","type":"UNSUPPORTED","record":"None","creator":"SYSTEM"}`),
+				}},
+				"RecordA": wapiPage{Result: []json.RawMessage{
+					json.RawMessage(`{"_ref":"` + aRef + `","ipv4addr":"10.0.0.1","name":"www.example.com","view":"default"}`),
+				}},
+				"HostRecord": wapiPage{Result: []json.RawMessage{
+					json.RawMessage(`{"_ref":"` + hostRef + `","name":"app.example.com","view":"default"}`),
+				}},
+				"SharedRecordA": SharedRecordA{Ref: sharedRef, Name: utils.StringPtr("mail")},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should decode the records the entries refer to", func() {
+			res, err := objMgr.ListZoneRecords("", "example.com", ZoneRecordFilters{})
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(4))
+			aRec := NewEmptyRecordA()
+			aRec.Ref, aRec.Ipv4Addr, aRec.Name, aRec.View = aRef, utils.StringPtr("10.0.0.1"), utils.StringPtr("www.example.com"), "default"
+			Expect(res[0].Object).To(Equal(aRec))
+			host, ok := res[1].Object.(*HostRecord)
+			Expect(ok).To(BeTrue())
+			Expect(host.Ref).To(Equal(hostRef))
+			shared, ok := res[2].Object.(*SharedRecordA)
+			Expect(ok).To(BeTrue())
+			Expect(shared.Name).To(Equal(utils.StringPtr("mail")))
+			Expect(res[3].Creator).To(Equal("SYSTEM"))
+			Expect(res[3].Object).To(BeNil())
+		})
+
+		It("should filter the records by type", func() {
+			res, err := objMgr.ListZoneRecords("default", "example.com", ZoneRecordFilters{
+				Types: []string{"record:a", "sharedrecord:a"}})
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0].Type).To(Equal("record:a"))
+			Expect(res[1].Type).To(Equal("sharedrecord:a"))
+		})
+	})
+
+	Describe("Fetch the records with their settings", func() {
+		It("should ask for all the fields and read the shared records in one request", func() {
+			fhr := &fakeSequenceRequestor{res: [][]byte{
+				[]byte(`{"result":[{"name":"www","type":"record:a","record":"` + aRef + `"},` +
+					`{"name":"mail","type":"sharedrecord:a","record":"` + sharedRef + `"}]}`),
+				[]byte(`{"result":[{"_ref":"` + aRef + `","name":"www.example.com","comment":"web","ttl":300,"use_ttl":true}]}`),
+				[]byte(`[{"_ref":"` + sharedRef + `","name":"mail","comment":"shared mail"}]`),
+			}}
+			wrb, _ := NewWapiRequestBuilder(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"})
+			OrigValidateConnector := ValidateConnector
+			ValidateConnector = MockValidateConnector
+			defer func() { ValidateConnector = OrigValidateConnector }()
+			conn, err := NewConnector(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"}, NewTransportConfig("false", 20, 10), wrb, fhr)
+			Expect(err).To(BeNil())
+
+			res, err := NewObjectManager(conn, cmpType, tenantID).ListZoneRecords("default", "example.com", ZoneRecordFilters{})
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(2))
+			Expect(*res[0].Object.(*RecordA).Comment).To(Equal("web"))
+			Expect(*res[1].Object.(*SharedRecordA).Comment).To(Equal("shared mail"))
+
+			Expect(fhr.reqs).To(HaveLen(3))
+			Expect(fhr.reqs[1].URL.Query().Get("_return_fields")).To(ContainSubstring("use_ttl"))
+			var body []map[string]interface{}
+			data, _ := ioutil.ReadAll(fhr.reqs[2].Body)
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			Expect(body).To(Equal([]map[string]interface{}{{
+				"method": "GET",
+				"object": sharedRef,
+				"args": map[string]interface{}{
+					"_return_fields": "ipv4addr,name,shared_record_group,comment,disable,extattrs,ttl,use_ttl",
+				},
+			}}))
+		})
+	})
+
+	Describe("Fetch the filtered records by reference", func() {
+		It("should not read every record of the type in the zone", func() {
+			fhr := &fakeSequenceRequestor{res: [][]byte{
+				[]byte(`{"result":[{"name":"www","type":"record:a","record":"` + aRef + `","creator":"STATIC"}]}`),
+				[]byte(`[{"_ref":"` + aRef + `","name":"www.example.com","comment":"web"}]`),
+			}}
+			wrb, _ := NewWapiRequestBuilder(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"})
+			OrigValidateConnector := ValidateConnector
+			ValidateConnector = MockValidateConnector
+			defer func() { ValidateConnector = OrigValidateConnector }()
+			conn, err := NewConnector(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"}, NewTransportConfig("false", 20, 10), wrb, fhr)
+			Expect(err).To(BeNil())
+
+			res, err := NewObjectManager(conn, cmpType, tenantID).ListZoneRecords("default", "example.com",
+				ZoneRecordFilters{NameRegex: "^www$", Creator: "STATIC"})
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+			Expect(*res[0].Object.(*RecordA).Comment).To(Equal("web"))
+
+			Expect(fhr.reqs).To(HaveLen(2))
+			Expect(fhr.reqs[0].URL.Query().Get("name~")).To(Equal("^www$"))
+			var body []map[string]interface{}
+			data, _ := ioutil.ReadAll(fhr.reqs[1].Body)
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			Expect(body).To(HaveLen(1))
+			Expect(body[0]["object"]).To(Equal(aRef))
+		})
+	})
+
+	Describe("Page through the records", func() {
+		allRecords := &Allrecords{}
+		allRecords.SetReturnFields(append(allRecords.ReturnFields(),
+			"address", "creator", "disable", "record", "ttl"))
+		conn := &fakeConnector{
+			getObjectObj: allRecords,
+			getObjectQueryParams: NewQueryParams(false, map[string]string{
+				"zone":              "example.com",
+				"view":              "internal",
+				"type":              "record:txt",
+				"name~":             "^_acme",
+				"creator":           "DYNAMIC",
+				"_paging":           "1",
+				"_return_as_object": "1",
+				"_max_results":      "1000",
+			}),
+			getObjectRef: "",
+			resultObject: wapiPage{Result: []json.RawMessage{
+				json.RawMessage(`{"name":"_acme-challenge","type":"record:txt","record":"None","creator":"DYNAMIC"}`),
+			}},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass the filters to WAPI", func() {
+			res, err := objMgr.ListZoneRecords("internal", "example.com", ZoneRecordFilters{
+				Types: []string{"record:txt"}, NameRegex: "^_acme", Creator: "DYNAMIC"})
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].Name).To(Equal("_acme-challenge"))
+		})
+
+		It("should reject an invalid creator", func() {
+			_, err := objMgr.ListZoneRecords("internal", "example.com", ZoneRecordFilters{Creator: "IMPORTED"})
+			Expect(err).To(Equal(fmt.Errorf("invalid creator 'IMPORTED', must be STATIC, DYNAMIC or SYSTEM")))
+		})
+	})
+})
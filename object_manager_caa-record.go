@@ -0,0 +1,126 @@
+package ibclient
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var caaTagRegExp = regexp.MustCompile("^[a-zA-Z0-9]{1,15}$")
+
+func validateCaaRecArgs(name string, caFlag uint32, caTag string, caValue string) error {
+	if name == "" {
+		return fmt.Errorf("'name' must not be empty")
+	}
+	if caFlag > 255 {
+		return fmt.Errorf("'ca_flag' must be in the range from 0 to 255 inclusively")
+	}
+	if !caaTagRegExp.MatchString(caTag) {
+		return fmt.Errorf("'ca_tag' must consist of 1 to 15 letters and digits")
+	}
+
+	switch strings.ToLower(caTag) {
+	case "issue", "issuewild":
+		// An empty issuer domain (";") forbids issuance.
+		issuer := strings.TrimSpace(strings.SplitN(caValue, ";", 2)[0])
+		if issuer == "" && !strings.Contains(caValue, ";") {
+			return fmt.Errorf("'ca_value' of the '%s' tag must be an issuer domain name or ';'", caTag)
+		}
+		if issuer != "" {
+			if err := ValidateDomainName(issuer); err != nil {
+				return fmt.Errorf("validation of the issuer domain name in 'ca_value' failed: %s", err)
+			}
+		}
+	case "iodef":
+		if !strings.HasPrefix(caValue, "mailto:") && !strings.HasPrefix(caValue, "http://") &&
+			!strings.HasPrefix(caValue, "https://") {
+			return fmt.Errorf("'ca_value' of the 'iodef' tag must be a mailto:, http:// or https:// URL")
+		}
+	default:
+		if caValue == "" {
+			return fmt.Errorf("'ca_value' must not be empty")
+		}
+	}
+	return nil
+}
+
+func NewEmptyRecordCAA() *RecordCaa {
+	recordCAA := &RecordCaa{}
+	recordCAA.SetReturnFields(append(recordCAA.ReturnFields(), "ca_flag", "ca_tag", "ca_value", "comment", "disable", "extattrs", "ttl", "use_ttl", "zone"))
+	return recordCAA
+}
+
+func NewRecordCAA(name string, dnsView string, caFlag uint32, caTag string, caValue string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) *RecordCaa {
+	recordCAA := NewEmptyRecordCAA()
+	recordCAA.Name = &name
+	if dnsView != "" {
+		recordCAA.View = &dnsView
+	}
+	recordCAA.CaFlag = &caFlag
+	recordCAA.CaTag = &caTag
+	recordCAA.CaValue = &caValue
+	recordCAA.Comment = &comment
+	recordCAA.Disable = &disable
+	recordCAA.Ea = ea
+	recordCAA.Ttl = &ttl
+	recordCAA.UseTtl = &useTtl
+	return recordCAA
+}
+
+// CreateCAARecord creates a CAA-record.
+//
+// Also, it preforms validation of input parameters: name, flag, tag and value.
+func (objMgr *ObjectManager) CreateCAARecord(name string, dnsView string, caFlag uint32, caTag string, caValue string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordCaa, error) {
+	if err := validateCaaRecArgs(name, caFlag, caTag, caValue); err != nil {
+		return nil, err
+	}
+	if dnsView == "" {
+		dnsView = "default"
+	}
+	recordCAA := NewRecordCAA(name, dnsView, caFlag, caTag, caValue, comment, disable, ea, ttl, useTtl)
+	ref, err := objMgr.connector.CreateObject(recordCAA)
+	if err != nil {
+		return nil, err
+	}
+	recordCAA.Ref = ref
+	return recordCAA, nil
+}
+
+func (objMgr *ObjectManager) GetCAARecordByRef(ref string) (*RecordCaa, error) {
+	recordCAA := NewEmptyRecordCAA()
+	err := objMgr.connector.GetObject(recordCAA, ref, NewQueryParams(false, nil), &recordCAA)
+	if err != nil {
+		return nil, err
+	}
+	return recordCAA, nil
+}
+
+func (objMgr *ObjectManager) GetAllCAARecord(queryParams *QueryParams) ([]RecordCaa, error) {
+	var res []RecordCaa
+	recordCAA := NewEmptyRecordCAA()
+	err := objMgr.connector.GetObject(recordCAA, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting CAA Record: %s", err)
+	}
+	return res, nil
+}
+
+// UpdateCAARecord updates the CAA-record.
+//
+// Also, it preforms validation of input parameters: name, flag, tag and value.
+func (objMgr *ObjectManager) UpdateCAARecord(ref string, name string, caFlag uint32, caTag string, caValue string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordCaa, error) {
+	if err := validateCaaRecArgs(name, caFlag, caTag, caValue); err != nil {
+		return nil, err
+	}
+	recordCAA := NewRecordCAA(name, "", caFlag, caTag, caValue, comment, disable, ea, ttl, useTtl)
+	updatedRef, err := objMgr.connector.UpdateObject(recordCAA, ref)
+	if err != nil {
+		return nil, err
+	}
+	recordCAA.Ref = updatedRef
+	return recordCAA, nil
+}
+
+func (objMgr *ObjectManager) DeleteCAARecord(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
@@ -0,0 +1,71 @@
+package ibclient
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Record CAA", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "example.com"
+	dnsView := "default"
+	comment := "certified by LE"
+	ea := EA{"Site": "LA"}
+	ttl := uint32(3600)
+	fakeRefReturn := "record:caa/ZG5zLmJpbmRfY2FhJC5fZGVmYXVsdC5jb20uZXhhbXBsZQ:example.com/default"
+
+	Describe("Create CAA Record", func() {
+		conn := &fakeConnector{
+			createObjectObj: NewRecordCAA(name, dnsView, 0, "issue", "letsencrypt.org", comment, false, ea, ttl, true),
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected CAA record to CreateObject", func() {
+			recordCAA, err := objMgr.CreateCAARecord(name, "", 0, "issue", "letsencrypt.org", comment, false, ea, ttl, true)
+			Expect(err).To(BeNil())
+			Expect(recordCAA.Ref).To(Equal(fakeRefReturn))
+		})
+	})
+
+	Describe("Update CAA Record", func() {
+		conn := &fakeConnector{
+			updateObjectObj: NewRecordCAA(name, "", 128, "iodef", "mailto:security@example.com", comment, false, ea, ttl, true),
+			updateObjectRef: fakeRefReturn,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected CAA record to UpdateObject", func() {
+			recordCAA, err := objMgr.UpdateCAARecord(fakeRefReturn, name, 128, "iodef", "mailto:security@example.com", comment, false, ea, ttl, true)
+			Expect(err).To(BeNil())
+			Expect(recordCAA.Ref).To(Equal(fakeRefReturn))
+		})
+	})
+
+	Describe("Negative cases", func() {
+		objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+
+		It("should reject an out of range flag", func() {
+			_, err := objMgr.CreateCAARecord(name, dnsView, 256, "issue", "letsencrypt.org", "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("'ca_flag' must be in the range from 0 to 255 inclusively")))
+		})
+
+		It("should reject an invalid tag", func() {
+			_, err := objMgr.CreateCAARecord(name, dnsView, 0, "issue-wild", "letsencrypt.org", "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("'ca_tag' must consist of 1 to 15 letters and digits")))
+		})
+
+		It("should reject an iodef value which is not a URL", func() {
+			_, err := objMgr.CreateCAARecord(name, dnsView, 0, "iodef", "security@example.com", "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("'ca_value' of the 'iodef' tag must be a mailto:, http:// or https:// URL")))
+		})
+
+		It("should accept an issue value forbidding issuance", func() {
+			Expect(validateCaaRecArgs(name, 0, "issuewild", ";")).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// wapiPageSize is the number of objects requested per page.
+const wapiPageSize = 1000
+
+// wapiPage is a page of objects returned by WAPI when paging is requested.
+type wapiPage struct {
+	Result     []json.RawMessage `json:"result"`
+	NextPageId string            `json:"next_page_id,omitempty"`
+}
+
+// getObjects fetches the objects matching the query parameters, an empty
+// result not being an error.
+func (objMgr *ObjectManager) getObjects(obj IBObject, qp *QueryParams, res interface{}) error {
+	err := objMgr.connector.GetObject(obj, "", qp, res)
+	if _, ok := err.(*NotFoundError); ok {
+		return nil
+	}
+	return err
+}
+
+// getPagedObjects returns all the objects matching the search fields, reading them page by page.
+func (objMgr *ObjectManager) getPagedObjects(obj IBObject, sf map[string]string) ([]json.RawMessage, error) {
+	var res []json.RawMessage
+	pageId := ""
+	for {
+		pageSf := map[string]string{
+			"_paging":           "1",
+			"_return_as_object": "1",
+			"_max_results":      strconv.Itoa(wapiPageSize),
+		}
+		for k, v := range sf {
+			pageSf[k] = v
+		}
+		if pageId != "" {
+			pageSf["_page_id"] = pageId
+		}
+		var page wapiPage
+		if err := objMgr.getObjects(obj, NewQueryParams(false, pageSf), &page); err != nil {
+			return nil, err
+		}
+		res = append(res, page.Result...)
+		if page.NextPageId == "" {
+			return res, nil
+		}
+		pageId = page.NextPageId
+	}
+}
+
+// getAllObjects reads all the objects matching the search fields page by
+// page, and decodes them into res, a pointer to a slice of objects.
+func (objMgr *ObjectManager) getAllObjects(obj IBObject, sf map[string]string, res interface{}) error {
+	found, err := objMgr.getPagedObjects(obj, sf)
+	if err != nil {
+		return err
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(found)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, res)
+}
+
+func strValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func uint32Value(u *uint32) uint32 {
+	if u == nil {
+		return 0
+	}
+	return *u
+}
+
+// eaObject is an object of any type read for its extensible attributes only.
+type eaObject struct {
+	IBBase     `json:"-"`
+	objectType string
+	Ref        string `json:"_ref"`
+	Ea         EA     `json:"extattrs"`
+}
+
+func (o *eaObject) ObjectType() string {
+	return o.objectType
+}
+
+func newEAObject(objectType string) *eaObject {
+	obj := &eaObject{objectType: objectType}
+	obj.returnFields = []string{"extattrs"}
+	return obj
+}
+
+// eaUpdate adds, replaces or removes extensible attributes of an object, keeping the other ones.
+type eaUpdate struct {
+	IBBase     `json:"-"`
+	objectType string
+	EaAdd      EA                  `json:"extattrs+,omitempty"`
+	EaRemove   map[string]struct{} `json:"extattrs-,omitempty"`
+}
+
+func (u *eaUpdate) ObjectType() string {
+	return u.objectType
+}
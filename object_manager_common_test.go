@@ -0,0 +1,45 @@
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: common helpers", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	Describe("Read all the objects page by page", func() {
+		It("should follow the next page IDs and decode every object", func() {
+			fhr := &fakeSequenceRequestor{res: [][]byte{
+				[]byte(`{"result":[{"_ref":"record:a/ZG5zLmJpbmRfYSQx:a1.example.com/default","name":"a1.example.com"}],"next_page_id":"789c"}`),
+				[]byte(`{"result":[{"_ref":"record:a/ZG5zLmJpbmRfYSQy:a2.example.com/default","name":"a2.example.com"}]}`),
+			}}
+			wrb, _ := NewWapiRequestBuilder(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"})
+			OrigValidateConnector := ValidateConnector
+			ValidateConnector = MockValidateConnector
+			defer func() { ValidateConnector = OrigValidateConnector }()
+			conn, err := NewConnector(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"}, NewTransportConfig("false", 20, 10), wrb, fhr)
+			Expect(err).To(BeNil())
+			objMgr := NewObjectManager(conn, cmpType, tenantID).(*ObjectManager)
+
+			var recs []RecordA
+			err = objMgr.getAllObjects(NewEmptyRecordA(), map[string]string{"zone": "example.com"}, &recs)
+			Expect(err).To(BeNil())
+			Expect(recs).To(HaveLen(2))
+			Expect(*recs[1].Name).To(Equal("a2.example.com"))
+			Expect(fhr.reqs).To(HaveLen(2))
+			Expect(fhr.reqs[0].URL.Query().Get("_max_results")).To(Equal("1000"))
+			Expect(fhr.reqs[1].URL.Query().Get("_page_id")).To(Equal("789c"))
+		})
+	})
+
+	Describe("Dereference optional values", func() {
+		It("should return the zero value for nil", func() {
+			Expect(strValue(nil)).To(BeEmpty())
+			Expect(uint32Value(nil)).To(BeZero())
+		})
+	})
+})
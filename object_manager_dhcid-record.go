@@ -0,0 +1,35 @@
+package ibclient
+
+import "fmt"
+
+// DHCID-records are created by the DHCP server on dynamic DNS updates,
+// WAPI only allows to read and delete them.
+
+func NewEmptyRecordDHCID() *RecordDhcid {
+	recordDHCID := &RecordDhcid{}
+	recordDHCID.SetReturnFields(append(recordDHCID.ReturnFields(), "creation_time", "creator", "dhcid", "dns_name", "ttl", "use_ttl", "zone"))
+	return recordDHCID
+}
+
+func (objMgr *ObjectManager) GetDHCIDRecordByRef(ref string) (*RecordDhcid, error) {
+	recordDHCID := NewEmptyRecordDHCID()
+	err := objMgr.connector.GetObject(recordDHCID, ref, NewQueryParams(false, nil), &recordDHCID)
+	if err != nil {
+		return nil, err
+	}
+	return recordDHCID, nil
+}
+
+func (objMgr *ObjectManager) GetAllDHCIDRecord(queryParams *QueryParams) ([]RecordDhcid, error) {
+	var res []RecordDhcid
+	recordDHCID := NewEmptyRecordDHCID()
+	err := objMgr.connector.GetObject(recordDHCID, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting DHCID Record: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) DeleteDHCIDRecord(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
@@ -0,0 +1,84 @@
+package ibclient
+
+import "fmt"
+
+func validateDnameRecArgs(name string, target string) error {
+	if name == "" || target == "" {
+		return fmt.Errorf("name and target are required to create a DNAME Record")
+	}
+	if err := ValidateDomainName(target); err != nil {
+		return fmt.Errorf("validation of 'target' value failed: %s", err)
+	}
+	return nil
+}
+
+func NewEmptyRecordDNAME() *RecordDname {
+	recordDNAME := &RecordDname{}
+	recordDNAME.SetReturnFields(append(recordDNAME.ReturnFields(), "comment", "disable", "extattrs", "ttl", "use_ttl", "zone"))
+	return recordDNAME
+}
+
+func NewRecordDNAME(name string, dnsView string, target string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) *RecordDname {
+	recordDNAME := NewEmptyRecordDNAME()
+	recordDNAME.Name = &name
+	recordDNAME.View = dnsView
+	recordDNAME.Target = &target
+	recordDNAME.Comment = &comment
+	recordDNAME.Disable = &disable
+	recordDNAME.Ea = ea
+	recordDNAME.Ttl = &ttl
+	recordDNAME.UseTtl = &useTtl
+	return recordDNAME
+}
+
+func (objMgr *ObjectManager) CreateDNAMERecord(name string, dnsView string, target string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordDname, error) {
+	if err := validateDnameRecArgs(name, target); err != nil {
+		return nil, err
+	}
+	if dnsView == "" {
+		dnsView = "default"
+	}
+	recordDNAME := NewRecordDNAME(name, dnsView, target, comment, disable, ea, ttl, useTtl)
+	ref, err := objMgr.connector.CreateObject(recordDNAME)
+	if err != nil {
+		return nil, err
+	}
+	recordDNAME.Ref = ref
+	return recordDNAME, nil
+}
+
+func (objMgr *ObjectManager) GetDNAMERecordByRef(ref string) (*RecordDname, error) {
+	recordDNAME := NewEmptyRecordDNAME()
+	err := objMgr.connector.GetObject(recordDNAME, ref, NewQueryParams(false, nil), &recordDNAME)
+	if err != nil {
+		return nil, err
+	}
+	return recordDNAME, nil
+}
+
+func (objMgr *ObjectManager) GetAllDNAMERecord(queryParams *QueryParams) ([]RecordDname, error) {
+	var res []RecordDname
+	recordDNAME := NewEmptyRecordDNAME()
+	err := objMgr.connector.GetObject(recordDNAME, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting DNAME Record: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) UpdateDNAMERecord(ref string, name string, target string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordDname, error) {
+	if err := validateDnameRecArgs(name, target); err != nil {
+		return nil, err
+	}
+	recordDNAME := NewRecordDNAME(name, "", target, comment, disable, ea, ttl, useTtl)
+	updatedRef, err := objMgr.connector.UpdateObject(recordDNAME, ref)
+	if err != nil {
+		return nil, err
+	}
+	recordDNAME.Ref = updatedRef
+	return recordDNAME, nil
+}
+
+func (objMgr *ObjectManager) DeleteDNAMERecord(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
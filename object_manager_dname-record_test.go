@@ -0,0 +1,36 @@
+package ibclient
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Record DNAME", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "old.example.com"
+	target := "new.example.com"
+	fakeRefReturn := "record:dname/ZG5zLmJpbmRfZG5hbWUkLl9kZWZhdWx0LmNvbS5leGFtcGxlLm9sZA:old.example.com/default"
+
+	Describe("Update DNAME Record", func() {
+		conn := &fakeConnector{
+			updateObjectObj: NewRecordDNAME(name, "", target, "moved", false, nil, 0, false),
+			updateObjectRef: fakeRefReturn,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected DNAME record to UpdateObject", func() {
+			recordDNAME, err := objMgr.UpdateDNAMERecord(fakeRefReturn, name, target, "moved", false, nil, 0, false)
+			Expect(err).To(BeNil())
+			Expect(recordDNAME.Ref).To(Equal(fakeRefReturn))
+		})
+
+		It("should require a target", func() {
+			_, err := objMgr.CreateDNAMERecord(name, "default", "", "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("name and target are required to create a DNAME Record")))
+		})
+	})
+})
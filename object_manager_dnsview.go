@@ -1,6 +1,12 @@
 package ibclient
 
-import "fmt"
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+)
 
 func (objMgr *ObjectManager) GetDNSView(name string) (*View, error) {
 	var res []View
@@ -18,3 +24,337 @@ func (objMgr *ObjectManager) GetDNSView(name string) (*View, error) {
 
 	return &res[0], nil
 }
+
+// DNSViewParams holds the settings of a DNS view to be created.
+type DNSViewParams struct {
+	Name        string
+	NetworkView string // "default" if empty
+	// Recursion enables or disables recursive queries in the view;
+	// nil inherits the grid setting.
+	Recursion         *bool
+	MatchClients      []*Addressac
+	MatchDestinations []*Addressac
+	Comment           string
+	Disable           bool
+	Ea                EA
+}
+
+// DNSViewUpdate holds the changes to apply to a DNS view; nil fields are
+// left unchanged. Setting MatchClients or MatchDestinations to an empty
+// slice removes the ACL, making the view match any client or destination.
+type DNSViewUpdate struct {
+	Name              *string
+	Recursion         *bool
+	MatchClients      *[]*Addressac
+	MatchDestinations *[]*Addressac
+	Comment           *string
+	Disable           *bool
+	Ea                EA
+}
+
+// dnsViewUpdate is the subset of the DNS view sent on update. It keeps the
+// ACLs behind pointers so that an empty ACL is sent rather than omitted.
+type dnsViewUpdate struct {
+	IBBase            `json:"-"`
+	Name              *string       `json:"name,omitempty"`
+	Recursion         *bool         `json:"recursion,omitempty"`
+	UseRecursion      *bool         `json:"use_recursion,omitempty"`
+	MatchClients      *[]*Addressac `json:"match_clients,omitempty"`
+	MatchDestinations *[]*Addressac `json:"match_destinations,omitempty"`
+	Comment           *string       `json:"comment,omitempty"`
+	Disable           *bool         `json:"disable,omitempty"`
+	Ea                EA            `json:"extattrs,omitempty"`
+}
+
+func (dnsViewUpdate) ObjectType() string {
+	return "view"
+}
+
+// DNSViewCloneResult reports what was copied by CloneDNSView, and what was not.
+type DNSViewCloneResult struct {
+	ZonesCreated   []string
+	RecordsCreated int
+	RecordsUpdated int
+	SkippedZones   []DNSViewCloneSkippedZone // zones of the source view which are not authoritative zones
+	SkippedRecords []ZoneFileRecord          // records which cannot be copied through the object manager
+}
+
+// DNSViewCloneSkippedZone is a zone of the source view which CloneDNSView does not copy.
+type DNSViewCloneSkippedZone struct {
+	ObjectType string // zone_forward, zone_stub, zone_delegated or zone_rp
+	Fqdn       string
+	Ref        string
+}
+
+func newDNSViewWithSettings() *View {
+	view := NewEmptyDNSView()
+	view.SetReturnFields(append(view.ReturnFields(),
+		"disable", "is_default", "recursion", "use_recursion", "match_clients", "match_destinations"))
+	return view
+}
+
+func validateDNSViewACL(field string, acl []*Addressac) error {
+	for _, ac := range acl {
+		if ac == nil {
+			return fmt.Errorf("'%s' must not contain empty entries", field)
+		}
+		switch ac.Permission {
+		case "ALLOW", "DENY":
+		default:
+			return fmt.Errorf("invalid permission '%s' in '%s', must be ALLOW or DENY", ac.Permission, field)
+		}
+		if ac.TsigKey != "" || ac.TsigKeyName != "" {
+			continue
+		}
+		if strings.EqualFold(ac.Address, "any") || net.ParseIP(ac.Address) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(ac.Address); err != nil {
+			return fmt.Errorf("'%s' in '%s' is neither an IP address, a network nor 'Any'", ac.Address, field)
+		}
+	}
+	return nil
+}
+
+// CreateDNSView creates a DNS view associated with a network view.
+func (objMgr *ObjectManager) CreateDNSView(params DNSViewParams) (*View, error) {
+	if params.Name == "" {
+		return nil, fmt.Errorf("DNS view's name is required to create a DNS view")
+	}
+	if err := validateDNSViewACL("match_clients", params.MatchClients); err != nil {
+		return nil, err
+	}
+	if err := validateDNSViewACL("match_destinations", params.MatchDestinations); err != nil {
+		return nil, err
+	}
+
+	view := newDNSViewWithSettings()
+	view.Name = &params.Name
+	netView := params.NetworkView
+	if netView == "" {
+		netView = "default"
+	}
+	view.NetworkView = &netView
+	if params.Recursion != nil {
+		useRecursion := true
+		view.Recursion = params.Recursion
+		view.UseRecursion = &useRecursion
+	}
+	view.MatchClients = params.MatchClients
+	view.MatchDestinations = params.MatchDestinations
+	if params.Comment != "" {
+		view.Comment = &params.Comment
+	}
+	view.Disable = &params.Disable
+	view.Ea = params.Ea
+
+	ref, err := objMgr.connector.CreateObject(view)
+	if err != nil {
+		return nil, err
+	}
+	view.Ref = ref
+	return view, nil
+}
+
+// GetDNSViewByRef returns the DNS view with its recursion and ACL settings.
+func (objMgr *ObjectManager) GetDNSViewByRef(ref string) (*View, error) {
+	view := newDNSViewWithSettings()
+	err := objMgr.connector.GetObject(view, ref, NewQueryParams(false, nil), &view)
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// UpdateDNSView applies the given changes to the DNS view.
+func (objMgr *ObjectManager) UpdateDNSView(ref string, upd DNSViewUpdate) (*View, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty reference to an object is not allowed")
+	}
+	if upd.Name != nil && *upd.Name == "" {
+		return nil, fmt.Errorf("DNS view's name must not be empty")
+	}
+	if upd.MatchClients != nil {
+		if err := validateDNSViewACL("match_clients", *upd.MatchClients); err != nil {
+			return nil, err
+		}
+	}
+	if upd.MatchDestinations != nil {
+		if err := validateDNSViewACL("match_destinations", *upd.MatchDestinations); err != nil {
+			return nil, err
+		}
+	}
+
+	view := &dnsViewUpdate{
+		Name:              upd.Name,
+		MatchClients:      upd.MatchClients,
+		MatchDestinations: upd.MatchDestinations,
+		Comment:           upd.Comment,
+		Disable:           upd.Disable,
+		Ea:                upd.Ea,
+	}
+	if upd.Recursion != nil {
+		useRecursion := true
+		view.Recursion = upd.Recursion
+		view.UseRecursion = &useRecursion
+	}
+	newRef, err := objMgr.connector.UpdateObject(view, ref)
+	if err != nil {
+		return nil, err
+	}
+	return objMgr.GetDNSViewByRef(newRef)
+}
+
+// DeleteDNSView deletes the DNS view together with its zones and records.
+func (objMgr *ObjectManager) DeleteDNSView(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+// cloneZoneAuthParams returns the settings to create a copy of the zone in another view.
+func cloneZoneAuthParams(zone ZoneAuth, view string) ZoneAuthParams {
+	params := ZoneAuthParams{
+		Fqdn:       zone.Fqdn,
+		View:       view,
+		ZoneFormat: zone.ZoneFormat,
+		Ea:         zone.Ea,
+	}
+	if zone.Prefix != nil {
+		params.Prefix = *zone.Prefix
+	}
+	// the servers of a zone served by a name server group are those of the group
+	if zone.NsGroup != nil && *zone.NsGroup != "" {
+		params.NsGroup = *zone.NsGroup
+	} else {
+		params.GridPrimary = zone.GridPrimary
+		params.GridSecondaries = zone.GridSecondaries
+	}
+	soa := &ZoneAuthSoa{}
+	if zone.UseGridZoneTimer != nil && *zone.UseGridZoneTimer {
+		soa.DefaultTtl = zone.SoaDefaultTtl
+		soa.Expire = zone.SoaExpire
+		soa.NegativeTtl = zone.SoaNegativeTtl
+		soa.Refresh = zone.SoaRefresh
+		soa.Retry = zone.SoaRetry
+	}
+	if zone.UseSoaEmail != nil && *zone.UseSoaEmail {
+		soa.Email = zone.SoaEmail
+	}
+	if *soa != (ZoneAuthSoa{}) {
+		params.Soa = soa
+	}
+	if zone.Comment != nil {
+		params.Comment = *zone.Comment
+	}
+	if zone.Disable != nil {
+		params.Disable = *zone.Disable
+	}
+	return params
+}
+
+// getSkippedCloneZones returns the zones of the view which CloneDNSView does not copy.
+func (objMgr *ObjectManager) getSkippedCloneZones(view string) ([]DNSViewCloneSkippedZone, error) {
+	var skipped []DNSViewCloneSkippedZone
+	sf := map[string]string{"view": view}
+	for _, obj := range []IBObject{NewEmptyZoneForward(), NewEmptyZoneStub(), NewEmptyZoneDelegated(), NewEmptyZoneRp()} {
+		res := reflect.New(reflect.SliceOf(reflect.TypeOf(obj).Elem()))
+		if err := objMgr.getAllObjects(obj, sf, res.Interface()); err != nil {
+			return nil, err
+		}
+		for i := 0; i < res.Elem().Len(); i++ {
+			zone := res.Elem().Index(i)
+			skipped = append(skipped, DNSViewCloneSkippedZone{
+				ObjectType: obj.ObjectType(),
+				Fqdn:       zone.FieldByName("Fqdn").String(),
+				Ref:        zone.FieldByName("Ref").String(),
+			})
+		}
+	}
+	return skipped, nil
+}
+
+// CloneDNSView copies the authoritative zones of the source DNS view and
+// their records into the target view, which must already exist. Zones and
+// records present in both views are kept, only the TTLs of the records
+// being aligned with the source. Host records are copied as plain A, AAAA
+// and CNAME records so that the copies do not claim IPAM addresses.
+// Forward, stub, delegated and response policy zones, and the records
+// which cannot be created through the object manager, are not copied;
+// they are listed in the result.
+func (objMgr *ObjectManager) CloneDNSView(srcView string, dstView string) (*DNSViewCloneResult, error) {
+	if srcView == "" || dstView == "" {
+		return nil, fmt.Errorf("source and target DNS views are required")
+	}
+	if srcView == dstView {
+		return nil, fmt.Errorf("source and target DNS views must differ")
+	}
+	for _, name := range []string{srcView, dstView} {
+		if _, err := objMgr.GetDNSView(name); err != nil {
+			return nil, err
+		}
+	}
+
+	getZones := func(view string) ([]ZoneAuth, error) {
+		var res []ZoneAuth
+		err := objMgr.getAllObjects(NewEmptyZoneAuth(), map[string]string{"view": view}, &res)
+		return res, err
+	}
+	srcZones, err := getZones(srcView)
+	if err != nil {
+		return nil, err
+	}
+	dstZones, err := getZones(dstView)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(dstZones))
+	for _, zone := range dstZones {
+		existing[zone.Fqdn] = true
+	}
+	// parent zones are created before their subzones
+	sort.SliceStable(srcZones, func(i, j int) bool {
+		return strings.Count(srcZones[i].Fqdn, ".") < strings.Count(srcZones[j].Fqdn, ".")
+	})
+
+	result := &DNSViewCloneResult{}
+	if result.SkippedZones, err = objMgr.getSkippedCloneZones(srcView); err != nil {
+		return nil, err
+	}
+	for _, zone := range srcZones {
+		if !existing[zone.Fqdn] {
+			if _, err = objMgr.CreateZoneAuthWithParams(cloneZoneAuthParams(zone, dstView)); err != nil {
+				return result, fmt.Errorf("failed to create zone '%s' in DNS view '%s': %s", zone.Fqdn, dstView, err)
+			}
+			result.ZonesCreated = append(result.ZonesCreated, zone.Fqdn)
+		}
+
+		fqdn := normZoneName(zone.Fqdn)
+		srcRecords, err := objMgr.getZoneFileRecords(srcView, fqdn)
+		if err != nil {
+			return result, err
+		}
+		for i := range srcRecords {
+			srcRecords[i].Ref, srcRecords[i].Host = "", ""
+		}
+		dstRecords, err := objMgr.getZoneFileRecords(dstView, fqdn)
+		if err != nil {
+			return result, err
+		}
+		diff := diffZoneRecords(fqdn, dstRecords, srcRecords)
+		result.SkippedRecords = append(result.SkippedRecords, diff.Skipped...)
+		for _, rec := range diff.Create {
+			if err = objMgr.createZoneFileRecord(dstView, rec); err != nil {
+				return result, fmt.Errorf("failed to create record '%s': %s", rec, err)
+			}
+			result.RecordsCreated++
+		}
+		for _, rec := range diff.Update {
+			useTtl := rec.Ttl != nil
+			upd := &zoneRecordTtl{objectType: strings.SplitN(rec.Ref, "/", 2)[0], Ttl: rec.Ttl, UseTtl: &useTtl}
+			if _, err = objMgr.connector.UpdateObject(upd, rec.Ref); err != nil {
+				return result, fmt.Errorf("failed to update record '%s': %s", rec, err)
+			}
+			result.RecordsUpdated++
+		}
+	}
+	return result, nil
+}
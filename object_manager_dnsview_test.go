@@ -0,0 +1,180 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// cloneViewConnector keeps in memory the zones and records of each DNS view,
+// keyed by view and by object type name.
+type cloneViewConnector struct {
+	fakeConnector
+	views   map[string]map[string]interface{}
+	created []IBObject
+}
+
+func (c *cloneViewConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res interface{}) error {
+	sf := qp.searchFields
+	if _, ok := obj.(*View); ok {
+		if _, ok = c.views[sf["name"]]; ok {
+			*res.(*[]View) = []View{{Ref: "view/ZG5zLnZpZXckLjE:" + sf["name"] + "/false"}}
+		}
+		return nil
+	}
+	val, ok := c.views[sf["view"]][reflect.TypeOf(obj).Elem().Name()]
+	if !ok {
+		return NewNotFoundError("not found")
+	}
+	if page, ok := res.(*wapiPage); ok {
+		*page = newFakeWapiPage(val)
+		return nil
+	}
+	reflect.ValueOf(res).Elem().Set(reflect.ValueOf(val))
+	return nil
+}
+
+func (c *cloneViewConnector) CreateObject(obj IBObject) (string, error) {
+	c.created = append(c.created, obj)
+	return obj.ObjectType() + "/ZG5zLnpvbmUkLjE:created", nil
+}
+
+var _ = Describe("Object Manager: DNS view", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "internal"
+	netView := "corp"
+	ref := "view/ZG5zLnZpZXckLjE:internal/false"
+
+	Describe("Create DNS view", func() {
+		recursion := true
+		useRecursion := true
+		disable := false
+		clients := []*Addressac{{Address: "10.0.0.0/8", Permission: "ALLOW"}, {Address: "Any", Permission: "DENY"}}
+
+		expected := newDNSViewWithSettings()
+		expected.Name = &name
+		expected.NetworkView = &netView
+		expected.Recursion = &recursion
+		expected.UseRecursion = &useRecursion
+		expected.MatchClients = clients
+		expected.Disable = &disable
+		expected.Ea = EA{"Site": "Blr"}
+
+		conn := &fakeConnector{
+			createObjectObj: expected,
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected View Object to CreateObject", func() {
+			view, err := objMgr.CreateDNSView(DNSViewParams{
+				Name:         name,
+				NetworkView:  netView,
+				Recursion:    &recursion,
+				MatchClients: clients,
+				Ea:           EA{"Site": "Blr"},
+			})
+			Expect(err).To(BeNil())
+			Expect(view.Ref).To(Equal(ref))
+		})
+
+		It("should reject an invalid ACL", func() {
+			_, err := objMgr.CreateDNSView(DNSViewParams{
+				Name:              name,
+				MatchDestinations: []*Addressac{{Address: "10.0.0", Permission: "ALLOW"}},
+			})
+			Expect(err).To(Equal(fmt.Errorf("'10.0.0' in 'match_destinations' is neither an IP address, a network nor 'Any'")))
+			_, err = objMgr.CreateDNSView(DNSViewParams{
+				Name:         name,
+				MatchClients: []*Addressac{{Address: "10.0.0.1", Permission: "PERMIT"}},
+			})
+			Expect(err).To(Equal(fmt.Errorf("invalid permission 'PERMIT' in 'match_clients', must be ALLOW or DENY")))
+		})
+	})
+
+	Describe("Update DNS view", func() {
+		noClients := []*Addressac{}
+		comment := "split horizon"
+		conn := &fakeConnector{
+			updateObjectObj:      &dnsViewUpdate{MatchClients: &noClients, Comment: &comment},
+			updateObjectRef:      ref,
+			getObjectObj:         newDNSViewWithSettings(),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         ref,
+			resultObject:         &View{Ref: ref, Name: &name, Comment: &comment},
+			fakeRefReturn:        ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should send the changes and return the updated view", func() {
+			view, err := objMgr.UpdateDNSView(ref, DNSViewUpdate{MatchClients: &noClients, Comment: &comment})
+			Expect(err).To(BeNil())
+			Expect(*view.Comment).To(Equal(comment))
+		})
+
+		It("should send an empty ACL to remove it", func() {
+			data, err := json.Marshal(&dnsViewUpdate{MatchClients: &noClients})
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(`{"match_clients":[]}`))
+		})
+	})
+
+	Describe("Clone zone settings", func() {
+		It("should copy the settings of the zone into the target view", func() {
+			useTimer := true
+			refresh := uint32(3600)
+			zone := ZoneAuth{
+				Fqdn:             "example.com",
+				View:             utils.StringPtr("default"),
+				ZoneFormat:       "FORWARD",
+				NsGroup:          utils.StringPtr("ns-group-1"),
+				GridPrimary:      []*Memberserver{{Name: "ns1.example.com"}},
+				UseGridZoneTimer: &useTimer,
+				SoaRefresh:       &refresh,
+				Comment:          utils.StringPtr("corp"),
+			}
+			Expect(cloneZoneAuthParams(zone, name)).To(Equal(ZoneAuthParams{
+				Fqdn:       "example.com",
+				View:       name,
+				ZoneFormat: "FORWARD",
+				NsGroup:    "ns-group-1",
+				Soa:        &ZoneAuthSoa{Refresh: &refresh},
+				Comment:    "corp",
+			}))
+		})
+
+		It("should reject cloning a view into itself", func() {
+			objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+			_, err := objMgr.CloneDNSView(name, name)
+			Expect(err).To(Equal(fmt.Errorf("source and target DNS views must differ")))
+		})
+
+		It("should report the zones and records which are not copied", func() {
+			fwdRef := "zone_forward/ZG5zLnpvbmUkLjEuY29tLmNvcnA:corp.com/default"
+			rpRef := "zone_rp/ZG5zLnpvbmUkLjIucnB6:rpz.local/default"
+			conn := &cloneViewConnector{views: map[string]map[string]interface{}{
+				"default": {
+					"ZoneAuth":    []ZoneAuth{{Ref: "zone_auth/ZG5zLnpvbmUkLjEuY29tLmV4YW1wbGU:example.com/default", Fqdn: "example.com"}},
+					"ZoneForward": []ZoneForward{{Ref: fwdRef, Fqdn: "corp.com"}},
+					"ZoneRp":      []ZoneRp{{Ref: rpRef, Fqdn: "rpz.local"}},
+					"RecordNS":    []RecordNS{{Name: "sub.example.com", Nameserver: utils.StringPtr("ns1.example.com")}},
+				},
+				name: {},
+			}}
+			res, err := NewObjectManager(conn, cmpType, tenantID).CloneDNSView("default", name)
+			Expect(err).To(BeNil())
+			Expect(res.ZonesCreated).To(Equal([]string{"example.com"}))
+			Expect(res.SkippedZones).To(Equal([]DNSViewCloneSkippedZone{
+				{ObjectType: "zone_forward", Fqdn: "corp.com", Ref: fwdRef},
+				{ObjectType: "zone_rp", Fqdn: "rpz.local", Ref: rpRef},
+			}))
+			Expect(res.SkippedRecords).To(Equal([]ZoneFileRecord{{Name: "sub.example.com", Type: "NS", Data: "ns1.example.com."}}))
+			Expect(res.RecordsCreated).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,753 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DtcBundle is a complete GSLB service: an LBDN along with the pools,
+// servers, monitors and topologies it depends on. The objects refer to each
+// other by name, so a bundle can be applied to any grid. The auth zones of
+// the LBDN are only referred to: they must exist on the grid the bundle is
+// applied to.
+type DtcBundle struct {
+	Lbdn       DtcBundleLbdn       `json:"lbdn"`
+	Pools      []DtcBundlePool     `json:"pools,omitempty"`
+	Servers    []DtcBundleServer   `json:"servers,omitempty"`
+	Monitors   []DtcBundleMonitor  `json:"monitors,omitempty"`
+	Topologies []DtcBundleTopology `json:"topologies,omitempty"`
+}
+
+// DtcBundleLink is a pool of an LBDN or a server of a pool, with its weight.
+type DtcBundleLink struct {
+	Name  string `json:"name"`
+	Ratio uint32 `json:"ratio,omitempty"`
+}
+
+// DtcBundleMonitorLink is a monitor, given by its type (http, icmp, tcp,
+// sip, pdp or snmp) and its name.
+type DtcBundleMonitorLink struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type DtcBundleAuthZone struct {
+	Fqdn string `json:"fqdn"`
+	View string `json:"view,omitempty"`
+}
+
+type DtcBundleLbdn struct {
+	Name                     string              `json:"name"`
+	Comment                  string              `json:"comment,omitempty"`
+	Disable                  bool                `json:"disable,omitempty"`
+	AuthZones                []DtcBundleAuthZone `json:"auth_zones,omitempty"`
+	AutoConsolidatedMonitors bool                `json:"auto_consolidated_monitors,omitempty"`
+	LbMethod                 string              `json:"lb_method"`
+	Patterns                 []string            `json:"patterns,omitempty"`
+	Persistence              uint32              `json:"persistence,omitempty"`
+	Pools                    []DtcBundleLink     `json:"pools,omitempty"`
+	Priority                 uint32              `json:"priority,omitempty"`
+	Topology                 string              `json:"topology,omitempty"`
+	Types                    []string            `json:"types,omitempty"`
+	Ttl                      uint32              `json:"ttl,omitempty"`
+	UseTtl                   bool                `json:"use_ttl,omitempty"`
+	Ea                       EA                  `json:"extattrs,omitempty"`
+}
+
+type DtcBundleDynamicRatio struct {
+	Method              string               `json:"method,omitempty"`
+	Monitor             DtcBundleMonitorLink `json:"monitor"`
+	MonitorMetric       string               `json:"monitor_metric,omitempty"`
+	MonitorWeighing     string               `json:"monitor_weighing,omitempty"`
+	InvertMonitorMetric bool                 `json:"invert_monitor_metric,omitempty"`
+}
+
+type DtcBundleConsolidatedMonitor struct {
+	Monitor                 DtcBundleMonitorLink `json:"monitor"`
+	Members                 []string             `json:"members"`
+	Availability            string               `json:"availability"`
+	FullHealthCommunication bool                 `json:"full_health_communication,omitempty"`
+}
+
+type DtcBundlePool struct {
+	Name                     string                         `json:"name"`
+	Comment                  string                         `json:"comment,omitempty"`
+	Disable                  bool                           `json:"disable,omitempty"`
+	LbPreferredMethod        string                         `json:"lb_preferred_method"`
+	LbPreferredTopology      string                         `json:"lb_preferred_topology,omitempty"`
+	LbDynamicRatioPreferred  *DtcBundleDynamicRatio         `json:"lb_dynamic_ratio_preferred,omitempty"`
+	LbAlternateMethod        string                         `json:"lb_alternate_method,omitempty"`
+	LbAlternateTopology      string                         `json:"lb_alternate_topology,omitempty"`
+	LbDynamicRatioAlternate  *DtcBundleDynamicRatio         `json:"lb_dynamic_ratio_alternate,omitempty"`
+	Servers                  []DtcBundleLink                `json:"servers,omitempty"`
+	Monitors                 []DtcBundleMonitorLink         `json:"monitors,omitempty"`
+	Availability             string                         `json:"availability,omitempty"`
+	Quorum                   uint32                         `json:"quorum,omitempty"`
+	AutoConsolidatedMonitors bool                           `json:"auto_consolidated_monitors,omitempty"`
+	ConsolidatedMonitors     []DtcBundleConsolidatedMonitor `json:"consolidated_monitors,omitempty"`
+	Ttl                      uint32                         `json:"ttl,omitempty"`
+	UseTtl                   bool                           `json:"use_ttl,omitempty"`
+	Ea                       EA                             `json:"extattrs,omitempty"`
+}
+
+type DtcBundleServerMonitor struct {
+	Monitor DtcBundleMonitorLink `json:"monitor"`
+	Host    string               `json:"host,omitempty"`
+}
+
+type DtcBundleServer struct {
+	Name                 string                   `json:"name"`
+	Host                 string                   `json:"host"`
+	Comment              string                   `json:"comment,omitempty"`
+	Disable              bool                     `json:"disable,omitempty"`
+	AutoCreateHostRecord bool                     `json:"auto_create_host_record,omitempty"`
+	Monitors             []DtcBundleServerMonitor `json:"monitors,omitempty"`
+	SniHostname          string                   `json:"sni_hostname,omitempty"`
+	UseSniHostname       bool                     `json:"use_sni_hostname,omitempty"`
+	Ea                   EA                       `json:"extattrs,omitempty"`
+}
+
+// DtcBundleMonitor is a monitor; Settings holds its fields other than its
+// name, as WAPI names them. The client certificate of a monitor is a grid
+// object of its own, with no name: it is left out of the exported settings
+// and must be set again on the grid the bundle is applied to.
+type DtcBundleMonitor struct {
+	Type     string                 `json:"type"`
+	Name     string                 `json:"name"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+type DtcBundleTopologyRule struct {
+	DestType    string                   `json:"dest_type"`
+	Destination string                   `json:"destination,omitempty"`
+	ReturnType  string                   `json:"return_type,omitempty"`
+	Sources     []*DtcTopologyRuleSource `json:"sources,omitempty"`
+}
+
+type DtcBundleTopology struct {
+	Name    string                  `json:"name"`
+	Comment string                  `json:"comment,omitempty"`
+	Rules   []DtcBundleTopologyRule `json:"rules,omitempty"`
+	Ea      EA                      `json:"extattrs,omitempty"`
+}
+
+// DtcBundleApplied is an object of a bundle applied to the grid.
+type DtcBundleApplied struct {
+	Link    DtcLink
+	Ref     string
+	Created bool // the object was created, otherwise it was updated
+}
+
+// ParseDtcBundle decodes a bundle from a JSON or YAML document.
+func ParseDtcBundle(data []byte) (*DtcBundle, error) {
+	// the bundle is described by its JSON fields only, YAML is decoded through them
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("cannot decode the Dtc bundle: %s", err)
+	}
+	jsonDoc, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode the Dtc bundle: %s", err)
+	}
+	bundle := &DtcBundle{}
+	if err = json.Unmarshal(jsonDoc, bundle); err != nil {
+		return nil, fmt.Errorf("cannot decode the Dtc bundle: %s", err)
+	}
+	return bundle, nil
+}
+
+// YAML encodes the bundle as a YAML document, with the same fields as its JSON encoding.
+func (b *DtcBundle) YAML() ([]byte, error) {
+	jsonDoc, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err = json.Unmarshal(jsonDoc, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+func dtcBool(b *bool) bool {
+	return b != nil && *b
+}
+
+// dtcBundleEa leaves out the extensible attributes of an object which has none.
+func dtcBundleEa(ea EA) EA {
+	if len(ea) == 0 {
+		return nil
+	}
+	return ea
+}
+
+// dtcMonitorRefSettings are the monitor fields which refer to grid objects.
+var dtcMonitorRefSettings = []string{"client_cert"}
+
+// dtcBundleExporter collects the objects of a bundle, each one after the
+// objects it depends on.
+type dtcBundleExporter struct {
+	objMgr *ObjectManager
+	bundle *DtcBundle
+	names  map[string]string // names of the exported objects, by reference
+}
+
+func (e *dtcBundleExporter) monitor(ref string) (DtcBundleMonitorLink, error) {
+	monitorType := strings.TrimPrefix(strings.SplitN(ref, "/", 2)[0], "dtc:monitor:")
+	if name, ok := e.names[ref]; ok {
+		return DtcBundleMonitorLink{Type: monitorType, Name: name}, nil
+	}
+	monitor, err := e.objMgr.GetDtcMonitorByRef(ref)
+	if err != nil {
+		return DtcBundleMonitorLink{}, err
+	}
+	settings, err := objectToMap(monitor)
+	if err != nil {
+		return DtcBundleMonitorLink{}, err
+	}
+	name, _ := settings["name"].(string)
+	delete(settings, "_ref")
+	delete(settings, "name")
+	for _, field := range dtcMonitorRefSettings {
+		delete(settings, field)
+	}
+	if ea, ok := settings["extattrs"].(map[string]interface{}); ok && len(ea) == 0 {
+		delete(settings, "extattrs")
+	}
+	e.names[ref] = name
+	e.bundle.Monitors = append(e.bundle.Monitors, DtcBundleMonitor{Type: monitorType, Name: name, Settings: settings})
+	return DtcBundleMonitorLink{Type: monitorType, Name: name}, nil
+}
+
+func (e *dtcBundleExporter) dynamicRatio(setting *SettingDynamicratio) (*DtcBundleDynamicRatio, error) {
+	if setting == nil || setting.Monitor == "" {
+		return nil, nil
+	}
+	monitor, err := e.monitor(setting.Monitor)
+	if err != nil {
+		return nil, err
+	}
+	return &DtcBundleDynamicRatio{
+		Method:              setting.Method,
+		Monitor:             monitor,
+		MonitorMetric:       setting.MonitorMetric,
+		MonitorWeighing:     setting.MonitorWeighing,
+		InvertMonitorMetric: setting.InvertMonitorMetric,
+	}, nil
+}
+
+func (e *dtcBundleExporter) server(ref string) (string, error) {
+	if name, ok := e.names[ref]; ok {
+		return name, nil
+	}
+	server, err := e.objMgr.GetDtcServerByRef(ref)
+	if err != nil {
+		return "", err
+	}
+	res := DtcBundleServer{
+		Name:                 strValue(server.Name),
+		Host:                 strValue(server.Host),
+		Comment:              strValue(server.Comment),
+		Disable:              dtcBool(server.Disable),
+		AutoCreateHostRecord: dtcBool(server.AutoCreateHostRecord),
+		SniHostname:          strValue(server.SniHostname),
+		UseSniHostname:       dtcBool(server.UseSniHostname),
+		Ea:                   dtcBundleEa(server.Ea),
+	}
+	e.names[ref] = res.Name
+	for _, m := range server.Monitors {
+		monitor, err := e.monitor(m.Monitor)
+		if err != nil {
+			return "", err
+		}
+		res.Monitors = append(res.Monitors, DtcBundleServerMonitor{Monitor: monitor, Host: m.Host})
+	}
+	e.bundle.Servers = append(e.bundle.Servers, res)
+	return res.Name, nil
+}
+
+func (e *dtcBundleExporter) topology(ref *string) (string, error) {
+	if ref == nil || *ref == "" {
+		return "", nil
+	}
+	if name, ok := e.names[*ref]; ok {
+		return name, nil
+	}
+	topology, err := e.objMgr.GetDtcTopologyByRef(*ref)
+	if err != nil {
+		return "", err
+	}
+	rules, err := e.objMgr.getDtcTopologyRules(topology)
+	if err != nil {
+		return "", err
+	}
+	res := DtcBundleTopology{Name: strValue(topology.Name), Comment: strValue(topology.Comment), Ea: dtcBundleEa(topology.Ea)}
+	e.names[*ref] = res.Name
+	for _, rule := range rules {
+		bundleRule := DtcBundleTopologyRule{DestType: rule.DestType, ReturnType: rule.ReturnType, Sources: rule.Sources}
+		if rule.DestinationLink != nil && *rule.DestinationLink != "" {
+			if rule.DestType == "POOL" {
+				bundleRule.Destination, err = e.pool(*rule.DestinationLink)
+			} else {
+				bundleRule.Destination, err = e.server(*rule.DestinationLink)
+			}
+			if err != nil {
+				return "", err
+			}
+		}
+		res.Rules = append(res.Rules, bundleRule)
+	}
+	e.bundle.Topologies = append(e.bundle.Topologies, res)
+	return res.Name, nil
+}
+
+func (e *dtcBundleExporter) pool(ref string) (string, error) {
+	if name, ok := e.names[ref]; ok {
+		return name, nil
+	}
+	pool, err := e.objMgr.GetDtcPoolByRef(ref)
+	if err != nil {
+		return "", err
+	}
+	res := DtcBundlePool{
+		Name:                     strValue(pool.Name),
+		Comment:                  strValue(pool.Comment),
+		Disable:                  dtcBool(pool.Disable),
+		LbPreferredMethod:        pool.LbPreferredMethod,
+		LbAlternateMethod:        pool.LbAlternateMethod,
+		Availability:             pool.Availability,
+		Quorum:                   uint32Value(pool.Quorum),
+		AutoConsolidatedMonitors: dtcBool(pool.AutoConsolidatedMonitors),
+		Ttl:                      uint32Value(pool.Ttl),
+		UseTtl:                   dtcBool(pool.UseTtl),
+		Ea:                       dtcBundleEa(pool.Ea),
+	}
+	e.names[ref] = res.Name
+	for _, link := range pool.Servers {
+		name, err := e.server(link.Server)
+		if err != nil {
+			return "", err
+		}
+		res.Servers = append(res.Servers, DtcBundleLink{Name: name, Ratio: link.Ratio})
+	}
+	for _, m := range pool.Monitors {
+		monitor, err := e.monitor(m.Ref)
+		if err != nil {
+			return "", err
+		}
+		res.Monitors = append(res.Monitors, monitor)
+	}
+	if res.LbDynamicRatioPreferred, err = e.dynamicRatio(pool.LbDynamicRatioPreferred); err != nil {
+		return "", err
+	}
+	if res.LbDynamicRatioAlternate, err = e.dynamicRatio(pool.LbDynamicRatioAlternate); err != nil {
+		return "", err
+	}
+	// consolidated monitors are managed by the grid when they are automatic
+	if !res.AutoConsolidatedMonitors {
+		for _, cm := range pool.ConsolidatedMonitors {
+			monitor, err := e.monitor(cm.Monitor)
+			if err != nil {
+				return "", err
+			}
+			res.ConsolidatedMonitors = append(res.ConsolidatedMonitors, DtcBundleConsolidatedMonitor{
+				Monitor:                 monitor,
+				Members:                 cm.Members,
+				Availability:            cm.Availability,
+				FullHealthCommunication: cm.FullHealthCommunication,
+			})
+		}
+	}
+	if res.LbPreferredTopology, err = e.topology(pool.LbPreferredTopology); err != nil {
+		return "", err
+	}
+	if res.LbAlternateTopology, err = e.topology(pool.LbAlternateTopology); err != nil {
+		return "", err
+	}
+	e.bundle.Pools = append(e.bundle.Pools, res)
+	return res.Name, nil
+}
+
+// ExportDtcBundle returns the LBDN with the given name along with all the
+// pools, servers, monitors and topologies it depends on, directly or
+// through topology rules.
+func (objMgr *ObjectManager) ExportDtcBundle(lbdnName string) (*DtcBundle, error) {
+	lbdn, err := objMgr.GetDtcLbdn(lbdnName)
+	if err != nil {
+		return nil, err
+	}
+	e := &dtcBundleExporter{objMgr: objMgr, bundle: &DtcBundle{}, names: make(map[string]string)}
+	res := DtcBundleLbdn{
+		Name:                     strValue(lbdn.Name),
+		Comment:                  strValue(lbdn.Comment),
+		Disable:                  dtcBool(lbdn.Disable),
+		AutoConsolidatedMonitors: dtcBool(lbdn.AutoConsolidatedMonitors),
+		LbMethod:                 lbdn.LbMethod,
+		Patterns:                 lbdn.Patterns,
+		Persistence:              uint32Value(lbdn.Persistence),
+		Priority:                 uint32Value(lbdn.Priority),
+		Types:                    lbdn.Types,
+		Ttl:                      uint32Value(lbdn.Ttl),
+		UseTtl:                   dtcBool(lbdn.UseTtl),
+		Ea:                       dtcBundleEa(lbdn.Ea),
+	}
+	for _, zone := range lbdn.AuthZones {
+		zoneAuth := &ZoneAuth{}
+		zoneAuth.SetReturnFields([]string{"fqdn", "view"})
+		if err = objMgr.connector.GetObject(zoneAuth, zone.Ref, NewQueryParams(false, nil), zoneAuth); err != nil {
+			return nil, err
+		}
+		res.AuthZones = append(res.AuthZones, DtcBundleAuthZone{Fqdn: zoneAuth.Fqdn, View: strValue(zoneAuth.View)})
+	}
+	for _, link := range lbdn.Pools {
+		name, err := e.pool(link.Pool)
+		if err != nil {
+			return nil, err
+		}
+		res.Pools = append(res.Pools, DtcBundleLink{Name: name, Ratio: link.Ratio})
+	}
+	if res.Topology, err = e.topology(lbdn.Topology); err != nil {
+		return nil, err
+	}
+	e.bundle.Lbdn = res
+	return e.bundle, nil
+}
+
+func validateDtcBundle(bundle *DtcBundle) error {
+	if bundle.Lbdn.Name == "" {
+		return fmt.Errorf("name of the LBDN is required to apply a Dtc bundle")
+	}
+	seen := make(map[DtcLink]bool)
+	check := func(link DtcLink) error {
+		if link.Name == "" {
+			return fmt.Errorf("name is required for each %s object of a Dtc bundle", link.ObjectType)
+		}
+		if seen[link] {
+			return fmt.Errorf("%s is defined more than once in the Dtc bundle", link)
+		}
+		seen[link] = true
+		return nil
+	}
+	for _, m := range bundle.Monitors {
+		if _, err := newDtcMonitor(m.Type); err != nil {
+			return err
+		}
+		if err := check(DtcLink{ObjectType: "dtc:monitor:" + m.Type, Name: m.Name}); err != nil {
+			return err
+		}
+	}
+	for _, s := range bundle.Servers {
+		if err := check(DtcLink{ObjectType: "dtc:server", Name: s.Name}); err != nil {
+			return err
+		}
+	}
+	for _, t := range bundle.Topologies {
+		if err := check(DtcLink{ObjectType: "dtc:topology", Name: t.Name}); err != nil {
+			return err
+		}
+	}
+	for _, p := range bundle.Pools {
+		if err := check(DtcLink{ObjectType: "dtc:pool", Name: p.Name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m DtcBundleMonitorLink) monitor() Monitor {
+	return Monitor{Name: m.Name, Type: m.Type}
+}
+
+func (d *DtcBundleDynamicRatio) settings() map[string]interface{} {
+	if d == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"monitor":               d.Monitor.monitor(),
+		"method":                d.Method,
+		"monitor_metric":        d.MonitorMetric,
+		"monitor_weighing":      d.MonitorWeighing,
+		"invert_monitor_metric": d.InvertMonitorMetric,
+	}
+}
+
+func optionalDtcName(name string) *string {
+	if name == "" {
+		return nil
+	}
+	return &name
+}
+
+// dtcBundleApplier creates or updates the objects of a bundle.
+type dtcBundleApplier struct {
+	objMgr   *ObjectManager
+	existing *dtcLinkResolver
+	applied  []DtcBundleApplied
+}
+
+func (a *dtcBundleApplier) apply(link DtcLink, create func() (string, error), update func(ref string) (string, error)) error {
+	var err error
+	res := DtcBundleApplied{Link: link}
+	if ref := a.existing.ref(link); ref != "" {
+		res.Ref, err = update(ref)
+	} else {
+		res.Created = true
+		res.Ref, err = create()
+	}
+	if err != nil {
+		return fmt.Errorf("cannot apply %s: %s", link, err)
+	}
+	a.applied = append(a.applied, res)
+	return nil
+}
+
+func (a *dtcBundleApplier) monitor(m DtcBundleMonitor) error {
+	newMonitor := func() (IBObject, error) {
+		monitor, _ := newDtcMonitor(m.Type)
+		settings := map[string]interface{}{"name": m.Name}
+		for k, v := range m.Settings {
+			if k != "name" && k != "_ref" {
+				settings[k] = v
+			}
+		}
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(data, monitor); err != nil {
+			return nil, err
+		}
+		return monitor, nil
+	}
+	return a.apply(DtcLink{ObjectType: "dtc:monitor:" + m.Type, Name: m.Name},
+		func() (string, error) {
+			monitor, err := newMonitor()
+			if err != nil {
+				return "", err
+			}
+			monitor, err = a.objMgr.CreateDtcMonitor(monitor)
+			if err != nil {
+				return "", err
+			}
+			return dtcObjectRef(monitor), nil
+		},
+		func(ref string) (string, error) {
+			monitor, err := newMonitor()
+			if err != nil {
+				return "", err
+			}
+			monitor, err = a.objMgr.UpdateDtcMonitor(ref, monitor)
+			if err != nil {
+				return "", err
+			}
+			return dtcObjectRef(monitor), nil
+		})
+}
+
+func (a *dtcBundleApplier) server(s DtcBundleServer) error {
+	var monitors []map[string]interface{}
+	for _, m := range s.Monitors {
+		monitors = append(monitors, map[string]interface{}{"monitor": m.Monitor.monitor(), "host": m.Host})
+	}
+	return a.apply(DtcLink{ObjectType: "dtc:server", Name: s.Name},
+		func() (string, error) {
+			server, err := a.objMgr.CreateDtcServer(s.Comment, s.Name, s.Host, s.AutoCreateHostRecord, s.Disable, s.Ea,
+				monitors, s.SniHostname, s.UseSniHostname)
+			if err != nil {
+				return "", err
+			}
+			return server.Ref, nil
+		},
+		func(ref string) (string, error) {
+			server, err := a.objMgr.UpdateDtcServer(ref, s.Comment, s.Name, s.Host, s.AutoCreateHostRecord, s.Disable, s.Ea,
+				monitors, s.SniHostname, s.UseSniHostname)
+			if err != nil {
+				return "", err
+			}
+			return server.Ref, nil
+		})
+}
+
+func (a *dtcBundleApplier) topology(t DtcBundleTopology) error {
+	var rules []DtcTopologyRuleParams
+	for _, r := range t.Rules {
+		rules = append(rules, DtcTopologyRuleParams{DestType: r.DestType, Destination: r.Destination, ReturnType: r.ReturnType, Sources: r.Sources})
+	}
+	return a.apply(DtcLink{ObjectType: "dtc:topology", Name: t.Name},
+		func() (string, error) {
+			topology, err := a.objMgr.CreateDtcTopology(t.Name, t.Comment, rules, t.Ea)
+			if err != nil {
+				return "", err
+			}
+			return topology.Ref, nil
+		},
+		func(ref string) (string, error) {
+			topology, err := a.objMgr.UpdateDtcTopology(ref, t.Name, t.Comment, rules, t.Ea)
+			if err != nil {
+				return "", err
+			}
+			return topology.Ref, nil
+		})
+}
+
+func (a *dtcBundleApplier) pool(p DtcBundlePool) error {
+	var servers []*DtcServerLink
+	for _, s := range p.Servers {
+		servers = append(servers, &DtcServerLink{Server: s.Name, Ratio: s.Ratio})
+	}
+	var monitors []Monitor
+	for _, m := range p.Monitors {
+		monitors = append(monitors, m.monitor())
+	}
+	var consolidatedMonitors []map[string]interface{}
+	if !p.AutoConsolidatedMonitors {
+		consolidatedMonitors = []map[string]interface{}{}
+		for _, cm := range p.ConsolidatedMonitors {
+			consolidatedMonitors = append(consolidatedMonitors, map[string]interface{}{
+				"monitor":                   cm.Monitor.monitor(),
+				"availability":              cm.Availability,
+				"members":                   cm.Members,
+				"full_health_communication": cm.FullHealthCommunication,
+			})
+		}
+	}
+	return a.apply(DtcLink{ObjectType: "dtc:pool", Name: p.Name},
+		func() (string, error) {
+			pool, err := a.objMgr.CreateDtcPool(p.Comment, p.Name, p.LbPreferredMethod, p.LbDynamicRatioPreferred.settings(),
+				servers, monitors, optionalDtcName(p.LbPreferredTopology), p.LbAlternateMethod, optionalDtcName(p.LbAlternateTopology),
+				p.LbDynamicRatioAlternate.settings(), p.Ea, p.AutoConsolidatedMonitors, consolidatedMonitors, p.Availability,
+				p.Ttl, p.UseTtl, p.Disable, p.Quorum)
+			if err != nil {
+				return "", err
+			}
+			return pool.Ref, nil
+		},
+		func(ref string) (string, error) {
+			pool, err := a.objMgr.UpdateDtcPool(ref, p.Comment, p.Name, p.LbPreferredMethod, p.LbDynamicRatioPreferred.settings(),
+				servers, monitors, optionalDtcName(p.LbPreferredTopology), p.LbAlternateMethod, optionalDtcName(p.LbAlternateTopology),
+				p.LbDynamicRatioAlternate.settings(), p.Ea, p.AutoConsolidatedMonitors, p.Availability, consolidatedMonitors,
+				p.Ttl, p.UseTtl, p.Disable, p.Quorum)
+			if err != nil {
+				return "", err
+			}
+			return pool.Ref, nil
+		})
+}
+
+func (a *dtcBundleApplier) lbdn(l DtcBundleLbdn) error {
+	var zones []AuthZonesLink
+	for _, z := range l.AuthZones {
+		zones = append(zones, AuthZonesLink{Fqdn: z.Fqdn, DnsView: z.View})
+	}
+	var pools []*DtcPoolLink
+	for _, p := range l.Pools {
+		pools = append(pools, &DtcPoolLink{Pool: p.Name, Ratio: p.Ratio})
+	}
+	topology := l.Topology
+	return a.apply(DtcLink{ObjectType: "dtc:lbdn", Name: l.Name},
+		func() (string, error) {
+			lbdn, err := a.objMgr.CreateDtcLbdn(l.Name, zones, l.Comment, l.Disable, l.AutoConsolidatedMonitors, l.Ea,
+				l.LbMethod, l.Patterns, l.Persistence, pools, l.Priority, &topology, l.Types, l.Ttl, l.UseTtl)
+			if err != nil {
+				return "", err
+			}
+			return lbdn.Ref, nil
+		},
+		func(ref string) (string, error) {
+			lbdn, err := a.objMgr.UpdateDtcLbdn(ref, l.Name, zones, l.Comment, l.Disable, l.AutoConsolidatedMonitors, l.Ea,
+				l.LbMethod, l.Patterns, l.Persistence, pools, l.Priority, &topology, l.Types, l.Ttl, l.UseTtl)
+			if err != nil {
+				return "", err
+			}
+			return lbdn.Ref, nil
+		})
+}
+
+// findExistingDtcLbdn returns the reference of the LBDN with the given name, or an empty string.
+func (objMgr *ObjectManager) findExistingDtcLbdn(name string) (string, error) {
+	var lbdns []DtcLbdn
+	err := objMgr.getObjects(&DtcLbdn{}, NewQueryParams(false, map[string]string{"name": name}), &lbdns)
+	if err != nil || len(lbdns) == 0 {
+		return "", err
+	}
+	return lbdns[0].Ref, nil
+}
+
+// ImportDtcBundle creates the objects of the bundle which do not exist on
+// the grid and updates the others, in the order of their dependencies:
+// monitors, servers, the topologies leading to servers only, pools, the
+// other topologies and the LBDN. It returns the objects applied, in this
+// order, along with the error which stopped the application, if any.
+func (objMgr *ObjectManager) ImportDtcBundle(bundle *DtcBundle) ([]DtcBundleApplied, error) {
+	if err := validateDtcBundle(bundle); err != nil {
+		return nil, err
+	}
+
+	// the existing objects are looked up at once
+	existing := newDtcLinkResolver(objMgr)
+	for _, m := range bundle.Monitors {
+		existing.add(DtcLink{ObjectType: "dtc:monitor:" + m.Type, Name: m.Name})
+	}
+	for _, s := range bundle.Servers {
+		existing.add(DtcLink{ObjectType: "dtc:server", Name: s.Name})
+	}
+	for _, t := range bundle.Topologies {
+		existing.add(DtcLink{ObjectType: "dtc:topology", Name: t.Name})
+	}
+	for _, p := range bundle.Pools {
+		existing.add(DtcLink{ObjectType: "dtc:pool", Name: p.Name})
+	}
+	if err := existing.resolve(); err != nil {
+		if _, ok := err.(*UnresolvedDtcLinksError); !ok {
+			return nil, err
+		}
+	}
+	lbdnRef, err := objMgr.findExistingDtcLbdn(bundle.Lbdn.Name)
+	if err != nil {
+		return nil, err
+	}
+	existing.setRef(DtcLink{ObjectType: "dtc:lbdn", Name: bundle.Lbdn.Name}, lbdnRef)
+
+	a := &dtcBundleApplier{objMgr: objMgr, existing: existing}
+	for _, m := range bundle.Monitors {
+		if err := a.monitor(m); err != nil {
+			return a.applied, err
+		}
+	}
+	for _, s := range bundle.Servers {
+		if err := a.server(s); err != nil {
+			return a.applied, err
+		}
+	}
+	var poolTopologies []DtcBundleTopology
+	for _, t := range bundle.Topologies {
+		leadsToPools := false
+		for _, r := range t.Rules {
+			leadsToPools = leadsToPools || (r.DestType == "POOL" && r.Destination != "")
+		}
+		if leadsToPools {
+			poolTopologies = append(poolTopologies, t)
+			continue
+		}
+		if err := a.topology(t); err != nil {
+			return a.applied, err
+		}
+	}
+	for _, p := range bundle.Pools {
+		if err := a.pool(p); err != nil {
+			return a.applied, err
+		}
+	}
+	for _, t := range poolTopologies {
+		if err := a.topology(t); err != nil {
+			return a.applied, err
+		}
+	}
+	if err := a.lbdn(bundle.Lbdn); err != nil {
+		return a.applied, err
+	}
+	return a.applied, nil
+}
@@ -0,0 +1,286 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// bundleGridConnector keeps the objects in memory the way WAPI returns them,
+// with the rules of the topologies stored as objects of their own.
+type bundleGridConnector struct {
+	objects map[string]map[string]interface{}
+	order   []string
+}
+
+func newBundleGridConnector() *bundleGridConnector {
+	return &bundleGridConnector{objects: make(map[string]map[string]interface{})}
+}
+
+func (c *bundleGridConnector) store(ref string, obj IBObject) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	fields := make(map[string]interface{})
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if rules, ok := fields["rules"].([]interface{}); ok && obj.ObjectType() == "dtc:topology" {
+		ruleRefs := make([]interface{}, 0, len(rules))
+		for _, rule := range rules {
+			ruleRef := fmt.Sprintf("dtc:topology:rule/%d", len(c.order))
+			ruleFields := rule.(map[string]interface{})
+			ruleFields["_ref"], ruleFields["topology"] = ruleRef, ref
+			c.objects[ruleRef] = ruleFields
+			c.order = append(c.order, ruleRef)
+			ruleRefs = append(ruleRefs, ruleRef)
+		}
+		fields["rules"] = ruleRefs
+	}
+	if old, ok := c.objects[ref]; ok {
+		for k, v := range fields {
+			old[k] = v
+		}
+		return nil
+	}
+	fields["_ref"] = ref
+	c.objects[ref] = fields
+	c.order = append(c.order, ref)
+	return nil
+}
+
+func (c *bundleGridConnector) CreateObject(obj IBObject) (string, error) {
+	data, _ := json.Marshal(obj)
+	var names struct {
+		Name string `json:"name"`
+		Fqdn string `json:"fqdn"`
+	}
+	_ = json.Unmarshal(data, &names)
+	ref := fmt.Sprintf("%s/%d:%s%s", obj.ObjectType(), len(c.order), names.Name, names.Fqdn)
+	return ref, c.store(ref, obj)
+}
+
+func (c *bundleGridConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res interface{}) error {
+	var found interface{}
+	if ref != "" {
+		fields, ok := c.objects[ref]
+		if !ok {
+			return NewNotFoundError("not found")
+		}
+		found = fields
+	} else {
+		var list []interface{}
+		for _, r := range c.order {
+			fields, ok := c.objects[r]
+			if !ok || !strings.HasPrefix(r, obj.ObjectType()+"/") {
+				continue
+			}
+			matches := true
+			for k, v := range qp.searchFields {
+				matches = matches && fmt.Sprint(fields[k]) == v
+			}
+			if matches {
+				list = append(list, fields)
+			}
+		}
+		if len(list) == 0 {
+			return NewNotFoundError("not found")
+		}
+		found = list
+	}
+	data, err := json.Marshal(found)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, res)
+}
+
+func (c *bundleGridConnector) UpdateObject(obj IBObject, ref string) (string, error) {
+	if _, ok := c.objects[ref]; !ok {
+		return "", NewNotFoundError("not found")
+	}
+	return ref, c.store(ref, obj)
+}
+
+func (c *bundleGridConnector) DeleteObject(ref string) (string, error) {
+	delete(c.objects, ref)
+	return ref, nil
+}
+
+var _ = Describe("Object Manager: DTC bundle", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	newGrid := func() (*bundleGridConnector, IBObjectManager) {
+		conn := newBundleGridConnector()
+		_, _ = conn.CreateObject(&ZoneAuth{Fqdn: "example.com", View: utils.StringPtr("default")})
+		return conn, NewObjectManager(conn, cmpType, tenantID)
+	}
+
+	expectedBundle := &DtcBundle{
+		Lbdn: DtcBundleLbdn{
+			Name:      "app",
+			AuthZones: []DtcBundleAuthZone{{Fqdn: "example.com", View: "default"}},
+			LbMethod:  "TOPOLOGY",
+			Patterns:  []string{"app.example.com"},
+			Pools:     []DtcBundleLink{{Name: "eu-pool", Ratio: 2}, {Name: "us-pool", Ratio: 1}},
+			Topology:  "pool-geo",
+			Types:     []string{"A"},
+			Ttl:       60,
+			UseTtl:    true,
+		},
+		Pools: []DtcBundlePool{
+			{
+				Name:              "eu-pool",
+				LbPreferredMethod: "ROUND_ROBIN",
+				Servers:           []DtcBundleLink{{Name: "eu-server", Ratio: 1}},
+				Monitors:          []DtcBundleMonitorLink{{Type: "http", Name: "https"}},
+				Availability:      "ALL",
+			},
+			{
+				Name:                "us-pool",
+				LbPreferredMethod:   "TOPOLOGY",
+				LbPreferredTopology: "server-geo",
+				Servers:             []DtcBundleLink{{Name: "us-server", Ratio: 1}, {Name: "eu-server", Ratio: 1}},
+				Availability:        "ANY",
+			},
+		},
+		Servers: []DtcBundleServer{
+			{Name: "eu-server", Host: "192.0.2.10",
+				Monitors: []DtcBundleServerMonitor{{Monitor: DtcBundleMonitorLink{Type: "http", Name: "https"}, Host: "192.0.2.11"}}},
+			{Name: "us-server", Host: "198.51.100.10"},
+		},
+		Monitors: []DtcBundleMonitor{
+			{Type: "http", Name: "https", Settings: map[string]interface{}{"port": float64(443), "secure": true}},
+		},
+		Topologies: []DtcBundleTopology{
+			{Name: "server-geo", Rules: []DtcBundleTopologyRule{
+				{DestType: "SERVER", Destination: "us-server", ReturnType: "REGULAR", Sources: []*DtcTopologyRuleSource{
+					{SourceType: "CONTINENT", SourceOp: "IS", SourceValue: "North America"}}},
+				{DestType: "SERVER", Destination: "eu-server", ReturnType: "REGULAR"},
+			}},
+			{Name: "pool-geo", Rules: []DtcBundleTopologyRule{
+				{DestType: "POOL", Destination: "us-pool", ReturnType: "REGULAR", Sources: []*DtcTopologyRuleSource{
+					{SourceType: "CONTINENT", SourceOp: "IS", SourceValue: "North America"}}},
+				{DestType: "POOL", Destination: "eu-pool", ReturnType: "REGULAR"},
+			}},
+		},
+	}
+
+	Describe("Export, then import into another grid", func() {
+		_, srcObjMgr := newGrid()
+		_, applyErr := srcObjMgr.ImportDtcBundle(expectedBundle)
+
+		It("should export the LBDN and its dependencies, each object after those it depends on", func() {
+			Expect(applyErr).To(BeNil())
+			bundle, err := srcObjMgr.ExportDtcBundle("app")
+			Expect(err).To(BeNil())
+			Expect(bundle).To(Equal(expectedBundle))
+		})
+
+		It("should encode the bundle as YAML and decode it back", func() {
+			bundle, err := srcObjMgr.ExportDtcBundle("app")
+			Expect(err).To(BeNil())
+			doc, err := bundle.YAML()
+			Expect(err).To(BeNil())
+			Expect(string(doc)).To(ContainSubstring("lb_preferred_topology: server-geo"))
+			parsed, err := ParseDtcBundle(doc)
+			Expect(err).To(BeNil())
+			Expect(parsed).To(Equal(bundle))
+		})
+
+		It("should create the objects in dependency order, then update them when applied again", func() {
+			bundle, err := srcObjMgr.ExportDtcBundle("app")
+			Expect(err).To(BeNil())
+			data, err := json.Marshal(bundle)
+			Expect(err).To(BeNil())
+			bundle, err = ParseDtcBundle(data)
+			Expect(err).To(BeNil())
+
+			_, dstObjMgr := newGrid()
+			applied, err := dstObjMgr.ImportDtcBundle(bundle)
+			Expect(err).To(BeNil())
+			var links []string
+			for _, a := range applied {
+				Expect(a.Created).To(BeTrue())
+				links = append(links, a.Link.String())
+			}
+			Expect(links).To(Equal([]string{
+				"dtc:monitor:http 'https'",
+				"dtc:server 'eu-server'",
+				"dtc:server 'us-server'",
+				"dtc:topology 'server-geo'",
+				"dtc:pool 'eu-pool'",
+				"dtc:pool 'us-pool'",
+				"dtc:topology 'pool-geo'",
+				"dtc:lbdn 'app'",
+			}))
+
+			exported, err := dstObjMgr.ExportDtcBundle("app")
+			Expect(err).To(BeNil())
+			Expect(exported).To(Equal(expectedBundle))
+
+			reapplied, err := dstObjMgr.ImportDtcBundle(bundle)
+			Expect(err).To(BeNil())
+			Expect(reapplied).To(HaveLen(len(applied)))
+			for i, a := range reapplied {
+				Expect(a.Created).To(BeFalse())
+				Expect(a.Ref).To(Equal(applied[i].Ref))
+			}
+		})
+	})
+
+	Describe("Export a monitor with a client certificate", func() {
+		conn, objMgr := newGrid()
+		monitorRef, _ := conn.CreateObject(&DtcMonitorHttp{
+			Name:       utils.StringPtr("https-cert"),
+			Port:       utils.Uint32Ptr(443),
+			ClientCert: utils.StringPtr("dtc:certificate/ZG5zLmlkbnNfY2VydGlmaWNhdGUkMQ:client"),
+		})
+
+		It("should leave the reference to the certificate out of the settings", func() {
+			e := &dtcBundleExporter{objMgr: objMgr.(*ObjectManager), bundle: &DtcBundle{}, names: make(map[string]string)}
+			link, err := e.monitor(monitorRef)
+			Expect(err).To(BeNil())
+			Expect(link).To(Equal(DtcBundleMonitorLink{Type: "http", Name: "https-cert"}))
+			Expect(e.bundle.Monitors).To(Equal([]DtcBundleMonitor{
+				{Type: "http", Name: "https-cert", Settings: map[string]interface{}{"port": float64(443)}},
+			}))
+		})
+	})
+
+	Describe("Import a bundle whose auth zone is missing", func() {
+		conn := newBundleGridConnector()
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should stop at the LBDN and report the zone", func() {
+			applied, err := objMgr.ImportDtcBundle(expectedBundle)
+			Expect(err).To(MatchError("cannot apply dtc:lbdn 'app': linked objects not found: zone_auth 'example.com' in view 'default'"))
+			Expect(applied).To(HaveLen(7))
+		})
+	})
+
+	Describe("Import an invalid bundle", func() {
+		objMgr := NewObjectManager(newBundleGridConnector(), cmpType, tenantID)
+
+		It("should fail if an object is defined twice", func() {
+			bundle := &DtcBundle{
+				Lbdn:    DtcBundleLbdn{Name: "app", LbMethod: "ROUND_ROBIN"},
+				Servers: []DtcBundleServer{{Name: "eu-server", Host: "192.0.2.10"}, {Name: "eu-server", Host: "192.0.2.11"}},
+			}
+			applied, err := objMgr.ImportDtcBundle(bundle)
+			Expect(applied).To(BeNil())
+			Expect(err).To(MatchError("dtc:server 'eu-server' is defined more than once in the Dtc bundle"))
+		})
+
+		It("should fail without the name of the LBDN", func() {
+			_, err := objMgr.ImportDtcBundle(&DtcBundle{})
+			Expect(err).To(MatchError("name of the LBDN is required to apply a Dtc bundle"))
+		})
+	})
+})
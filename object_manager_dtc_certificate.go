@@ -0,0 +1,257 @@
+package ibclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// dtcCertificateUsage is the usage of the certificates uploaded for the
+// HTTPS and SIP monitors.
+const dtcCertificateUsage = "DTC_CA"
+
+// DtcCertificateInfo is a DTC certificate along with the details of its
+// underlying X.509 certificate.
+type DtcCertificateInfo struct {
+	Ref            string
+	Certificate    string // reference of the grid:x509certificate
+	InUse          bool   // the certificate is the client certificate of a monitor
+	Issuer         string
+	Serial         string
+	Subject        string
+	ValidNotBefore *UnixTime
+	ValidNotAfter  *UnixTime
+}
+
+// ExpiresBefore tells whether the certificate is no longer valid at the given time.
+func (c *DtcCertificateInfo) ExpiresBefore(t time.Time) bool {
+	return c.ValidNotAfter != nil && c.ValidNotAfter.Time.Before(t)
+}
+
+// dtcMonitorClientCertUpdate sets the client certificate of an HTTP or SIP
+// monitor, leaving its other fields untouched. A nil certificate is sent as
+// null, which removes it.
+type dtcMonitorClientCertUpdate struct {
+	IBBase     `json:"-"`
+	objectType string
+	ClientCert *string `json:"client_cert"`
+	Secure     *bool   `json:"secure,omitempty"`
+	EnableSni  *bool   `json:"enable_sni,omitempty"`
+}
+
+func (u *dtcMonitorClientCertUpdate) ObjectType() string {
+	return u.objectType
+}
+
+func NewEmptyDtcCertificate() *DtcCertificate {
+	certificate := &DtcCertificate{}
+	certificate.SetReturnFields(append(certificate.ReturnFields(), "certificate", "in_use"))
+	return certificate
+}
+
+// ParseDtcCertificatePEM returns the certificate of a PEM document holding
+// one certificate and, optionally, its private key. The key must match the
+// certificate.
+func ParseDtcCertificatePEM(data []byte) (*x509.Certificate, error) {
+	var cert *x509.Certificate
+	hasKey := false
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		switch {
+		case block.Type == "CERTIFICATE":
+			if cert != nil {
+				return nil, fmt.Errorf("more than one certificate in the PEM document")
+			}
+			var err error
+			if cert, err = x509.ParseCertificate(block.Bytes); err != nil {
+				return nil, fmt.Errorf("invalid certificate: %s", err)
+			}
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			hasKey = true
+		default:
+			return nil, fmt.Errorf("unexpected %s block in the PEM document", block.Type)
+		}
+	}
+	if cert == nil {
+		return nil, fmt.Errorf("no certificate found in the PEM document")
+	}
+	if hasKey {
+		if _, err := tls.X509KeyPair(data, data); err != nil {
+			return nil, fmt.Errorf("invalid private key: %s", err)
+		}
+	}
+	return cert, nil
+}
+
+// sameDtcCertificateSerial tells whether the serial number reported by the
+// grid, in hexadecimal with or without colons, is the given one.
+func sameDtcCertificateSerial(serial string, number *big.Int) bool {
+	n, ok := new(big.Int).SetString(strings.ReplaceAll(serial, ":", ""), 16)
+	return ok && n.Cmp(number) == 0
+}
+
+func (objMgr *ObjectManager) getDtcCertificateInfo(certificate *DtcCertificate) (*DtcCertificateInfo, error) {
+	res := &DtcCertificateInfo{Ref: certificate.Ref, Certificate: certificate.Certificate, InUse: certificate.InUse}
+	if certificate.Certificate == "" {
+		return res, nil
+	}
+	x509Cert := &GridX509certificate{}
+	x509Cert.SetReturnFields([]string{"issuer", "serial", "subject", "valid_not_after", "valid_not_before"})
+	err := objMgr.connector.GetObject(x509Cert, certificate.Certificate, NewQueryParams(false, nil), x509Cert)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the certificate '%s', err: %s", certificate.Certificate, err)
+	}
+	res.Issuer = x509Cert.Issuer
+	res.Serial = x509Cert.Serial
+	res.Subject = x509Cert.Subject
+	res.ValidNotBefore = x509Cert.ValidNotBefore
+	res.ValidNotAfter = x509Cert.ValidNotAfter
+	return res, nil
+}
+
+// GetDtcCertificates returns the DTC certificates with the details of their
+// X.509 certificates.
+func (objMgr *ObjectManager) GetDtcCertificates() ([]DtcCertificateInfo, error) {
+	var certificates []DtcCertificate
+	err := objMgr.getObjects(NewEmptyDtcCertificate(), NewQueryParams(false, nil), &certificates)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Dtc Certificate objects, err: %s", err)
+	}
+	res := make([]DtcCertificateInfo, 0, len(certificates))
+	for i := range certificates {
+		info, err := objMgr.getDtcCertificateInfo(&certificates[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *info)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) GetDtcCertificateByRef(ref string) (*DtcCertificateInfo, error) {
+	certificate := NewEmptyDtcCertificate()
+	if err := objMgr.connector.GetObject(certificate, ref, NewQueryParams(false, nil), certificate); err != nil {
+		return nil, err
+	}
+	return objMgr.getDtcCertificateInfo(certificate)
+}
+
+// GetExpiringDtcCertificates returns the DTC certificates which are no
+// longer valid within the given duration, including the expired ones.
+func (objMgr *ObjectManager) GetExpiringDtcCertificates(within time.Duration) ([]DtcCertificateInfo, error) {
+	certificates, err := objMgr.GetDtcCertificates()
+	if err != nil {
+		return nil, err
+	}
+	limit := time.Now().Add(within)
+	var res []DtcCertificateInfo
+	for _, c := range certificates {
+		if c.ExpiresBefore(limit) {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
+
+// UploadDtcCertificate uploads the PEM document, holding a certificate and
+// its private key, as a DTC certificate on the given grid member, and
+// returns it. Expired certificates are rejected.
+func (objMgr *ObjectManager) UploadDtcCertificate(pemData []byte, member string) (*DtcCertificateInfo, error) {
+	if member == "" {
+		return nil, fmt.Errorf("member is required to upload a Dtc Certificate")
+	}
+	cert, err := ParseDtcCertificatePEM(pemData)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(cert.NotAfter) {
+		return nil, fmt.Errorf("the certificate '%s' expired on %s", cert.Subject, cert.NotAfter.Format(time.RFC3339))
+	}
+	uploader, ok := objMgr.connector.(IBFileUploader)
+	if !ok {
+		return nil, fmt.Errorf("the connector does not support file uploads")
+	}
+	caller, ok := objMgr.connector.(IBFunctionCaller)
+	if !ok {
+		return nil, fmt.Errorf("the connector does not support WAPI function calls")
+	}
+	token, err := uploader.UploadFile("dtc-certificate.pem", pemData)
+	if err != nil {
+		return nil, fmt.Errorf("error uploading the certificate, err: %s", err)
+	}
+	args := map[string]string{"certificate_usage": dtcCertificateUsage, "member": member, "token": token}
+	if err = caller.CallFunction("fileop", "upload_certificate", args, nil); err != nil {
+		return nil, fmt.Errorf("error uploading the certificate, err: %s", err)
+	}
+
+	// the upload does not return the new object, it is found by its serial number
+	certificates, err := objMgr.GetDtcCertificates()
+	if err != nil {
+		return nil, err
+	}
+	for i := range certificates {
+		if sameDtcCertificateSerial(certificates[i].Serial, cert.SerialNumber) {
+			return &certificates[i], nil
+		}
+	}
+	return nil, NewNotFoundError(fmt.Sprintf("Dtc Certificate with serial number '%x' not found after its upload", cert.SerialNumber))
+}
+
+// SetDtcMonitorClientCertificate sets the DTC certificate with the given
+// reference as the client certificate of the HTTP or SIP monitor, given by
+// its name or reference; an empty certificate removes it. HTTP monitors are
+// switched to HTTPS, with SNI, along with their certificate.
+func (objMgr *ObjectManager) SetDtcMonitorClientCertificate(monitor Monitor, certificate string) (IBObject, error) {
+	if certificate != "" && !strings.HasPrefix(certificate, "dtc:certificate/") {
+		return nil, fmt.Errorf("'%s' is not a reference to a Dtc Certificate", certificate)
+	}
+	upd := &dtcMonitorClientCertUpdate{objectType: "dtc:monitor:" + monitor.Type}
+	if certificate != "" {
+		upd.ClientCert = &certificate
+	}
+	switch monitor.Type {
+	case "http":
+		if certificate != "" {
+			enabled := true
+			upd.Secure, upd.EnableSni = &enabled, &enabled
+		}
+	case "sip":
+	default:
+		return nil, fmt.Errorf("client certificates are only supported by http and sip monitors")
+	}
+	if monitor.Name == "" {
+		return nil, fmt.Errorf("name or reference of the monitor is required to set its client certificate")
+	}
+	refs, err := objMgr.ResolveDtcLinks([]DtcLink{{ObjectType: upd.objectType, Name: monitor.Name}})
+	if err != nil {
+		return nil, err
+	}
+	newRef, err := objMgr.connector.UpdateObject(upd, refs[0])
+	if err != nil {
+		return nil, err
+	}
+	if monitor.Type == "sip" {
+		return &DtcMonitorSip{Ref: newRef, ClientCert: upd.ClientCert}, nil
+	}
+	return &DtcMonitorHttp{Ref: newRef, ClientCert: upd.ClientCert, Secure: upd.Secure, EnableSni: upd.EnableSni}, nil
+}
+
+// DeleteDtcCertificate deletes the DTC certificate, unless a monitor uses it.
+func (objMgr *ObjectManager) DeleteDtcCertificate(ref string) (string, error) {
+	certificate := NewEmptyDtcCertificate()
+	if err := objMgr.connector.GetObject(certificate, ref, NewQueryParams(false, nil), certificate); err != nil {
+		return "", err
+	}
+	if certificate.InUse {
+		return "", fmt.Errorf("the Dtc Certificate '%s' is used by a monitor", ref)
+	}
+	return objMgr.connector.DeleteObject(ref)
+}
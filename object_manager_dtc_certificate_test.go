@@ -0,0 +1,184 @@
+package ibclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// newTestCertificatePEM returns a self-signed certificate with the given
+// serial number and expiry, and its private key, in PEM.
+func newTestCertificatePEM(serial int64, notAfter time.Time) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "monitor.example.com"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).To(BeNil())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+}
+
+var _ = Describe("Object Manager: DTC certificate", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	certRef := "dtc:certificate/ZG5zLmR0Y19jZXJ0aWZpY2F0ZSQw:monitor.example.com"
+	x509Ref := "grid:x509certificate/b25lLng1MDlfY2VydGlmaWNhdGUkMA:monitor.example.com"
+	notAfter := time.Now().Add(10 * 24 * time.Hour).Truncate(time.Second)
+
+	Describe("Parse a PEM document", func() {
+		certPEM, keyPEM := newTestCertificatePEM(2587, notAfter)
+		_, otherKeyPEM := newTestCertificatePEM(1, notAfter)
+
+		It("should return the certificate along with a matching key", func() {
+			cert, err := ParseDtcCertificatePEM(append(certPEM, keyPEM...))
+			Expect(err).To(BeNil())
+			Expect(cert.SerialNumber.Int64()).To(Equal(int64(2587)))
+			Expect(cert.Subject.CommonName).To(Equal("monitor.example.com"))
+		})
+
+		It("should fail if the key does not match the certificate", func() {
+			_, err := ParseDtcCertificatePEM(append(certPEM, otherKeyPEM...))
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(HavePrefix("invalid private key"))
+		})
+
+		It("should fail without exactly one certificate", func() {
+			_, err := ParseDtcCertificatePEM(keyPEM)
+			Expect(err).To(MatchError("no certificate found in the PEM document"))
+			_, err = ParseDtcCertificatePEM(append(certPEM, certPEM...))
+			Expect(err).To(MatchError("more than one certificate in the PEM document"))
+		})
+	})
+
+	Describe("Upload a certificate", func() {
+		certPEM, keyPEM := newTestCertificatePEM(2587, notAfter)
+		pemData := append(certPEM, keyPEM...)
+		conn := &fakeConnector{
+			uploadFileName:   "dtc-certificate.pem",
+			uploadFileData:   pemData,
+			uploadFileToken:  "upload-token",
+			callFunctionRef:  "fileop",
+			callFunctionName: "upload_certificate",
+			callFunctionArgs: map[string]string{"certificate_usage": dtcCertificateUsage, "member": "infoblox.localdomain", "token": "upload-token"},
+			getObjectObj:     map[string]interface{}{},
+			resultObject: map[string]interface{}{
+				"DtcCertificate": []DtcCertificate{{Ref: certRef, Certificate: x509Ref}},
+				"GridX509certificate": GridX509certificate{
+					Ref:           x509Ref,
+					Serial:        "0a:1b",
+					Subject:       "CN=monitor.example.com",
+					ValidNotAfter: &UnixTime{notAfter},
+				},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should upload it and return the new certificate", func() {
+			info, err := objMgr.UploadDtcCertificate(pemData, "infoblox.localdomain")
+			Expect(err).To(BeNil())
+			Expect(*info).To(Equal(DtcCertificateInfo{
+				Ref:           certRef,
+				Certificate:   x509Ref,
+				Serial:        "0a:1b",
+				Subject:       "CN=monitor.example.com",
+				ValidNotAfter: &UnixTime{notAfter},
+			}))
+		})
+
+		It("should report the certificates expiring within the given duration", func() {
+			expiring, err := objMgr.GetExpiringDtcCertificates(30 * 24 * time.Hour)
+			Expect(err).To(BeNil())
+			Expect(expiring).To(HaveLen(1))
+			Expect(expiring[0].Ref).To(Equal(certRef))
+
+			expiring, err = objMgr.GetExpiringDtcCertificates(24 * time.Hour)
+			Expect(err).To(BeNil())
+			Expect(expiring).To(BeEmpty())
+		})
+
+		It("should reject an expired certificate", func() {
+			expiredPEM, expiredKeyPEM := newTestCertificatePEM(3, time.Now().Add(-time.Hour))
+			_, err := objMgr.UploadDtcCertificate(append(expiredPEM, expiredKeyPEM...), "infoblox.localdomain")
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("CN=monitor.example.com' expired on"))
+		})
+	})
+
+	Describe("Set the client certificate of a monitor", func() {
+		monitorRef := "dtc:monitor:http/ZG5zLmlkbnNfbW9uaXRvcl9odHRwJGh0dHBz:https"
+		enabled := true
+		cert := certRef
+		conn := &fakeConnector{
+			getObjectObj: map[string]interface{}{},
+			resultObject: map[string]interface{}{
+				"DtcMonitor": []DtcMonitorHttp{{Ref: monitorRef}},
+			},
+			updateObjectObj: &dtcMonitorClientCertUpdate{
+				objectType: "dtc:monitor:http",
+				ClientCert: &cert,
+				Secure:     &enabled,
+				EnableSni:  &enabled,
+			},
+			updateObjectRef: monitorRef,
+			fakeRefReturn:   monitorRef,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should switch the HTTP monitor to HTTPS with the certificate", func() {
+			monitor, err := objMgr.SetDtcMonitorClientCertificate(Monitor{Name: "https", Type: "http"}, certRef)
+			Expect(err).To(BeNil())
+			Expect(monitor.(*DtcMonitorHttp).Ref).To(Equal(monitorRef))
+			Expect(*monitor.(*DtcMonitorHttp).ClientCert).To(Equal(certRef))
+		})
+
+		It("should send null to remove the certificate", func() {
+			data, err := json.Marshal(&dtcMonitorClientCertUpdate{objectType: "dtc:monitor:sip"})
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(`{"client_cert":null}`))
+		})
+
+		It("should only accept HTTP and SIP monitors", func() {
+			_, err := objMgr.SetDtcMonitorClientCertificate(Monitor{Name: "ping", Type: "icmp"}, certRef)
+			Expect(err).To(MatchError("client certificates are only supported by http and sip monitors"))
+		})
+	})
+
+	Describe("Delete a certificate", func() {
+		It("should delete a certificate which is not used", func() {
+			conn := &fakeConnector{
+				getObjectObj:    map[string]interface{}{},
+				resultObject:    map[string]interface{}{"DtcCertificate": DtcCertificate{Ref: certRef}},
+				deleteObjectRef: certRef,
+				fakeRefReturn:   certRef,
+			}
+			ref, err := NewObjectManager(conn, cmpType, tenantID).DeleteDtcCertificate(certRef)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(certRef))
+		})
+
+		It("should not delete a certificate used by a monitor", func() {
+			conn := &fakeConnector{
+				getObjectObj: map[string]interface{}{},
+				resultObject: map[string]interface{}{"DtcCertificate": DtcCertificate{Ref: certRef, InUse: true}},
+			}
+			_, err := NewObjectManager(conn, cmpType, tenantID).DeleteDtcCertificate(certRef)
+			Expect(err).To(MatchError("the Dtc Certificate '" + certRef + "' is used by a monitor"))
+		})
+	})
+})
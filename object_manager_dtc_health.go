@@ -0,0 +1,212 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DtcObjectHealth is the health of a DTC LBDN, pool or server.
+type DtcObjectHealth struct {
+	ObjectType   string // dtc:lbdn, dtc:pool or dtc:server
+	Ref          string
+	Name         string
+	Availability string // the availability color: GREEN, YELLOW, RED, BLUE, GRAY or NONE
+	EnabledState string
+	Description  string
+	Status       string    // the status of the DTC object of the LBDN, pool or server, empty if unknown
+	StatusTime   *UnixTime // the time the status was last determined, nil if unknown
+	Monitors     []DtcMonitorHealth
+	Members      []DtcObjectHealth // the health of the pools of an LBDN or of the servers of a pool
+}
+
+// DtcMonitorHealth is a monitor checking a pool or a server. It carries no
+// status: WAPI does not report the result of each monitor, neither through
+// the monitors nor through the DTC objects. Their combined outcome is the
+// health of the servers they check, given in the Members of the pool.
+type DtcMonitorHealth struct {
+	Monitor      string // reference of the monitor
+	Host         string // the address or FQDN checked instead of the server host, if any
+	Consolidated bool   // the statuses are shared across the grid members serving the pool
+	Availability string // ANY or ALL grid members must report the servers healthy, if consolidated
+}
+
+// DtcHealthChange is sent by WatchDtcObjectHealth when the health of an
+// object changes. Previous is nil for the first health read of the object;
+// Err is set, and Current is nil, if the health could not be read.
+type DtcHealthChange struct {
+	Ref      string
+	Previous *DtcObjectHealth
+	Current  *DtcObjectHealth
+	Err      error
+}
+
+func newDtcObjectHealth(objType string, ref string, name *string, health *DtcHealth) *DtcObjectHealth {
+	res := &DtcObjectHealth{ObjectType: objType, Ref: ref}
+	if name != nil {
+		res.Name = *name
+	}
+	if health != nil {
+		res.Availability = health.Availability
+		res.EnabledState = health.EnabledState
+		res.Description = health.Description
+	}
+	return res
+}
+
+// getDtcObjectHealth reads the health of the object and, if requested, of its members.
+func (objMgr *ObjectManager) getDtcObjectHealth(ref string, withMembers bool) (*DtcObjectHealth, error) {
+	var res *DtcObjectHealth
+	var members []string
+	qp := NewQueryParams(false, nil)
+	switch objType := strings.SplitN(ref, "/", 2)[0]; objType {
+	case "dtc:lbdn":
+		lbdn := &DtcLbdn{}
+		lbdn.SetReturnFields([]string{"name", "health", "pools"})
+		if err := objMgr.connector.GetObject(lbdn, ref, qp, &lbdn); err != nil {
+			return nil, err
+		}
+		res = newDtcObjectHealth(objType, ref, lbdn.Name, lbdn.Health)
+		for _, link := range lbdn.Pools {
+			members = append(members, link.Pool)
+		}
+	case "dtc:pool":
+		pool := &DtcPool{}
+		pool.SetReturnFields([]string{"name", "health", "servers", "monitors", "consolidated_monitors"})
+		if err := objMgr.connector.GetObject(pool, ref, qp, &pool); err != nil {
+			return nil, err
+		}
+		res = newDtcObjectHealth(objType, ref, pool.Name, pool.Health)
+		for _, monitor := range pool.Monitors {
+			monitorHealth := DtcMonitorHealth{Monitor: monitor.Ref}
+			for _, cm := range pool.ConsolidatedMonitors {
+				if cm.Monitor == monitor.Ref {
+					monitorHealth.Consolidated = true
+					monitorHealth.Availability = cm.Availability
+				}
+			}
+			res.Monitors = append(res.Monitors, monitorHealth)
+		}
+		for _, link := range pool.Servers {
+			members = append(members, link.Server)
+		}
+	case "dtc:server":
+		server := &DtcServer{}
+		server.SetReturnFields([]string{"name", "health", "monitors"})
+		if err := objMgr.connector.GetObject(server, ref, qp, &server); err != nil {
+			return nil, err
+		}
+		res = newDtcObjectHealth(objType, ref, server.Name, server.Health)
+		for _, monitor := range server.Monitors {
+			res.Monitors = append(res.Monitors, DtcMonitorHealth{Monitor: monitor.Monitor, Host: monitor.Host})
+		}
+	default:
+		return nil, fmt.Errorf("'%s' is not a reference to a Dtc Lbdn, Pool or Server", ref)
+	}
+
+	// the status and its time are only given by the DTC object of the LBDN, pool or server
+	var objects []DtcObject
+	dtcObject := &DtcObject{}
+	dtcObject.SetReturnFields([]string{"object", "status", "status_time"})
+	err := objMgr.getObjects(dtcObject, NewQueryParams(false, map[string]string{"object": ref}), &objects)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) > 0 {
+		res.Status = objects[0].Status
+		res.StatusTime = objects[0].StatusTime
+	}
+
+	if withMembers {
+		for _, member := range members {
+			memberHealth, err := objMgr.getDtcObjectHealth(member, false)
+			if err != nil {
+				return nil, err
+			}
+			res.Members = append(res.Members, *memberHealth)
+		}
+	}
+	return res, nil
+}
+
+// GetDtcObjectHealth returns the health of the DTC LBDN, pool or server
+// with the given reference, along with the health of its pools or servers.
+func (objMgr *ObjectManager) GetDtcObjectHealth(ref string) (*DtcObjectHealth, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty reference to an object is not allowed")
+	}
+	return objMgr.getDtcObjectHealth(ref, true)
+}
+
+// dtcHealthChanged tells whether the availability, state, description,
+// status or monitors of the object or of one of its members differ.
+func dtcHealthChanged(prev *DtcObjectHealth, cur *DtcObjectHealth) bool {
+	if prev.Availability != cur.Availability || prev.EnabledState != cur.EnabledState ||
+		prev.Description != cur.Description || prev.Status != cur.Status ||
+		len(prev.Monitors) != len(cur.Monitors) || len(prev.Members) != len(cur.Members) {
+		return true
+	}
+	for i := range prev.Monitors {
+		if prev.Monitors[i] != cur.Monitors[i] {
+			return true
+		}
+	}
+	for i := range prev.Members {
+		if prev.Members[i].Ref != cur.Members[i].Ref || dtcHealthChanged(&prev.Members[i], &cur.Members[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// WatchDtcObjectHealth reads the health of the DTC LBDNs, pools or servers
+// with the given references every interval, and sends a DtcHealthChange
+// whenever the health of one of them, or of one of its members, changes.
+// The first health read of each object is sent as well. The returned
+// channel is closed once stop is closed.
+func (objMgr *ObjectManager) WatchDtcObjectHealth(refs []string, interval time.Duration, stop <-chan struct{}) (<-chan DtcHealthChange, error) {
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("at least one reference is required to watch the health of Dtc objects")
+	}
+	for _, ref := range refs {
+		switch strings.SplitN(ref, "/", 2)[0] {
+		case "dtc:lbdn", "dtc:pool", "dtc:server":
+		default:
+			return nil, fmt.Errorf("'%s' is not a reference to a Dtc Lbdn, Pool or Server", ref)
+		}
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("the interval between health reads must be positive")
+	}
+
+	changes := make(chan DtcHealthChange)
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		last := make(map[string]*DtcObjectHealth, len(refs))
+		for {
+			for _, ref := range refs {
+				change := DtcHealthChange{Ref: ref, Previous: last[ref]}
+				change.Current, change.Err = objMgr.getDtcObjectHealth(ref, true)
+				if change.Err == nil {
+					if change.Previous != nil && !dtcHealthChanged(change.Previous, change.Current) {
+						continue
+					}
+					last[ref] = change.Current
+				}
+				select {
+				case changes <- change:
+				case <-stop:
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
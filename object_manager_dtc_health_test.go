@@ -0,0 +1,116 @@
+package ibclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: DTC health", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	poolRef := "dtc:pool/ZG5zLmlkbnNfcG9vbCRldS1wb29s:eu-pool"
+	serverRef := "dtc:server/ZG5zLmlkbnNfc2VydmVyJGV1LTE:eu-1"
+	monitorRef := "dtc:monitor:http/ZG5zLmlkbnNfbW9uaXRvcl9odHRwJGh0dHA:http"
+	statusTime := &UnixTime{time.Unix(1700000000, 0)}
+
+	newConn := func() *fakeConnector {
+		return &fakeConnector{
+			getObjectObj: map[string]interface{}{"DtcPool": &DtcPool{}},
+			resultObject: map[string]interface{}{
+				"DtcPool": &DtcPool{
+					Ref:      poolRef,
+					Name:     utils.StringPtr("eu-pool"),
+					Health:   &DtcHealth{Availability: "YELLOW", EnabledState: "ENABLED", Description: "1 of 2 servers down"},
+					Servers:  []*DtcServerLink{{Server: serverRef, Ratio: 1}},
+					Monitors: []*DtcMonitorHttp{{Ref: monitorRef}},
+					ConsolidatedMonitors: []*DtcPoolConsolidatedMonitorHealth{
+						{Monitor: monitorRef, Availability: "ALL", Members: []string{"infoblox.localdomain"}}},
+				},
+				"DtcServer": &DtcServer{
+					Ref:      serverRef,
+					Name:     utils.StringPtr("eu-1"),
+					Health:   &DtcHealth{Availability: "RED", EnabledState: "ENABLED", Description: "http monitor failed"},
+					Monitors: []*DtcServerMonitor{{Monitor: monitorRef, Host: "10.0.0.10"}},
+				},
+				"DtcObject": []DtcObject{{Object: poolRef, Status: "YELLOW", StatusTime: statusTime}},
+			},
+		}
+	}
+
+	It("should return the health of a pool and of its servers", func() {
+		objMgr := NewObjectManager(newConn(), cmpType, tenantID)
+		health, err := objMgr.GetDtcObjectHealth(poolRef)
+		Expect(err).To(BeNil())
+		Expect(*health).To(Equal(DtcObjectHealth{
+			ObjectType:   "dtc:pool",
+			Ref:          poolRef,
+			Name:         "eu-pool",
+			Availability: "YELLOW",
+			EnabledState: "ENABLED",
+			Description:  "1 of 2 servers down",
+			Status:       "YELLOW",
+			StatusTime:   statusTime,
+			Monitors:     []DtcMonitorHealth{{Monitor: monitorRef, Consolidated: true, Availability: "ALL"}},
+			Members: []DtcObjectHealth{{
+				ObjectType:   "dtc:server",
+				Ref:          serverRef,
+				Name:         "eu-1",
+				Availability: "RED",
+				EnabledState: "ENABLED",
+				Description:  "http monitor failed",
+				Status:       "YELLOW",
+				StatusTime:   statusTime,
+				Monitors:     []DtcMonitorHealth{{Monitor: monitorRef, Host: "10.0.0.10"}},
+			}},
+		}))
+	})
+
+	It("should reject a reference to another object", func() {
+		objMgr := NewObjectManager(newConn(), cmpType, tenantID)
+		_, err := objMgr.GetDtcObjectHealth(monitorRef)
+		Expect(err).To(Equal(fmt.Errorf("'%s' is not a reference to a Dtc Lbdn, Pool or Server", monitorRef)))
+	})
+
+	It("should only send the health when it changes", func() {
+		objMgr := NewObjectManager(newConn(), cmpType, tenantID)
+		stop := make(chan struct{})
+		changes, err := objMgr.WatchDtcObjectHealth([]string{poolRef}, 5*time.Millisecond, stop)
+		Expect(err).To(BeNil())
+
+		var change DtcHealthChange
+		Eventually(changes).Should(Receive(&change))
+		Expect(change.Err).To(BeNil())
+		Expect(change.Previous).To(BeNil())
+		Expect(change.Current.Availability).To(Equal("YELLOW"))
+		Consistently(changes, 50*time.Millisecond).ShouldNot(Receive())
+		close(stop)
+		Eventually(changes).Should(BeClosed())
+	})
+
+	It("should detect the changes of the members", func() {
+		prev := &DtcObjectHealth{Availability: "GREEN", Members: []DtcObjectHealth{{Ref: serverRef, Availability: "GREEN"}}}
+		cur := &DtcObjectHealth{Availability: "GREEN", Members: []DtcObjectHealth{{Ref: serverRef, Availability: "RED"}}}
+		Expect(dtcHealthChanged(prev, prev)).To(BeFalse())
+		Expect(dtcHealthChanged(prev, cur)).To(BeTrue())
+	})
+
+	It("should detect the changes of the status and of the monitors", func() {
+		prev := &DtcObjectHealth{Availability: "GREEN", Status: "GREEN",
+			Monitors: []DtcMonitorHealth{{Monitor: monitorRef, Host: "10.0.0.10"}}}
+		Expect(dtcHealthChanged(prev, &DtcObjectHealth{Availability: "GREEN", Status: "RED",
+			Monitors: prev.Monitors})).To(BeTrue())
+		Expect(dtcHealthChanged(prev, &DtcObjectHealth{Availability: "GREEN", Status: "GREEN",
+			Monitors: []DtcMonitorHealth{{Monitor: monitorRef, Host: "10.0.0.11"}}})).To(BeTrue())
+		Expect(dtcHealthChanged(prev, &DtcObjectHealth{Availability: "GREEN", Status: "GREEN"})).To(BeTrue())
+	})
+
+	It("should validate the watched references", func() {
+		objMgr := NewObjectManager(newConn(), cmpType, tenantID)
+		_, err := objMgr.WatchDtcObjectHealth([]string{poolRef}, 0, nil)
+		Expect(err).To(Equal(fmt.Errorf("the interval between health reads must be positive")))
+	})
+})
@@ -3,7 +3,6 @@ package ibclient
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
 )
 
 type AuthZonesLink struct {
@@ -86,39 +85,13 @@ func (objMgr *ObjectManager) CreateDtcLbdn(name string, authZones []AuthZonesLin
 	if name == "" || lbMethod == "" {
 		return nil, fmt.Errorf("name and load balancing method fields are required to create a Dtc Lbdn object")
 	}
-	// get ref id of authzones and replace
-	var zones []*ZoneAuth
-	var err error
-	if len(authZones) > 0 {
-		zones, err = getAuthZones(authZones, objMgr)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// get ref id of pools and replace
-	var dtcPoolLink []*DtcPoolLink
-	if len(pools) > 0 {
-		dtcPoolLink, err = getPools(pools, objMgr)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	if lbMethod == "TOPOLOGY" && topology == nil {
 		return nil, fmt.Errorf("topology field is required when load balancing method is TOPOLOGY")
 	}
-	//get ref id of topology and replace
-	var topologyRef string
-	if topology != nil {
-		if *topology == "" && lbMethod != "TOPOLOGY" {
-			topologyRef = ""
-		} else {
-			topologyRef, err = getTopology(*topology, objMgr)
-			if err != nil {
-				return nil, err
-			}
-		}
+	// get ref id of authzones, pools and topology and replace
+	zones, dtcPoolLink, topologyRef, err := resolveDtcLbdnLinks(objMgr, authZones, pools, topology, lbMethod)
+	if err != nil {
+		return nil, err
 	}
 
 	dtcLbdn := NewDtcLbdn("", name, zones, comment, disable, autoConsolidatedMonitors, ea,
@@ -131,68 +104,48 @@ func (objMgr *ObjectManager) CreateDtcLbdn(name string, authZones []AuthZonesLin
 	return dtcLbdn, nil
 }
 
-func getTopology(topology string, objMgr *ObjectManager) (string, error) {
-	var dtcTopology []DtcTopology
-	var topologyRef string
-	if topology == "" {
-		return "", fmt.Errorf("topology field is required to retreive a unique Dtc Topology record")
-	}
-	isRef := regexp.MustCompile("^dtc:topology:*")
-	if !isRef.MatchString(topology) {
-		sf := map[string]string{
-			"name": topology,
-		}
-		err := objMgr.connector.GetObject(&DtcTopology{}, "", NewQueryParams(false, sf), &dtcTopology)
-		if err != nil {
-			return "", fmt.Errorf("error getting Dtc Topology object %s, err: %s", topology, err)
-		}
+func authZoneLink(zone AuthZonesLink) DtcLink {
+	return DtcLink{ObjectType: "zone_auth", Name: zone.Fqdn, View: zone.DnsView}
+}
 
-		if len(dtcTopology) > 0 {
-			topologyRef = dtcTopology[0].Ref
+// resolveDtcLbdnLinks returns the auth zones, the pools and the topology of
+// an LBDN, given by their names or references, with their names resolved at
+// once. An *UnresolvedDtcLinksError lists all the objects not found.
+func resolveDtcLbdnLinks(objMgr *ObjectManager, authZones []AuthZonesLink, pools []*DtcPoolLink, topology *string, lbMethod string) ([]*ZoneAuth, []*DtcPoolLink, string, error) {
+	links := newDtcLinkResolver(objMgr)
+	for _, zone := range authZones {
+		if zone.Fqdn == "" {
+			return nil, nil, "", fmt.Errorf("FQDN or reference is required for each auth zone of a Dtc Lbdn object")
 		}
+		links.add(authZoneLink(zone))
 	}
-	return topologyRef, nil
-}
-
-func getPools(pools []*DtcPoolLink, objMgr *ObjectManager) ([]*DtcPoolLink, error) {
-	var dtcPoolLink []*DtcPoolLink
-
 	for _, pool := range pools {
-		sf := map[string]string{"name": pool.Pool}
-		var dtcPools []DtcPool
-
-		isRef := regexp.MustCompile("^dtc:pool:*")
-		if !isRef.MatchString(pool.Pool) {
-			err := objMgr.connector.GetObject(&DtcPool{}, "", NewQueryParams(false, sf), &dtcPools)
-			if err != nil {
-				return nil, fmt.Errorf("error getting Dtc Pool object %s, err: %s", pool.Pool, err)
-			}
-			if len(dtcPools) > 0 {
-				dtcPoolLink = append(dtcPoolLink, &DtcPoolLink{Pool: dtcPools[0].Ref, Ratio: pool.Ratio})
-			}
+		if pool == nil || pool.Pool == "" {
+			return nil, nil, "", fmt.Errorf("name or reference is required for each pool of a Dtc Lbdn object")
 		}
-
+		links.add(DtcLink{ObjectType: "dtc:pool", Name: pool.Pool})
+	}
+	topologyLink := DtcLink{ObjectType: "dtc:topology"}
+	if topology != nil && (*topology != "" || lbMethod == "TOPOLOGY") {
+		if *topology == "" {
+			return nil, nil, "", fmt.Errorf("topology field is required to retreive a unique Dtc Topology record")
+		}
+		topologyLink.Name = *topology
+		links.add(topologyLink)
+	}
+	if err := links.resolve(); err != nil {
+		return nil, nil, "", err
 	}
-	return dtcPoolLink, nil
-}
 
-func getAuthZones(authZones []AuthZonesLink, objMgr *ObjectManager) ([]*ZoneAuth, error) {
 	var zones []*ZoneAuth
-	for _, authZone := range authZones {
-		sf := map[string]string{
-			"fqdn": authZone.Fqdn,
-			"view": authZone.DnsView,
-		}
-		var zoneAuth []ZoneAuth
-		err := objMgr.connector.GetObject(&ZoneAuth{}, "", NewQueryParams(false, sf), &zoneAuth)
-		if err != nil {
-			return nil, fmt.Errorf("error getting ZoneAuth object %s in %s DNS view, err: %s", authZone.Fqdn, authZone.DnsView, err)
-		}
-		if len(zoneAuth) > 0 {
-			zones = append(zones, &zoneAuth[0])
-		}
+	for _, zone := range authZones {
+		zones = append(zones, &ZoneAuth{Ref: links.ref(authZoneLink(zone)), Fqdn: zone.Fqdn})
 	}
-	return zones, nil
+	var dtcPoolLink []*DtcPoolLink
+	for _, pool := range pools {
+		dtcPoolLink = append(dtcPoolLink, &DtcPoolLink{Pool: links.ref(DtcLink{ObjectType: "dtc:pool", Name: pool.Pool}), Ratio: pool.Ratio})
+	}
+	return zones, dtcPoolLink, links.ref(topologyLink), nil
 }
 
 func NewDtcLbdn(ref string, name string, authZones []*ZoneAuth, comment string, disable bool, autoConsolidatedMonitors bool, ea EA,
@@ -274,36 +227,10 @@ func (objMgr *ObjectManager) UpdateDtcLbdn(ref string, name string, authZones []
 	if lbMethod == "TOPOLOGY" && topology == nil {
 		return nil, fmt.Errorf("topology field is required when load balancing method is TOPOLOGY")
 	}
-	// get ref id of authzones and replace
-	var zones []*ZoneAuth
-	var err error
-	if len(authZones) > 0 {
-		zones, err = getAuthZones(authZones, objMgr)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// get ref id of pools and replace
-	var dtcPoolLink []*DtcPoolLink
-	if len(pools) > 0 {
-		dtcPoolLink, err = getPools(pools, objMgr)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	//get ref id of topology and replace
-	var topologyRef string
-	if topology != nil {
-		if *topology == "" && lbMethod != "TOPOLOGY" {
-			topologyRef = ""
-		} else {
-			topologyRef, err = getTopology(*topology, objMgr)
-			if err != nil {
-				return nil, err
-			}
-		}
+	// get ref id of authzones, pools and topology and replace
+	zones, dtcPoolLink, topologyRef, err := resolveDtcLbdnLinks(objMgr, authZones, pools, topology, lbMethod)
+	if err != nil {
+		return nil, err
 	}
 
 	dtcLbdn := NewDtcLbdn(ref, name, zones, comment, disable, autoConsolidatedMonitors, ea,
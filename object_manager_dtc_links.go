@@ -0,0 +1,249 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DtcLink is an object linked to a DTC object, given by its name or its
+// reference.
+type DtcLink struct {
+	ObjectType string // dtc:server, dtc:pool, dtc:topology, dtc:monitor:<type> or zone_auth
+	Name       string // the name, the FQDN of an auth zone, or the reference of the object
+	View       string // the DNS view of an auth zone, any view if empty
+}
+
+func (l DtcLink) String() string {
+	if l.ObjectType == "zone_auth" && l.View != "" {
+		return fmt.Sprintf("%s '%s' in view '%s'", l.ObjectType, l.Name, l.View)
+	}
+	return fmt.Sprintf("%s '%s'", l.ObjectType, l.Name)
+}
+
+// isRef tells whether the link is given by the reference of the object.
+func (l DtcLink) isRef() bool {
+	return strings.HasPrefix(l.Name, l.ObjectType+"/")
+}
+
+func (l DtcLink) searchFields() map[string]string {
+	if l.ObjectType != "zone_auth" {
+		return map[string]string{"name": l.Name}
+	}
+	sf := map[string]string{"fqdn": l.Name}
+	if l.View != "" {
+		sf["view"] = l.View
+	}
+	return sf
+}
+
+// UnresolvedDtcLinksError is returned when objects linked to a DTC object
+// are not found. It lists all of them.
+type UnresolvedDtcLinksError struct {
+	Links []DtcLink
+}
+
+func (e *UnresolvedDtcLinksError) Error() string {
+	links := make([]string, len(e.Links))
+	for i, l := range e.Links {
+		links[i] = l.String()
+	}
+	return fmt.Sprintf("linked objects not found: %s", strings.Join(links, ", "))
+}
+
+func NewUnresolvedDtcLinksError(links []DtcLink) *UnresolvedDtcLinksError {
+	return &UnresolvedDtcLinksError{Links: links}
+}
+
+func newAmbiguousDtcLinkError(link DtcLink, count int) error {
+	if link.ObjectType == "zone_auth" && link.View == "" {
+		return fmt.Errorf("%s matches %d objects, its view is required", link, count)
+	}
+	return fmt.Errorf("%s matches %d objects, a single object is required", link, count)
+}
+
+func newDtcLinkObject(objType string) (IBObject, error) {
+	switch objType {
+	case "dtc:server":
+		return &DtcServer{}, nil
+	case "dtc:pool":
+		return &DtcPool{}, nil
+	case "dtc:topology":
+		return &DtcTopology{}, nil
+	case "zone_auth":
+		return &ZoneAuth{}, nil
+	}
+	if strings.HasPrefix(objType, "dtc:monitor:") {
+		return newDtcMonitor(strings.TrimPrefix(objType, "dtc:monitor:"))
+	}
+	return nil, fmt.Errorf("invalid Dtc link object type '%s'", objType)
+}
+
+// dtcLinkResolver resolves the names of the objects linked to a DTC object
+// to their references, all at once.
+type dtcLinkResolver struct {
+	objMgr *ObjectManager
+	links  []DtcLink
+	refs   map[DtcLink]string
+}
+
+func newDtcLinkResolver(objMgr *ObjectManager) *dtcLinkResolver {
+	return &dtcLinkResolver{objMgr: objMgr, refs: make(map[DtcLink]string)}
+}
+
+// add registers a link to resolve; links without a name are ignored.
+func (r *dtcLinkResolver) add(link DtcLink) {
+	if link.Name == "" {
+		return
+	}
+	if _, ok := r.refs[link]; ok {
+		return
+	}
+	r.refs[link] = ""
+	if link.isRef() {
+		r.refs[link] = link.Name
+		return
+	}
+	r.links = append(r.links, link)
+}
+
+func (r *dtcLinkResolver) addMonitor(monitor Monitor) {
+	if monitor.Type != "" {
+		r.add(DtcLink{ObjectType: "dtc:monitor:" + monitor.Type, Name: monitor.Name})
+	}
+}
+
+// ref returns the reference of a resolved link, or an empty string.
+func (r *dtcLinkResolver) ref(link DtcLink) string {
+	return r.refs[link]
+}
+
+// setRef records the reference of a link looked up by other means.
+func (r *dtcLinkResolver) setRef(link DtcLink, ref string) {
+	r.refs[link] = ref
+}
+
+func (r *dtcLinkResolver) monitorRef(monitor Monitor) string {
+	if monitor.Type == "" {
+		return ""
+	}
+	return r.ref(DtcLink{ObjectType: "dtc:monitor:" + monitor.Type, Name: monitor.Name})
+}
+
+// resolve looks up the references of the links added since the last call,
+// with a single multi-request if the connector allows it. All the links
+// not found are reported in an *UnresolvedDtcLinksError.
+func (r *dtcLinkResolver) resolve() error {
+	links := r.links
+	r.links = nil
+	if len(links) == 0 {
+		return nil
+	}
+	for _, link := range links {
+		if _, err := newDtcLinkObject(link.ObjectType); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if conn, ok := r.objMgr.wapiConnector(); ok {
+		err = r.resolveBulk(conn, links)
+	} else {
+		err = r.resolveEach(links)
+	}
+	if err != nil {
+		return err
+	}
+
+	var missing []DtcLink
+	for _, link := range links {
+		if r.refs[link] == "" {
+			missing = append(missing, link)
+		}
+	}
+	if len(missing) > 0 {
+		return NewUnresolvedDtcLinksError(missing)
+	}
+	return nil
+}
+
+func (r *dtcLinkResolver) resolveBulk(conn *Connector, links []DtcLink) error {
+	body := make([]*RequestBody, 0, len(links))
+	for _, link := range links {
+		data := make(map[string]interface{})
+		for k, v := range link.searchFields() {
+			data[k] = v
+		}
+		// no return fields are asked for, the references are always returned
+		body = append(body, &RequestBody{
+			Method: "GET",
+			Object: link.ObjectType,
+			Data:   data,
+		})
+	}
+	res, err := conn.makeRequest(CREATE, NewMultiRequest(body), "", NewQueryParams(false, nil))
+	if err != nil {
+		return fmt.Errorf("error resolving the linked objects, err: %s", err)
+	}
+	var results [][]struct {
+		Ref string `json:"_ref"`
+	}
+	if err = json.Unmarshal(res, &results); err != nil {
+		return err
+	}
+	if len(results) != len(links) {
+		return fmt.Errorf("error resolving the linked objects, %d results received for %d objects", len(results), len(links))
+	}
+	for i, found := range results {
+		if len(found) > 1 {
+			return newAmbiguousDtcLinkError(links[i], len(found))
+		}
+		if len(found) > 0 {
+			r.refs[links[i]] = found[0].Ref
+		}
+	}
+	return nil
+}
+
+func (r *dtcLinkResolver) resolveEach(links []DtcLink) error {
+	for _, link := range links {
+		obj, _ := newDtcLinkObject(link.ObjectType)
+		res := reflect.New(reflect.SliceOf(reflect.TypeOf(obj).Elem()))
+		err := r.objMgr.getObjects(obj, NewQueryParams(false, link.searchFields()), res.Interface())
+		if err != nil {
+			return fmt.Errorf("error getting %s, err: %s", link, err)
+		}
+		if res.Elem().Len() > 1 {
+			return newAmbiguousDtcLinkError(link, res.Elem().Len())
+		}
+		if res.Elem().Len() > 0 {
+			r.refs[link] = dtcObjectRef(res.Elem().Index(0).Addr().Interface().(IBObject))
+		}
+	}
+	return nil
+}
+
+// ResolveDtcLinks returns the references of the given objects, in the same
+// order. The names are resolved with a single request; an
+// *UnresolvedDtcLinksError lists all the objects which are not found.
+func (objMgr *ObjectManager) ResolveDtcLinks(links []DtcLink) ([]string, error) {
+	r := newDtcLinkResolver(objMgr)
+	for _, link := range links {
+		if link.Name == "" {
+			return nil, fmt.Errorf("name or reference is required for each linked %s object", link.ObjectType)
+		}
+		if _, err := newDtcLinkObject(link.ObjectType); err != nil {
+			return nil, err
+		}
+		r.add(link)
+	}
+	if err := r.resolve(); err != nil {
+		return nil, err
+	}
+	refs := make([]string, len(links))
+	for i, link := range links {
+		refs[i] = r.ref(link)
+	}
+	return refs, nil
+}
@@ -0,0 +1,103 @@
+package ibclient
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: DTC links", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	poolRef := "dtc:pool/ZG5zLmlkbnNfcG9vbCRldS1wb29s:eu-pool"
+	otherPoolRef := "dtc:pool/ZG5zLmlkbnNfcG9vbCR1cy1wb29s:us-pool"
+	zoneRef := "zone_auth/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxl:example.com/default"
+
+	Describe("Resolve links with a single multi-request", func() {
+		urlStr := "https://172.22.18.66:443/wapi/v2.12/request"
+		httpReq, _ := http.NewRequest(CREATE.toMethod(), urlStr, bytes.NewBuffer([]byte{}))
+		frb := &FakeRequestBuilder{
+			r: CREATE,
+			obj: NewMultiRequest([]*RequestBody{
+				{Method: "GET", Object: "dtc:pool", Data: map[string]interface{}{"name": "eu-pool"}},
+				{Method: "GET", Object: "zone_auth", Data: map[string]interface{}{"fqdn": "example.com", "view": "default"}},
+				{Method: "GET", Object: "dtc:monitor:http", Data: map[string]interface{}{"name": "https"}},
+			}),
+			urlStr: urlStr,
+			req:    httpReq,
+		}
+		fhr := &FakeHttpRequestor{
+			req: httpReq,
+			res: []byte(`[[{"_ref":"` + poolRef + `","name":"eu-pool"}],[{"_ref":"` + zoneRef + `"}],[]]`),
+		}
+		OrigValidateConnector := ValidateConnector
+		ValidateConnector = MockValidateConnector
+		defer func() { ValidateConnector = OrigValidateConnector }()
+		conn, err := NewConnector(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+			AuthConfig{Username: "admin", Password: "infoblox"}, NewTransportConfig("false", 20, 10), frb, fhr)
+		if err != nil {
+			Fail("Error creating Connector")
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should list every object which is not found", func() {
+			_, err := objMgr.ResolveDtcLinks([]DtcLink{
+				{ObjectType: "dtc:pool", Name: "eu-pool"},
+				{ObjectType: "dtc:pool", Name: otherPoolRef},
+				{ObjectType: "zone_auth", Name: "example.com", View: "default"},
+				{ObjectType: "dtc:monitor:http", Name: "https"},
+				{ObjectType: "dtc:pool", Name: "eu-pool"},
+			})
+			Expect(err).To(Equal(NewUnresolvedDtcLinksError([]DtcLink{{ObjectType: "dtc:monitor:http", Name: "https"}})))
+			Expect(err.Error()).To(Equal("linked objects not found: dtc:monitor:http 'https'"))
+		})
+	})
+
+	Describe("Resolve links of an LBDN", func() {
+		newConn := func(pools []DtcPool) *fakeConnector {
+			return &fakeConnector{
+				getObjectObj: map[string]interface{}{"DtcPool": &DtcPool{}},
+				resultObject: map[string]interface{}{"DtcPool": pools},
+			}
+		}
+
+		It("should keep the pools given by reference", func() {
+			objMgr := NewObjectManager(newConn([]DtcPool{{Ref: poolRef, Name: utils.StringPtr("eu-pool")}}), cmpType, tenantID)
+			zones, pools, topology, err := resolveDtcLbdnLinks(objMgr.(*ObjectManager), nil,
+				[]*DtcPoolLink{{Pool: "eu-pool", Ratio: 2}, {Pool: otherPoolRef, Ratio: 1}}, nil, "RATIO")
+			Expect(err).To(BeNil())
+			Expect(zones).To(BeNil())
+			Expect(topology).To(BeEmpty())
+			Expect(pools).To(Equal([]*DtcPoolLink{{Pool: poolRef, Ratio: 2}, {Pool: otherPoolRef, Ratio: 1}}))
+		})
+
+		It("should fail instead of dropping the pools not found", func() {
+			objMgr := NewObjectManager(newConn([]DtcPool{}), cmpType, tenantID)
+			_, err := objMgr.CreateDtcLbdn("lbdn", nil, "", false, false, nil, "RATIO", nil, 0,
+				[]*DtcPoolLink{{Pool: "eu-pool", Ratio: 2}, {Pool: "us-pool", Ratio: 1}}, 0, nil, nil, 0, false)
+			Expect(err).To(Equal(NewUnresolvedDtcLinksError([]DtcLink{
+				{ObjectType: "dtc:pool", Name: "eu-pool"},
+				{ObjectType: "dtc:pool", Name: "us-pool"},
+			})))
+		})
+	})
+
+	It("should report a name matching several objects", func() {
+		conn := &fakeConnector{
+			getObjectObj: map[string]interface{}{"DtcPool": &DtcPool{}},
+			resultObject: map[string]interface{}{"DtcPool": []DtcPool{{Ref: poolRef}, {Ref: otherPoolRef}}},
+		}
+		_, err := NewObjectManager(conn, cmpType, tenantID).ResolveDtcLinks([]DtcLink{{ObjectType: "dtc:pool", Name: "eu-pool"}})
+		Expect(err).To(MatchError("dtc:pool 'eu-pool' matches 2 objects, a single object is required"))
+	})
+
+	It("should reject an unknown object type", func() {
+		objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+		_, err := objMgr.ResolveDtcLinks([]DtcLink{{ObjectType: "dtc:lbdn", Name: "lbdn"}})
+		Expect(err).To(Equal(fmt.Errorf("invalid Dtc link object type 'dtc:lbdn'")))
+	})
+})
@@ -0,0 +1,270 @@
+package ibclient
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+func NewEmptyDtcMonitorHttp() *DtcMonitorHttp {
+	monitor := &DtcMonitorHttp{}
+	monitor.SetReturnFields(append(monitor.ReturnFields(), "ciphers", "client_cert", "content_check", "content_check_input",
+		"content_check_op", "content_check_regex", "content_extract_group", "content_extract_type", "content_extract_value",
+		"enable_sni", "extattrs", "interval", "port", "request", "result", "result_code", "retry_down", "retry_up", "secure",
+		"timeout", "validate_cert"))
+	return monitor
+}
+
+func NewEmptyDtcMonitorIcmp() *DtcMonitorIcmp {
+	monitor := &DtcMonitorIcmp{}
+	monitor.SetReturnFields(append(monitor.ReturnFields(), "extattrs", "interval", "retry_down", "retry_up", "timeout"))
+	return monitor
+}
+
+func NewEmptyDtcMonitorTcp() *DtcMonitorTcp {
+	monitor := &DtcMonitorTcp{}
+	monitor.SetReturnFields(append(monitor.ReturnFields(), "extattrs", "interval", "port", "retry_down", "retry_up", "timeout"))
+	return monitor
+}
+
+func NewEmptyDtcMonitorSip() *DtcMonitorSip {
+	monitor := &DtcMonitorSip{}
+	monitor.SetReturnFields(append(monitor.ReturnFields(), "ciphers", "client_cert", "extattrs", "interval", "port", "request",
+		"result", "result_code", "retry_down", "retry_up", "timeout", "transport", "validate_cert"))
+	return monitor
+}
+
+func NewEmptyDtcMonitorPdp() *DtcMonitorPdp {
+	monitor := &DtcMonitorPdp{}
+	monitor.SetReturnFields(append(monitor.ReturnFields(), "extattrs", "interval", "port", "retry_down", "retry_up", "timeout"))
+	return monitor
+}
+
+func NewEmptyDtcMonitorSnmp() *DtcMonitorSnmp {
+	monitor := &DtcMonitorSnmp{}
+	monitor.SetReturnFields(append(monitor.ReturnFields(), "community", "context", "engine_id", "extattrs", "interval", "oids",
+		"port", "retry_down", "retry_up", "timeout", "user", "version"))
+	return monitor
+}
+
+// dtcMonitorTypes maps the monitor types, as used in Monitor.Type, to their objects.
+var dtcMonitorTypes = map[string]func() IBObject{
+	"http": func() IBObject { return NewEmptyDtcMonitorHttp() },
+	"icmp": func() IBObject { return NewEmptyDtcMonitorIcmp() },
+	"tcp":  func() IBObject { return NewEmptyDtcMonitorTcp() },
+	"sip":  func() IBObject { return NewEmptyDtcMonitorSip() },
+	"pdp":  func() IBObject { return NewEmptyDtcMonitorPdp() },
+	"snmp": func() IBObject { return NewEmptyDtcMonitorSnmp() },
+}
+
+func newDtcMonitor(monitorType string) (IBObject, error) {
+	newMonitor, ok := dtcMonitorTypes[monitorType]
+	if !ok {
+		return nil, fmt.Errorf("invalid Dtc Monitor type '%s', must be one of http, icmp, tcp, sip, pdp or snmp", monitorType)
+	}
+	return newMonitor(), nil
+}
+
+// dtcObjectRef returns the reference of the DTC monitor, record or linked object.
+func dtcObjectRef(obj IBObject) string {
+	return reflect.ValueOf(obj).Elem().FieldByName("Ref").String()
+}
+
+func setDtcObjectRef(obj IBObject, ref string) {
+	reflect.ValueOf(obj).Elem().FieldByName("Ref").SetString(ref)
+}
+
+func validateDtcMonitorResult(result string, resultCode *uint32) error {
+	switch result {
+	case "", "ANY":
+	case "CODE_IS", "CODE_IS_NOT":
+		if resultCode == nil {
+			return fmt.Errorf("result code is required when the result is %s", result)
+		}
+	default:
+		return fmt.Errorf("invalid result '%s', must be ANY, CODE_IS or CODE_IS_NOT", result)
+	}
+	return nil
+}
+
+func validateDtcMonitorSnmpOids(oids []*DtcMonitorSnmpOid) error {
+	for _, oid := range oids {
+		if oid == nil || oid.Oid == "" {
+			return fmt.Errorf("OID is required for each OID of an SNMP monitor")
+		}
+		switch oid.Type {
+		case "", "STRING", "INTEGER":
+		default:
+			return fmt.Errorf("invalid type '%s' of the OID %s, must be STRING or INTEGER", oid.Type, oid.Oid)
+		}
+		switch oid.Condition {
+		case "", "ANY":
+		case "EXACT", "LEQ", "GEQ":
+			if oid.First == "" {
+				return fmt.Errorf("first term is required by the %s condition of the OID %s", oid.Condition, oid.Oid)
+			}
+		case "RANGE":
+			if oid.First == "" || oid.Last == "" {
+				return fmt.Errorf("first and last terms are required by the RANGE condition of the OID %s", oid.Oid)
+			}
+		default:
+			return fmt.Errorf("invalid condition '%s' of the OID %s, must be ANY, EXACT, LEQ, GEQ or RANGE", oid.Condition, oid.Oid)
+		}
+	}
+	return nil
+}
+
+func validateDtcMonitor(monitor IBObject, create bool) error {
+	var name *string
+	switch m := monitor.(type) {
+	case *DtcMonitorHttp:
+		name = m.Name
+		switch m.ContentCheck {
+		case "", "NONE", "EXTRACT":
+		case "MATCH":
+			if m.ContentCheckRegex == nil || *m.ContentCheckRegex == "" {
+				return fmt.Errorf("content check regular expression is required by the MATCH content check")
+			}
+		default:
+			return fmt.Errorf("invalid content check '%s', must be NONE, EXTRACT or MATCH", m.ContentCheck)
+		}
+		if err := validateDtcMonitorResult(m.Result, m.ResultCode); err != nil {
+			return err
+		}
+	case *DtcMonitorIcmp:
+		name = m.Name
+	case *DtcMonitorTcp:
+		name = m.Name
+		if create && m.Port == nil {
+			return fmt.Errorf("port is required to create a TCP monitor")
+		}
+	case *DtcMonitorSip:
+		name = m.Name
+		switch m.Transport {
+		case "", "UDP", "TCP", "TLS", "SIPS":
+		default:
+			return fmt.Errorf("invalid transport '%s', must be UDP, TCP, TLS or SIPS", m.Transport)
+		}
+		if err := validateDtcMonitorResult(m.Result, m.ResultCode); err != nil {
+			return err
+		}
+	case *DtcMonitorPdp:
+		name = m.Name
+	case *DtcMonitorSnmp:
+		name = m.Name
+		switch m.Version {
+		case "", "V1", "V2C":
+		case "V3":
+			if m.User == nil || *m.User == "" {
+				return fmt.Errorf("user is required by SNMP V3 monitors")
+			}
+		default:
+			return fmt.Errorf("invalid SNMP version '%s', must be V1, V2C or V3", m.Version)
+		}
+		if err := validateDtcMonitorSnmpOids(m.Oids); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported Dtc Monitor object type %T", monitor)
+	}
+	if name == nil || *name == "" {
+		return fmt.Errorf("name is required for a Dtc Monitor object")
+	}
+	return nil
+}
+
+// CreateDtcMonitor creates the given monitor, which is one of
+// *DtcMonitorHttp, *DtcMonitorIcmp, *DtcMonitorTcp, *DtcMonitorSip,
+// *DtcMonitorPdp or *DtcMonitorSnmp, and returns it with its reference.
+func (objMgr *ObjectManager) CreateDtcMonitor(monitor IBObject) (IBObject, error) {
+	if err := validateDtcMonitor(monitor, true); err != nil {
+		return nil, err
+	}
+	ref, err := objMgr.connector.CreateObject(monitor)
+	if err != nil {
+		return nil, err
+	}
+	setDtcObjectRef(monitor, ref)
+	return monitor, nil
+}
+
+// UpdateDtcMonitor replaces the settings of the monitor with the given reference.
+func (objMgr *ObjectManager) UpdateDtcMonitor(ref string, monitor IBObject) (IBObject, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty reference to an object is not allowed")
+	}
+	if err := validateDtcMonitor(monitor, false); err != nil {
+		return nil, err
+	}
+	newRef, err := objMgr.connector.UpdateObject(monitor, ref)
+	if err != nil {
+		return nil, err
+	}
+	setDtcObjectRef(monitor, newRef)
+	return monitor, nil
+}
+
+// getDtcMonitors returns the monitors of the given type matching the query parameters.
+func (objMgr *ObjectManager) getDtcMonitors(monitorType string, queryParams *QueryParams) ([]IBObject, error) {
+	monitor, err := newDtcMonitor(monitorType)
+	if err != nil {
+		return nil, err
+	}
+	res := reflect.New(reflect.SliceOf(reflect.TypeOf(monitor).Elem()))
+	err = objMgr.connector.GetObject(monitor, "", queryParams, res.Interface())
+	if err != nil {
+		return nil, err
+	}
+	monitors := make([]IBObject, 0, res.Elem().Len())
+	for i := 0; i < res.Elem().Len(); i++ {
+		monitors = append(monitors, res.Elem().Index(i).Addr().Interface().(IBObject))
+	}
+	return monitors, nil
+}
+
+// GetAllDtcMonitors returns the monitors of the given type matching the
+// query parameters, each one decoded into the struct of its type.
+func (objMgr *ObjectManager) GetAllDtcMonitors(monitorType string, queryParams *QueryParams) ([]IBObject, error) {
+	monitors, err := objMgr.getDtcMonitors(monitorType, queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Dtc Monitor objects, err: %s", err)
+	}
+	return monitors, nil
+}
+
+// GetDtcMonitor returns the monitor of the given type with the given name.
+func (objMgr *ObjectManager) GetDtcMonitor(monitorType string, name string) (IBObject, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name of the monitor is required to retrieve a Dtc Monitor")
+	}
+	monitors, err := objMgr.getDtcMonitors(monitorType, NewQueryParams(false, map[string]string{"name": name}))
+	if err != nil {
+		return nil, err
+	}
+	if len(monitors) == 0 {
+		return nil, NewNotFoundError(fmt.Sprintf("Dtc Monitor with name %s not found", name))
+	}
+	return monitors[0], nil
+}
+
+// GetDtcMonitorByRef returns the monitor with the given reference, decoded
+// into the struct of its type.
+func (objMgr *ObjectManager) GetDtcMonitorByRef(ref string) (IBObject, error) {
+	objType := strings.SplitN(ref, "/", 2)[0]
+	if !strings.HasPrefix(objType, "dtc:monitor:") {
+		return nil, fmt.Errorf("'%s' is not a reference to a Dtc Monitor", ref)
+	}
+	monitor, err := newDtcMonitor(strings.TrimPrefix(objType, "dtc:monitor:"))
+	if err != nil {
+		return nil, err
+	}
+	err = objMgr.connector.GetObject(monitor, ref, NewQueryParams(false, nil), monitor)
+	if err != nil {
+		return nil, err
+	}
+	return monitor, nil
+}
+
+func (objMgr *ObjectManager) DeleteDtcMonitor(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
@@ -0,0 +1,119 @@
+package ibclient
+
+import (
+	"fmt"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: DTC monitor", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	snmpRef := "dtc:monitor:snmp/ZG5zLmlkbnNfbW9uaXRvcl9zbm1wJHNubXA:snmp"
+	tcpRef := "dtc:monitor:tcp/ZG5zLmlkbnNfbW9uaXRvcl90Y3AkdGNw:tcp-8443"
+
+	Describe("Create monitors", func() {
+		It("should create an SNMP monitor with its OIDs", func() {
+			monitor := NewEmptyDtcMonitorSnmp()
+			monitor.Name = utils.StringPtr("snmp")
+			monitor.Version = "V2C"
+			monitor.Community = utils.StringPtr("public")
+			monitor.Oids = []*DtcMonitorSnmpOid{{Oid: ".1.3.6.1.2.1.1.3.0", Type: "INTEGER", Condition: "GEQ", First: "100"}}
+			conn := &fakeConnector{
+				createObjectObj: monitor,
+				fakeRefReturn:   snmpRef,
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			res, err := objMgr.CreateDtcMonitor(monitor)
+			Expect(err).To(BeNil())
+			Expect(res.(*DtcMonitorSnmp).Ref).To(Equal(snmpRef))
+		})
+
+		It("should validate the settings of the monitor", func() {
+			objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+			_, err := objMgr.CreateDtcMonitor(&DtcMonitorTcp{Name: utils.StringPtr("tcp")})
+			Expect(err).To(Equal(fmt.Errorf("port is required to create a TCP monitor")))
+			_, err = objMgr.CreateDtcMonitor(&DtcMonitorSnmp{Name: utils.StringPtr("snmp"), Version: "V3"})
+			Expect(err).To(Equal(fmt.Errorf("user is required by SNMP V3 monitors")))
+			_, err = objMgr.CreateDtcMonitor(&DtcMonitorHttp{Name: utils.StringPtr("http"), Result: "CODE_IS"})
+			Expect(err).To(Equal(fmt.Errorf("result code is required when the result is CODE_IS")))
+			_, err = objMgr.CreateDtcMonitor(&DtcMonitor{Name: utils.StringPtr("any")})
+			Expect(err).To(Equal(fmt.Errorf("unsupported Dtc Monitor object type *ibclient.DtcMonitor")))
+		})
+	})
+
+	Describe("Update monitor", func() {
+		monitor := NewEmptyDtcMonitorTcp()
+		monitor.Name = utils.StringPtr("tcp-8443")
+		monitor.Interval = utils.Uint32Ptr(10)
+		expected := NewEmptyDtcMonitorTcp()
+		expected.Name = utils.StringPtr("tcp-8443")
+		expected.Interval = utils.Uint32Ptr(10)
+		conn := &fakeConnector{
+			updateObjectObj: expected,
+			updateObjectRef: tcpRef,
+			fakeRefReturn:   tcpRef,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should update the monitor without requiring its port", func() {
+			res, err := objMgr.UpdateDtcMonitor(tcpRef, monitor)
+			Expect(err).To(BeNil())
+			Expect(res.(*DtcMonitorTcp).Ref).To(Equal(tcpRef))
+		})
+	})
+
+	Describe("Get monitors", func() {
+		It("should decode the monitor into the struct of its type", func() {
+			conn := &fakeConnector{
+				getObjectObj:         NewEmptyDtcMonitorSnmp(),
+				getObjectQueryParams: NewQueryParams(false, map[string]string{"name": "snmp"}),
+				getObjectRef:         "",
+				resultObject: []DtcMonitorSnmp{{
+					Ref: snmpRef, Name: utils.StringPtr("snmp"), Version: "V2C",
+					Oids: []*DtcMonitorSnmpOid{{Oid: ".1.3.6.1.2.1.1.3.0"}}}},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			res, err := objMgr.GetDtcMonitor("snmp", "snmp")
+			Expect(err).To(BeNil())
+			snmp, ok := res.(*DtcMonitorSnmp)
+			Expect(ok).To(BeTrue())
+			Expect(snmp.Oids).To(HaveLen(1))
+		})
+
+		It("should get the monitor by reference", func() {
+			conn := &fakeConnector{
+				getObjectObj:         NewEmptyDtcMonitorTcp(),
+				getObjectQueryParams: NewQueryParams(false, nil),
+				getObjectRef:         tcpRef,
+				resultObject:         &DtcMonitorTcp{Ref: tcpRef, Name: utils.StringPtr("tcp-8443"), Port: utils.Uint32Ptr(8443)},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			res, err := objMgr.GetDtcMonitorByRef(tcpRef)
+			Expect(err).To(BeNil())
+			Expect(res.(*DtcMonitorTcp).Port).To(Equal(utils.Uint32Ptr(8443)))
+		})
+
+		It("should reject an unknown monitor type", func() {
+			objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+			_, err := objMgr.GetDtcMonitor("dns", "dns")
+			Expect(err).To(Equal(fmt.Errorf("invalid Dtc Monitor type 'dns', must be one of http, icmp, tcp, sip, pdp or snmp")))
+		})
+	})
+
+	Describe("Delete monitor", func() {
+		conn := &fakeConnector{
+			deleteObjectRef: snmpRef,
+			fakeRefReturn:   snmpRef,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should delete the monitor", func() {
+			ref, err := objMgr.DeleteDtcMonitor(snmpRef)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(snmpRef))
+		})
+	})
+})
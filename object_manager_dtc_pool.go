@@ -11,41 +11,61 @@ type Monitor struct {
 	Type string
 }
 
-// Updating Servers in DtcServerLink with reference
-func updateServerReferences(servers []*DtcServerLink, objMgr *ObjectManager) error {
-	for _, link := range servers {
-		sf := map[string]string{"name": link.Server}
-		queryParams := NewQueryParams(false, sf)
-		var serverResult []DtcServer
-		err := objMgr.connector.GetObject(&DtcServer{}, "dtc:server", queryParams, &serverResult)
-		if err != nil {
-			return fmt.Errorf("error getting Dtc Server %s, err: %s", link.Server, err)
+func dtcServerLink(server *DtcServerLink) DtcLink {
+	return DtcLink{ObjectType: "dtc:server", Name: server.Server}
+}
+
+func dtcTopologyLink(topology string) DtcLink {
+	return DtcLink{ObjectType: "dtc:topology", Name: topology}
+}
+
+// resolveDtcPoolLinks resolves at once the names of the servers, monitors
+// and topologies of a pool, replacing the names of the servers with their
+// references. An *UnresolvedDtcLinksError lists all the objects not found.
+func resolveDtcPoolLinks(objMgr *ObjectManager,
+	servers []*DtcServerLink,
+	monitors []Monitor,
+	lbDynamicRatioPreferred map[string]interface{},
+	lbDynamicRatioAlternate map[string]interface{},
+	lbPreferredTopology *string,
+	lbAlternateTopology *string,
+	userMonitors []map[string]interface{}) (*dtcLinkResolver, error) {
+
+	links := newDtcLinkResolver(objMgr)
+	for _, server := range servers {
+		if server == nil || server.Server == "" {
+			return nil, fmt.Errorf("name or reference is required for each server of a Dtc Pool object")
 		}
-		if len(serverResult) > 0 {
-			link.Server = serverResult[0].Ref
+		links.add(dtcServerLink(server))
+	}
+	for _, monitor := range monitors {
+		links.addMonitor(monitor)
+	}
+	for _, lbDynamicRatio := range []map[string]interface{}{lbDynamicRatioPreferred, lbDynamicRatioAlternate} {
+		if monitor, ok := lbDynamicRatio["monitor"].(Monitor); ok {
+			links.addMonitor(monitor)
 		}
 	}
-	return nil
-}
-
-// get the monitor reference
-func getMonitorReference(monitorName string, monitorType string, objMgr *ObjectManager) (string, error) {
-	if monitorType == "" {
-		return "", nil
+	for _, userMonitor := range userMonitors {
+		if monitor, ok := userMonitor["monitor"].(Monitor); ok {
+			links.addMonitor(monitor)
+		}
 	}
-	fields := map[string]string{"name": monitorName}
-	queryParams := NewQueryParams(false, fields)
-	var monitorResult []DtcMonitorHttp
-
-	monitorTypeKey := fmt.Sprintf("dtc:monitor:%s", monitorType)
-	err := objMgr.connector.GetObject(&DtcMonitorHttp{}, monitorTypeKey, queryParams, &monitorResult)
-	if err != nil {
-		return "", fmt.Errorf("error getting Dtc Monitor object %s, err: %s", monitorName, err)
+	for _, topology := range []*string{lbPreferredTopology, lbAlternateTopology} {
+		if topology != nil {
+			if *topology == "" {
+				return nil, fmt.Errorf("topology field is required to retreive a unique Dtc Topology record")
+			}
+			links.add(dtcTopologyLink(*topology))
+		}
 	}
-	if len(monitorResult) > 0 {
-		return monitorResult[0].Ref, nil
+	if err := links.resolve(); err != nil {
+		return nil, err
 	}
-	return "", fmt.Errorf("Dtc Monitor with name %s not found", monitorName)
+	for _, server := range servers {
+		server.Server = links.ref(dtcServerLink(server))
+	}
+	return links, nil
 }
 
 func (cm ConsolidatedMonitorsWrapper) MarshalJSON() ([]byte, error) {
@@ -218,8 +238,9 @@ func (objMgr *ObjectManager) CreateDtcPool(
 	if lbPreferredMethod == "TOPOLOGY" && lbPreferredTopology == nil {
 		return nil, fmt.Errorf("preferred topology cannot be nil when preferred load balancing method is set to TOPOLOGY")
 	}
-	//update servers with server references
-	err := updateServerReferences(servers, objMgr)
+	//update servers with server references and resolve the monitors and topologies
+	links, err := resolveDtcPoolLinks(objMgr, servers, monitors, lbDynamicRatioPreferred, lbDynamicRatioAlternate,
+		lbPreferredTopology, lbAlternateTopology, userMonitors)
 	if err != nil {
 		return nil, err
 	}
@@ -232,10 +253,7 @@ func (objMgr *ObjectManager) CreateDtcPool(
 		monitorWeighing, _ := lbDynamicRatioPreferred["monitor_weighing"].(string)
 		invertMonitorMetric, _ := lbDynamicRatioPreferred["invert_monitor_metric"].(bool)
 
-		monitorRef, err := getMonitorReference(monitor.Name, monitor.Type, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		monitorRef := links.monitorRef(monitor)
 		lbDynamicRatioPreferredMethod = &SettingDynamicratio{
 			Method:              method,
 			Monitor:             monitorRef,
@@ -250,27 +268,18 @@ func (objMgr *ObjectManager) CreateDtcPool(
 	// Convert monitor names to monitor references
 	var monitorResults []*DtcMonitorHttp
 	for _, monitor := range monitors {
-		monitorRef, err := getMonitorReference(monitor.Name, monitor.Type, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		monitorRef := links.monitorRef(monitor)
 		monitorResults = append(monitorResults, &DtcMonitorHttp{Ref: monitorRef})
 	}
 	//Update the topology name with the topology reference
 	if lbPreferredTopology != nil {
-		topology, err := getTopology(*lbPreferredTopology, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		topology := links.ref(dtcTopologyLink(*lbPreferredTopology))
 		lbPreferredTopology = &topology
 	}
 
 	//Update the topology name with the topology reference
 	if lbAlternateTopology != nil {
-		topologyAlternate, err := getTopology(*lbAlternateTopology, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		topologyAlternate := links.ref(dtcTopologyLink(*lbAlternateTopology))
 		lbAlternateTopology = &topologyAlternate
 	}
 	//update the monitor in LbDynamicRatioPreferred with reference
@@ -282,10 +291,7 @@ func (objMgr *ObjectManager) CreateDtcPool(
 		monitorWeighingAlternate, _ := lbDynamicRatioAlternate["monitor_weighing"].(string)
 		interferometricAlternate, _ := lbDynamicRatioAlternate["invert_monitor_metric"].(bool)
 
-		monitorRefAlternate, err := getMonitorReference(monitorAlternate.Name, monitorAlternate.Type, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		monitorRefAlternate := links.monitorRef(monitorAlternate)
 		lbDynamicRatioAlternateMethod = &SettingDynamicratio{
 			Method:              methodAlternate,
 			Monitor:             monitorRefAlternate,
@@ -318,10 +324,7 @@ func (objMgr *ObjectManager) CreateDtcPool(
 				if !okMember {
 					return nil, fmt.Errorf("required field missing: members")
 				}
-				monitorRef, err := getMonitorReference(monitor.Name, monitor.Type, objMgr)
-				if err != nil {
-					return nil, err
-				}
+				monitorRef := links.monitorRef(monitor)
 
 				consolidatedMonitor := &DtcPoolConsolidatedMonitorHealth{
 					Members:                 members,
@@ -382,8 +385,9 @@ func (objMgr *ObjectManager) UpdateDtcPool(
 	if autoConsolidatedMonitors && len(userMonitors) > 0 {
 		return nil, fmt.Errorf("either AutoConsolidatedMonitors or ConsolidatedMonitors should be set.")
 	}
-	//update servers with server references
-	err := updateServerReferences(servers, objMgr)
+	//update servers with server references and resolve the monitors and topologies
+	links, err := resolveDtcPoolLinks(objMgr, servers, monitors, lbDynamicRatioPreferred, lbDynamicRatioAlternate,
+		lbPreferredTopology, lbAlternateTopology, userMonitors)
 	if err != nil {
 		return nil, err
 	}
@@ -396,10 +400,7 @@ func (objMgr *ObjectManager) UpdateDtcPool(
 		monitorWeighing, _ := lbDynamicRatioPreferred["monitor_weighing"].(string)
 		invertMonitorMetric, _ := lbDynamicRatioPreferred["invert_monitor_metric"].(bool)
 
-		monitorRef, err := getMonitorReference(monitor.Name, monitor.Type, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		monitorRef := links.monitorRef(monitor)
 		lbDynamicRatioPreferredMethod = &SettingDynamicratio{
 			Method:              method,
 			Monitor:             monitorRef,
@@ -413,26 +414,17 @@ func (objMgr *ObjectManager) UpdateDtcPool(
 	// Convert monitor names to monitor references
 	var monitorResults []*DtcMonitorHttp
 	for _, monitor := range monitors {
-		monitorRef, err := getMonitorReference(monitor.Name, monitor.Type, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		monitorRef := links.monitorRef(monitor)
 		monitorResults = append(monitorResults, &DtcMonitorHttp{Ref: monitorRef})
 	}
 	//Update the topology name with the topology reference
 	if lbPreferredTopology != nil {
-		topology, err := getTopology(*lbPreferredTopology, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		topology := links.ref(dtcTopologyLink(*lbPreferredTopology))
 		lbPreferredTopology = &topology
 	}
 	//Update the topology name with the topology reference
 	if lbAlternateTopology != nil {
-		topologyAlternate, err := getTopology(*lbAlternateTopology, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		topologyAlternate := links.ref(dtcTopologyLink(*lbAlternateTopology))
 		lbAlternateTopology = &topologyAlternate
 	}
 	//Convert LbDynamicRatioAlternate to use monitor reference
@@ -444,10 +436,7 @@ func (objMgr *ObjectManager) UpdateDtcPool(
 		monitorWeighingAlternate, _ := lbDynamicRatioAlternate["monitor_weighing"].(string)
 		invertMonitorMetricAlternate, _ := lbDynamicRatioAlternate["invert_monitor_metric"].(bool)
 
-		monitorRefAlternate, err := getMonitorReference(monitorAlternate.Name, monitorAlternate.Type, objMgr)
-		if err != nil {
-			return nil, err
-		}
+		monitorRefAlternate := links.monitorRef(monitorAlternate)
 		lbDynamicRatioAlternateMethod = &SettingDynamicratio{
 			Method:              methodAlternate,
 			Monitor:             monitorRefAlternate,
@@ -480,10 +469,7 @@ func (objMgr *ObjectManager) UpdateDtcPool(
 				if !okMember {
 					return nil, fmt.Errorf("required field missing: members")
 				}
-				monitorRef, err := getMonitorReference(monitor.Name, monitor.Type, objMgr)
-				if err != nil {
-					return nil, err
-				}
+				monitorRef := links.monitorRef(monitor)
 
 				consolidatedMonitor := &DtcPoolConsolidatedMonitorHealth{
 					Members:                 members,
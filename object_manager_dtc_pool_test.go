@@ -84,7 +84,7 @@ var _ = Describe("Object Manager DTC Pool", func() {
 			getObjectObj: map[string]interface{}{
 				"DtcServer":   &DtcServer{},
 				"DtcTopology": &DtcTopology{},
-				"DtcMonitor":  &DtcMonitorHttp{},
+				"DtcMonitor":  &DtcMonitorSnmp{},
 			},
 			getObjectQueryParams: map[string]*QueryParams{
 				"DtcServer":   NewQueryParams(false, map[string]string{"name": "test-server"}),
@@ -96,7 +96,7 @@ var _ = Describe("Object Manager DTC Pool", func() {
 					Ref:  topologyRef,
 					Name: utils.StringPtr("test-topo"),
 				}},
-				"DtcMonitor": []DtcMonitorHttp{{
+				"DtcMonitor": []DtcMonitorSnmp{{
 					Ref: monitorRef,
 				}},
 				"DtcServer": []DtcServer{{
@@ -150,7 +150,7 @@ var _ = Describe("Object Manager DTC Pool", func() {
 			createObjectObj: NewDtcPool(comment, name, lbPreferredMethod, lbDynamicRatioPreferred, createObjServers, createMonitor, nil, "", nil, nil, nil, false, "", []*DtcPoolConsolidatedMonitorHealth{}, 20, true, false, 2),
 			getObjectObj: map[string]interface{}{
 				"DtcServer":  &DtcServer{},
-				"DtcMonitor": &DtcMonitorHttp{},
+				"DtcMonitor": &DtcMonitorSnmp{},
 			},
 			getObjectQueryParams: map[string]*QueryParams{
 				"DtcServer":  NewQueryParams(false, map[string]string{"name": "test-server"}),
@@ -161,7 +161,7 @@ var _ = Describe("Object Manager DTC Pool", func() {
 					Ref:  serverRef,
 					Name: utils.StringPtr("test-server"),
 				}},
-				"DtcMonitor": []DtcMonitorHttp{{
+				"DtcMonitor": []DtcMonitorSnmp{{
 					Ref: monitorRef,
 				}},
 				"DtcPool": objAsResult,
@@ -227,7 +227,7 @@ var _ = Describe("Object Manager DTC Pool", func() {
 			createObjectObj: NewDtcPool(comment, name, lbPreferredMethod, lbDynamicRatioPreferred, createObjServers, createMonitor, nil, "", nil, nil, nil, false, "", consolidatedMonitorStruct, 20, true, true, 2),
 			getObjectObj: map[string]interface{}{
 				"DtcServer":  &DtcServer{},
-				"DtcMonitor": &DtcMonitorHttp{},
+				"DtcMonitor": &DtcMonitorSnmp{},
 			},
 			getObjectQueryParams: map[string]*QueryParams{
 				"DtcServer":  NewQueryParams(false, map[string]string{"name": "test-server"}),
@@ -238,7 +238,7 @@ var _ = Describe("Object Manager DTC Pool", func() {
 					Ref:  serverRef,
 					Name: utils.StringPtr("test-server"),
 				}},
-				"DtcMonitor": []DtcMonitorHttp{{
+				"DtcMonitor": []DtcMonitorSnmp{{
 					Ref: monitorRef,
 				}},
 				"DtcPool": objAsResult,
@@ -367,7 +367,7 @@ var _ = Describe("Object Manager DTC Pool", func() {
 
 			conn = &fakeConnector{
 				getObjectObj: map[string]interface{}{
-					"DtcMonitor": &DtcMonitorHttp{},
+					"DtcMonitor": &DtcMonitorSnmp{},
 					"DtcPool":    NewEmptyDtcPool(),
 				},
 				getObjectQueryParams: map[string]*QueryParams{
@@ -377,7 +377,7 @@ var _ = Describe("Object Manager DTC Pool", func() {
 				getObjectRef:   updatedRef,
 				getObjectError: nil,
 				resultObject: map[string]interface{}{
-					"DtcMonitor": []DtcMonitorHttp{{
+					"DtcMonitor": []DtcMonitorSnmp{{
 						Ref: monitorRef,
 					}},
 					"DtcPool": expectedObj,
@@ -0,0 +1,206 @@
+package ibclient
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+)
+
+func NewEmptyDtcRecordA() *DtcRecordA {
+	record := &DtcRecordA{}
+	record.SetReturnFields(append(record.ReturnFields(), "auto_created", "comment", "disable", "ttl", "use_ttl"))
+	return record
+}
+
+func NewEmptyDtcRecordAaaa() *DtcRecordAaaa {
+	record := &DtcRecordAaaa{}
+	record.SetReturnFields(append(record.ReturnFields(), "auto_created", "comment", "disable", "ttl", "use_ttl"))
+	return record
+}
+
+func NewEmptyDtcRecordCname() *DtcRecordCname {
+	record := &DtcRecordCname{}
+	record.SetReturnFields(append(record.ReturnFields(), "auto_created", "comment", "disable", "dns_canonical", "ttl", "use_ttl"))
+	return record
+}
+
+func NewEmptyDtcRecordNaptr() *DtcRecordNaptr {
+	record := &DtcRecordNaptr{}
+	record.SetReturnFields(append(record.ReturnFields(), "comment", "disable", "flags", "ttl", "use_ttl"))
+	return record
+}
+
+func NewEmptyDtcRecordSrv() *DtcRecordSrv {
+	record := &DtcRecordSrv{}
+	record.SetReturnFields(append(record.ReturnFields(), "comment", "disable", "ttl", "use_ttl"))
+	return record
+}
+
+// dtcRecordTypes maps the DTC record types to their objects.
+var dtcRecordTypes = map[string]func() IBObject{
+	"a":     func() IBObject { return NewEmptyDtcRecordA() },
+	"aaaa":  func() IBObject { return NewEmptyDtcRecordAaaa() },
+	"cname": func() IBObject { return NewEmptyDtcRecordCname() },
+	"naptr": func() IBObject { return NewEmptyDtcRecordNaptr() },
+	"srv":   func() IBObject { return NewEmptyDtcRecordSrv() },
+}
+
+// dtcRecordTypeOrder is the order in which GetDtcRecords returns the records of all types.
+var dtcRecordTypeOrder = []string{"a", "aaaa", "cname", "naptr", "srv"}
+
+func newDtcRecord(recordType string) (IBObject, error) {
+	newRecord, ok := dtcRecordTypes[recordType]
+	if !ok {
+		return nil, fmt.Errorf("invalid Dtc Record type '%s', must be one of a, aaaa, cname, naptr or srv", recordType)
+	}
+	return newRecord(), nil
+}
+
+// prepareDtcRecord validates the record and sets its server, clearing the
+// fields which cannot be written. The server is only set on creation, as
+// it cannot be changed afterwards.
+func prepareDtcRecord(record IBObject, server string) error {
+	switch r := record.(type) {
+	case *DtcRecordA:
+		if r.Ipv4Addr == nil || net.ParseIP(*r.Ipv4Addr) == nil || net.ParseIP(*r.Ipv4Addr).To4() == nil {
+			return fmt.Errorf("a valid IPv4 address is required for a Dtc A record")
+		}
+		r.DtcServer, r.AutoCreated = server, ""
+	case *DtcRecordAaaa:
+		if r.Ipv6Addr == nil || net.ParseIP(*r.Ipv6Addr) == nil || net.ParseIP(*r.Ipv6Addr).To4() != nil {
+			return fmt.Errorf("a valid IPv6 address is required for a Dtc AAAA record")
+		}
+		r.DtcServer, r.AutoCreated = server, ""
+	case *DtcRecordCname:
+		if r.Canonical == nil || *r.Canonical == "" {
+			return fmt.Errorf("canonical name is required for a Dtc CNAME record")
+		}
+		r.DtcServer, r.AutoCreated, r.DnsCanonical = server, "", ""
+	case *DtcRecordNaptr:
+		if r.Order == nil || r.Preference == nil || r.Replacement == nil || *r.Replacement == "" {
+			return fmt.Errorf("order, preference and replacement are required for a Dtc NAPTR record")
+		}
+		r.DtcServer = server
+	case *DtcRecordSrv:
+		if r.Name == nil || *r.Name == "" || r.Target == nil || *r.Target == "" ||
+			r.Port == nil || r.Priority == nil || r.Weight == nil {
+			return fmt.Errorf("name, target, port, priority and weight are required for a Dtc SRV record")
+		}
+		r.DtcServer = server
+	default:
+		return fmt.Errorf("unsupported Dtc Record object type %T", record)
+	}
+	return nil
+}
+
+// CreateDtcRecord creates the given record, which is one of *DtcRecordA,
+// *DtcRecordAaaa, *DtcRecordCname, *DtcRecordNaptr or *DtcRecordSrv, for
+// the DTC server with the given name or reference, and returns it with its
+// reference.
+func (objMgr *ObjectManager) CreateDtcRecord(server string, record IBObject) (IBObject, error) {
+	if server == "" {
+		return nil, fmt.Errorf("name or reference of the server is required to create a Dtc Record")
+	}
+	refs, err := objMgr.ResolveDtcLinks([]DtcLink{{ObjectType: "dtc:server", Name: server}})
+	if err != nil {
+		return nil, err
+	}
+	serverRef := refs[0]
+	if err := prepareDtcRecord(record, serverRef); err != nil {
+		return nil, err
+	}
+	ref, err := objMgr.connector.CreateObject(record)
+	if err != nil {
+		return nil, err
+	}
+	setDtcObjectRef(record, ref)
+	return record, nil
+}
+
+// UpdateDtcRecord replaces the settings of the record with the given reference.
+func (objMgr *ObjectManager) UpdateDtcRecord(ref string, record IBObject) (IBObject, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty reference to an object is not allowed")
+	}
+	if err := prepareDtcRecord(record, ""); err != nil {
+		return nil, err
+	}
+	setDtcObjectRef(record, "")
+	newRef, err := objMgr.connector.UpdateObject(record, ref)
+	if err != nil {
+		return nil, err
+	}
+	setDtcObjectRef(record, newRef)
+	return record, nil
+}
+
+// getDtcRecords returns the records of the given type of the server.
+func (objMgr *ObjectManager) getDtcRecords(recordType string, serverRef string) ([]IBObject, error) {
+	record, err := newDtcRecord(recordType)
+	if err != nil {
+		return nil, err
+	}
+	res := reflect.New(reflect.SliceOf(reflect.TypeOf(record).Elem()))
+	sf := map[string]string{"dtc_server": serverRef}
+	if err = objMgr.getObjects(record, NewQueryParams(false, sf), res.Interface()); err != nil {
+		return nil, err
+	}
+	records := make([]IBObject, 0, res.Elem().Len())
+	for i := 0; i < res.Elem().Len(); i++ {
+		records = append(records, res.Elem().Index(i).Addr().Interface().(IBObject))
+	}
+	return records, nil
+}
+
+// GetDtcRecords returns the records of the given type of the DTC server
+// with the given name or reference, each one decoded into the struct of
+// its type. The records of all types are returned if recordType is empty.
+func (objMgr *ObjectManager) GetDtcRecords(server string, recordType string) ([]IBObject, error) {
+	if server == "" {
+		return nil, fmt.Errorf("name or reference of the server is required to retrieve Dtc Records")
+	}
+	types := dtcRecordTypeOrder
+	if recordType != "" {
+		if _, err := newDtcRecord(recordType); err != nil {
+			return nil, err
+		}
+		types = []string{recordType}
+	}
+	refs, err := objMgr.ResolveDtcLinks([]DtcLink{{ObjectType: "dtc:server", Name: server}})
+	if err != nil {
+		return nil, err
+	}
+	serverRef := refs[0]
+	var res []IBObject
+	for _, t := range types {
+		records, err := objMgr.getDtcRecords(t, serverRef)
+		if err != nil {
+			return nil, fmt.Errorf("error getting Dtc Record objects, err: %s", err)
+		}
+		res = append(res, records...)
+	}
+	return res, nil
+}
+
+// GetDtcRecordByRef returns the record with the given reference, decoded
+// into the struct of its type.
+func (objMgr *ObjectManager) GetDtcRecordByRef(ref string) (IBObject, error) {
+	objType := strings.SplitN(ref, "/", 2)[0]
+	if !strings.HasPrefix(objType, "dtc:record:") {
+		return nil, fmt.Errorf("'%s' is not a reference to a Dtc Record", ref)
+	}
+	record, err := newDtcRecord(strings.TrimPrefix(objType, "dtc:record:"))
+	if err != nil {
+		return nil, err
+	}
+	err = objMgr.connector.GetObject(record, ref, NewQueryParams(false, nil), record)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (objMgr *ObjectManager) DeleteDtcRecord(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
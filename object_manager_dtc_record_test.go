@@ -0,0 +1,107 @@
+package ibclient
+
+import (
+	"fmt"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: DTC record", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	serverRef := "dtc:server/ZG5zLmlkbnNfc2VydmVyJGV1LTE:eu-1"
+	aRef := "dtc:record:a/ZG5zLmlkbnNfYV9yZWNvcmQkMTAuMC4wLjEw:10.0.0.10/eu-1"
+	srvRef := "dtc:record:srv/ZG5zLmlkbnNfc3J2X3JlY29yZCRzaXA:_sip._tcp/eu-1"
+
+	Describe("Create records", func() {
+		It("should create an A record for the server with the given name", func() {
+			expected := NewEmptyDtcRecordA()
+			expected.Ipv4Addr = utils.StringPtr("10.0.0.10")
+			expected.DtcServer = serverRef
+			conn := &fakeConnector{
+				getObjectObj:    map[string]interface{}{"DtcServer": &DtcServer{}},
+				resultObject:    map[string]interface{}{"DtcServer": []DtcServer{{Ref: serverRef, Name: utils.StringPtr("eu-1")}}},
+				createObjectObj: expected,
+				fakeRefReturn:   aRef,
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			record := NewEmptyDtcRecordA()
+			record.Ipv4Addr = utils.StringPtr("10.0.0.10")
+			res, err := objMgr.CreateDtcRecord("eu-1", record)
+			Expect(err).To(BeNil())
+			Expect(res.(*DtcRecordA).Ref).To(Equal(aRef))
+		})
+
+		It("should validate the settings of the record", func() {
+			objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+			_, err := objMgr.CreateDtcRecord(serverRef, &DtcRecordA{Ipv4Addr: utils.StringPtr("2001:db8::1")})
+			Expect(err).To(Equal(fmt.Errorf("a valid IPv4 address is required for a Dtc A record")))
+			_, err = objMgr.CreateDtcRecord(serverRef, &DtcRecordSrv{Name: utils.StringPtr("_sip._tcp"), Target: utils.StringPtr("sip.example.com")})
+			Expect(err).To(Equal(fmt.Errorf("name, target, port, priority and weight are required for a Dtc SRV record")))
+			_, err = objMgr.CreateDtcRecord("", &DtcRecordA{})
+			Expect(err).To(Equal(fmt.Errorf("name or reference of the server is required to create a Dtc Record")))
+		})
+	})
+
+	Describe("Update record", func() {
+		It("should not send the server nor the read-only fields", func() {
+			record := NewEmptyDtcRecordCname()
+			record.Ref = "dtc:record:cname/ZG5zLmlkbnNfY25hbWU:www/eu-1"
+			record.Canonical = utils.StringPtr("www.example.com")
+			record.DnsCanonical = "www.example.com"
+			record.AutoCreated = "DEFAULT"
+			record.DtcServer = serverRef
+			expected := NewEmptyDtcRecordCname()
+			expected.Canonical = utils.StringPtr("www.example.com")
+			conn := &fakeConnector{
+				updateObjectObj: expected,
+				updateObjectRef: record.Ref,
+				fakeRefReturn:   record.Ref,
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			res, err := objMgr.UpdateDtcRecord(record.Ref, record)
+			Expect(err).To(BeNil())
+			Expect(res.(*DtcRecordCname).Ref).To(Equal(conn.fakeRefReturn))
+		})
+	})
+
+	Describe("Get records", func() {
+		It("should return the records of all types of the server", func() {
+			conn := &fakeConnector{
+				getObjectObj: map[string]interface{}{"DtcServer": &DtcServer{}},
+				resultObject: map[string]interface{}{
+					"DtcRecordA": []DtcRecordA{{Ref: aRef, DtcServer: serverRef, Ipv4Addr: utils.StringPtr("10.0.0.10")}},
+					"DtcRecordSrv": []DtcRecordSrv{{Ref: srvRef, DtcServer: serverRef, Name: utils.StringPtr("_sip._tcp"),
+						Target: utils.StringPtr("sip.example.com"), Port: utils.Uint32Ptr(5060)}},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			res, err := objMgr.GetDtcRecords(serverRef, "")
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0].(*DtcRecordA).Ref).To(Equal(aRef))
+			Expect(res[1].(*DtcRecordSrv).Ref).To(Equal(srvRef))
+		})
+
+		It("should decode the record into the struct of its type", func() {
+			conn := &fakeConnector{
+				getObjectObj:         NewEmptyDtcRecordSrv(),
+				getObjectQueryParams: NewQueryParams(false, nil),
+				getObjectRef:         srvRef,
+				resultObject:         &DtcRecordSrv{Ref: srvRef, DtcServer: serverRef, Port: utils.Uint32Ptr(5060)},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			res, err := objMgr.GetDtcRecordByRef(srvRef)
+			Expect(err).To(BeNil())
+			Expect(*res.(*DtcRecordSrv).Port).To(Equal(uint32(5060)))
+		})
+
+		It("should reject an unknown record type", func() {
+			objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+			_, err := objMgr.GetDtcRecords(serverRef, "mx")
+			Expect(err).To(Equal(fmt.Errorf("invalid Dtc Record type 'mx', must be one of a, aaaa, cname, naptr or srv")))
+		})
+	})
+})
@@ -52,6 +52,31 @@ func NewDtcServer(comment string,
 	return DtcServer
 }
 
+// resolveDtcServerMonitors returns the monitors of a server, with the
+// names of the monitors resolved at once.
+func resolveDtcServerMonitors(objMgr *ObjectManager, monitors []map[string]interface{}) ([]*DtcServerMonitor, error) {
+	links := newDtcLinkResolver(objMgr)
+	for _, userMonitor := range monitors {
+		monitor, okMonitor := userMonitor["monitor"].(Monitor)
+		if !okMonitor {
+			return nil, fmt.Errorf("required field missing: monitor")
+		}
+		links.addMonitor(monitor)
+	}
+	if err := links.resolve(); err != nil {
+		return nil, err
+	}
+	var serverMonitors []*DtcServerMonitor
+	for _, userMonitor := range monitors {
+		monitorHost, _ := userMonitor["host"].(string)
+		serverMonitors = append(serverMonitors, &DtcServerMonitor{
+			Monitor: links.monitorRef(userMonitor["monitor"].(Monitor)),
+			Host:    monitorHost,
+		})
+	}
+	return serverMonitors, nil
+}
+
 func (objMgr *ObjectManager) CreateDtcServer(
 	comment string,
 	name string,
@@ -71,24 +96,9 @@ func (objMgr *ObjectManager) CreateDtcServer(
 		return nil, fmt.Errorf("'sni_hostname' must be provided when 'use_sni_hostname' is enabled, " +
 			"and 'use_sni_hostname' must be enabled if 'sni_hostname' is provided")
 	}
-	var serverMonitors []*DtcServerMonitor
-	for _, userMonitor := range monitors {
-		monitor, okMonitor := userMonitor["monitor"].(Monitor)
-		monitorHost, _ := userMonitor["host"].(string)
-		if !okMonitor {
-			return nil, fmt.Errorf("required field missing: monitor")
-		}
-		monitorRef, err := getMonitorReference(monitor.Name, monitor.Type, objMgr)
-		if err != nil {
-			return nil, err
-		}
-
-		serverMonitor := &DtcServerMonitor{
-			Monitor: monitorRef,
-			Host:    monitorHost,
-		}
-
-		serverMonitors = append(serverMonitors, serverMonitor)
+	serverMonitors, err := resolveDtcServerMonitors(objMgr, monitors)
+	if err != nil {
+		return nil, err
 	}
 	dtcServer := NewDtcServer(comment, name, host, autoCreateHostRecord, disable, ea, serverMonitors, sniHostname, useSniHostname)
 	ref, err := objMgr.connector.CreateObject(dtcServer)
@@ -145,28 +155,13 @@ func (objMgr *ObjectManager) UpdateDtcServer(
 		return nil, fmt.Errorf("'sni_hostname' must be provided when 'use_sni_hostname' is enabled, " +
 			"and 'use_sni_hostname' must be enabled if 'sni_hostname' is provided")
 	}
-	var serverMonitors []*DtcServerMonitor
-	for _, userMonitor := range monitors {
-		monitor, okMonitor := userMonitor["monitor"].(Monitor)
-		monitorHost, _ := userMonitor["host"].(string)
-		if !okMonitor {
-			return nil, fmt.Errorf("required field missing: monitor")
-		}
-		monitorRef, err := getMonitorReference(monitor.Name, monitor.Type, objMgr)
-		if err != nil {
-			return nil, err
-		}
-
-		serverMonitor := &DtcServerMonitor{
-			Monitor: monitorRef,
-			Host:    monitorHost,
-		}
-
-		serverMonitors = append(serverMonitors, serverMonitor)
+	serverMonitors, err := resolveDtcServerMonitors(objMgr, monitors)
+	if err != nil {
+		return nil, err
 	}
 	dtcServer := NewDtcServer(comment, name, host, autoCreateHostRecord, disable, ea, serverMonitors, sniHostname, useSniHostname)
 	dtcServer.Ref = ref
-	ref, err := objMgr.connector.UpdateObject(dtcServer, ref)
+	ref, err = objMgr.connector.UpdateObject(dtcServer, ref)
 	if err != nil {
 		return nil, err
 	}
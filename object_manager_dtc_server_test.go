@@ -38,13 +38,13 @@ var _ = Describe("Object Manager DTC Server", func() {
 			createObjectObj: NewDtcServer(comment, name, host, false, false, eas, serverMonitor, sniHost, useSniHost),
 			getObjectRef:    fakeRefReturn,
 			getObjectObj: map[string]interface{}{
-				"DtcMonitor": &DtcMonitorHttp{},
+				"DtcMonitor": &DtcMonitorSnmp{},
 			},
 			getObjectQueryParams: map[string]*QueryParams{
 				"DtcMonitor": NewQueryParams(false, map[string]string{"name": "snmp"}),
 			},
 			resultObject: map[string]interface{}{
-				"DtcMonitor": []DtcMonitorHttp{{
+				"DtcMonitor": []DtcMonitorSnmp{{
 					Ref: monitorRef,
 				}},
 				"DtcServer": objectAsResult,
@@ -0,0 +1,439 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DtcTopologyRuleParams describes a topology rule. Destination is the name
+// or the reference of the pool or server the matching clients are sent to;
+// it is not needed when the rule returns NOERR or NXDOMAIN. A rule without
+// sources matches every client.
+type DtcTopologyRuleParams struct {
+	DestType    string // POOL or SERVER
+	Destination string
+	ReturnType  string // REGULAR (default), NOERR or NXDOMAIN
+	Sources     []*DtcTopologyRuleSource
+}
+
+// DtcTopologyClient describes a client as seen by the topology rules: its IP
+// address and the values the Topology DB gives for it.
+type DtcTopologyClient struct {
+	IP          string
+	Continent   string
+	Country     string
+	Subdivision string
+	City        string
+	Ea          map[string]string // values of the EA0 to EA3 fields
+}
+
+func (t *DtcTopology) UnmarshalJSON(data []byte) error {
+	type Alias DtcTopology
+	aux := &struct {
+		Rules []json.RawMessage `json:"rules,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	// rules are returned as references unless they are created along with the topology
+	t.Rules = nil
+	for _, raw := range aux.Rules {
+		rule := &DtcTopologyRule{}
+		var ref string
+		if err := json.Unmarshal(raw, &ref); err == nil {
+			rule.Ref = ref
+		} else if err := json.Unmarshal(raw, rule); err != nil {
+			return err
+		}
+		t.Rules = append(t.Rules, rule)
+	}
+	return nil
+}
+
+func NewEmptyDtcTopology() *DtcTopology {
+	topology := &DtcTopology{}
+	topology.SetReturnFields(append(topology.ReturnFields(), "extattrs", "rules"))
+	return topology
+}
+
+func NewEmptyDtcTopologyRule() *DtcTopologyRule {
+	rule := &DtcTopologyRule{}
+	rule.SetReturnFields(append(rule.ReturnFields(), "dest_type", "destination_link", "return_type", "sources", "topology", "valid"))
+	return rule
+}
+
+func NewDtcTopology(name string, comment string, rules []*DtcTopologyRule, ea EA) *DtcTopology {
+	topology := NewEmptyDtcTopology()
+	topology.Name = &name
+	topology.Comment = &comment
+	topology.Rules = rules
+	topology.Ea = ea
+	return topology
+}
+
+func validateDtcTopologyRuleSource(source *DtcTopologyRuleSource) error {
+	if source == nil {
+		return fmt.Errorf("topology rule sources must not be empty")
+	}
+	switch source.SourceOp {
+	case "", "IS", "IS_NOT":
+	default:
+		return fmt.Errorf("invalid source operation '%s', must be IS or IS_NOT", source.SourceOp)
+	}
+	switch source.SourceType {
+	case "SUBNET":
+		if _, _, err := net.ParseCIDR(source.SourceValue); err != nil {
+			return fmt.Errorf("'%s' is not a valid subnet", source.SourceValue)
+		}
+	case "EA0", "EA1", "EA2", "EA3", "CITY", "CONTINENT", "COUNTRY", "SUBDIVISION":
+		if source.SourceValue == "" {
+			return fmt.Errorf("a value is required for %s sources", source.SourceType)
+		}
+	default:
+		return fmt.Errorf("invalid source type '%s', must be SUBNET, EA0, EA1, EA2, EA3, CITY, CONTINENT, COUNTRY or SUBDIVISION", source.SourceType)
+	}
+	return nil
+}
+
+// ValidateDtcTopologyRules checks the rules, in the order they are evaluated.
+// A rule without sources is the default one and must be the last.
+func ValidateDtcTopologyRules(rules []*DtcTopologyRule) error {
+	for i, rule := range rules {
+		if rule == nil {
+			return fmt.Errorf("topology rule %d is empty", i)
+		}
+		switch rule.DestType {
+		case "POOL", "SERVER":
+		default:
+			return fmt.Errorf("invalid destination type '%s' of the topology rule %d, must be POOL or SERVER", rule.DestType, i)
+		}
+		switch rule.ReturnType {
+		case "", "REGULAR":
+			if rule.DestinationLink == nil || *rule.DestinationLink == "" {
+				return fmt.Errorf("a destination is required by the topology rule %d", i)
+			}
+		case "NOERR", "NXDOMAIN":
+		default:
+			return fmt.Errorf("invalid return type '%s' of the topology rule %d, must be REGULAR, NOERR or NXDOMAIN", rule.ReturnType, i)
+		}
+		for _, source := range rule.Sources {
+			if err := validateDtcTopologyRuleSource(source); err != nil {
+				return fmt.Errorf("topology rule %d: %s", i, err)
+			}
+		}
+		if len(rule.Sources) == 0 && i != len(rules)-1 {
+			return fmt.Errorf("the default topology rule %d must be the last one", i)
+		}
+	}
+	return nil
+}
+
+func dtcTopologySourceMatches(source *DtcTopologyRuleSource, client DtcTopologyClient, ip net.IP) bool {
+	var matches bool
+	switch source.SourceType {
+	case "SUBNET":
+		_, subnet, err := net.ParseCIDR(source.SourceValue)
+		matches = err == nil && ip != nil && subnet.Contains(ip)
+	case "CONTINENT":
+		matches = strings.EqualFold(source.SourceValue, client.Continent)
+	case "COUNTRY":
+		matches = strings.EqualFold(source.SourceValue, client.Country)
+	case "SUBDIVISION":
+		matches = strings.EqualFold(source.SourceValue, client.Subdivision)
+	case "CITY":
+		matches = strings.EqualFold(source.SourceValue, client.City)
+	default:
+		matches = strings.EqualFold(source.SourceValue, client.Ea[source.SourceType])
+	}
+	if source.SourceOp == "IS_NOT" {
+		return !matches
+	}
+	return matches
+}
+
+// MatchDtcTopologyRules returns the index of the first rule matching the
+// client, all the sources of a rule having to match, or -1 if none does.
+// The rules are evaluated offline, the client attributes the Topology DB
+// would give being taken from client.
+func MatchDtcTopologyRules(rules []*DtcTopologyRule, client DtcTopologyClient) (int, error) {
+	ip := net.ParseIP(client.IP)
+	if ip == nil {
+		return -1, fmt.Errorf("'%s' is not a valid IP address", client.IP)
+	}
+	if err := ValidateDtcTopologyRules(rules); err != nil {
+		return -1, err
+	}
+	for i, rule := range rules {
+		matches := true
+		for _, source := range rule.Sources {
+			if !dtcTopologySourceMatches(source, client, ip) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// dtcTopologyDestinationLink returns the link to the pool or server a rule leads to.
+func dtcTopologyDestinationLink(destType string, destination string) (DtcLink, error) {
+	switch destType {
+	case "POOL":
+		return DtcLink{ObjectType: "dtc:pool", Name: destination}, nil
+	case "SERVER":
+		return DtcLink{ObjectType: "dtc:server", Name: destination}, nil
+	}
+	return DtcLink{}, fmt.Errorf("invalid destination type '%s', must be POOL or SERVER", destType)
+}
+
+// newDtcTopologyRules builds the topology rules, resolving the names of their destinations at once.
+func newDtcTopologyRules(params []DtcTopologyRuleParams, objMgr *ObjectManager) ([]*DtcTopologyRule, error) {
+	links := newDtcLinkResolver(objMgr)
+	for _, p := range params {
+		if p.Destination != "" {
+			link, err := dtcTopologyDestinationLink(p.DestType, p.Destination)
+			if err != nil {
+				return nil, err
+			}
+			links.add(link)
+		}
+	}
+	if err := links.resolve(); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*DtcTopologyRule, 0, len(params))
+	for _, p := range params {
+		rule := &DtcTopologyRule{
+			DestType:   p.DestType,
+			ReturnType: p.ReturnType,
+			Sources:    p.Sources,
+		}
+		if rule.ReturnType == "" {
+			rule.ReturnType = "REGULAR"
+		}
+		if p.Destination != "" {
+			link, _ := dtcTopologyDestinationLink(p.DestType, p.Destination)
+			ref := links.ref(link)
+			rule.DestinationLink = &ref
+		}
+		rules = append(rules, rule)
+	}
+	if err := ValidateDtcTopologyRules(rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (objMgr *ObjectManager) CreateDtcTopology(name string, comment string, rules []DtcTopologyRuleParams, ea EA) (*DtcTopology, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name is required to create a Dtc Topology object")
+	}
+	topologyRules, err := newDtcTopologyRules(rules, objMgr)
+	if err != nil {
+		return nil, err
+	}
+	topology := NewDtcTopology(name, comment, topologyRules, ea)
+	ref, err := objMgr.connector.CreateObject(topology)
+	if err != nil {
+		return nil, err
+	}
+	topology.Ref = ref
+	return topology, nil
+}
+
+func (objMgr *ObjectManager) GetAllDtcTopology(queryParams *QueryParams) ([]DtcTopology, error) {
+	var res []DtcTopology
+	err := objMgr.connector.GetObject(NewEmptyDtcTopology(), "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Dtc Topology object, err: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) GetDtcTopology(name string) (*DtcTopology, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name of the topology is required to retrieve a Dtc Topology")
+	}
+	var res []DtcTopology
+	sf := map[string]string{"name": name}
+	err := objMgr.connector.GetObject(NewEmptyDtcTopology(), "", NewQueryParams(false, sf), &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, NewNotFoundError(fmt.Sprintf("Dtc Topology with name '%s' not found", name))
+	}
+	return &res[0], nil
+}
+
+func (objMgr *ObjectManager) GetDtcTopologyByRef(ref string) (*DtcTopology, error) {
+	topology := NewEmptyDtcTopology()
+	err := objMgr.connector.GetObject(topology, ref, NewQueryParams(false, nil), topology)
+	if err != nil {
+		return nil, err
+	}
+	return topology, nil
+}
+
+func (objMgr *ObjectManager) UpdateDtcTopology(ref string, name string, comment string, rules []DtcTopologyRuleParams, ea EA) (*DtcTopology, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name is required to update a Dtc Topology object")
+	}
+	topologyRules, err := newDtcTopologyRules(rules, objMgr)
+	if err != nil {
+		return nil, err
+	}
+	topology := NewDtcTopology(name, comment, topologyRules, ea)
+	newRef, err := objMgr.connector.UpdateObject(topology, ref)
+	if err != nil {
+		return nil, err
+	}
+	topology.Ref = newRef
+	return topology, nil
+}
+
+func (objMgr *ObjectManager) DeleteDtcTopology(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+// getDtcTopologyRules returns the rules of the topology in their evaluation order.
+func (objMgr *ObjectManager) getDtcTopologyRules(dtcTopology *DtcTopology) ([]*DtcTopologyRule, error) {
+	var rules []DtcTopologyRule
+	sf := map[string]string{"topology": dtcTopology.Ref}
+	if err := objMgr.getObjects(NewEmptyDtcTopologyRule(), NewQueryParams(false, sf), &rules); err != nil {
+		return nil, err
+	}
+	byRef := make(map[string]*DtcTopologyRule, len(rules))
+	for i := range rules {
+		byRef[rules[i].Ref] = &rules[i]
+	}
+	res := make([]*DtcTopologyRule, 0, len(dtcTopology.Rules))
+	for _, rule := range dtcTopology.Rules {
+		r, ok := byRef[rule.Ref]
+		if !ok {
+			return nil, fmt.Errorf("topology rule '%s' of the topology '%s' not found", rule.Ref, dtcTopology.Ref)
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// GetDtcTopologyRules returns the rules of the topology with the given name, in their evaluation order.
+func (objMgr *ObjectManager) GetDtcTopologyRules(topology string) ([]*DtcTopologyRule, error) {
+	dtcTopology, err := objMgr.GetDtcTopology(topology)
+	if err != nil {
+		return nil, err
+	}
+	return objMgr.getDtcTopologyRules(dtcTopology)
+}
+
+// setDtcTopologyRules replaces the rules of the topology. Rules can only be
+// written as part of their topology, so they are all sent again.
+func (objMgr *ObjectManager) setDtcTopologyRules(topology *DtcTopology, rules []*DtcTopologyRule) (*DtcTopology, error) {
+	newRules := make([]*DtcTopologyRule, 0, len(rules))
+	for _, rule := range rules {
+		newRules = append(newRules, &DtcTopologyRule{
+			DestType:        rule.DestType,
+			DestinationLink: rule.DestinationLink,
+			ReturnType:      rule.ReturnType,
+			Sources:         rule.Sources,
+		})
+	}
+	if err := ValidateDtcTopologyRules(newRules); err != nil {
+		return nil, err
+	}
+	upd := NewEmptyDtcTopology()
+	upd.Rules = newRules
+	upd.Ea = topology.Ea
+	newRef, err := objMgr.connector.UpdateObject(upd, topology.Ref)
+	if err != nil {
+		return nil, err
+	}
+	upd.Ref = newRef
+	upd.Name = topology.Name
+	upd.Comment = topology.Comment
+	return upd, nil
+}
+
+// AddDtcTopologyRule inserts the rule at the given position of the rules
+// of the topology, or appends it if position is negative.
+func (objMgr *ObjectManager) AddDtcTopologyRule(topology string, rule DtcTopologyRuleParams, position int) (*DtcTopology, error) {
+	dtcTopology, err := objMgr.GetDtcTopology(topology)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := objMgr.getDtcTopologyRules(dtcTopology)
+	if err != nil {
+		return nil, err
+	}
+	newRules, err := newDtcTopologyRules([]DtcTopologyRuleParams{rule}, objMgr)
+	if err != nil {
+		return nil, err
+	}
+	if position < 0 || position > len(rules) {
+		position = len(rules)
+	}
+	rules = append(rules[:position], append(newRules, rules[position:]...)...)
+	return objMgr.setDtcTopologyRules(dtcTopology, rules)
+}
+
+// MoveDtcTopologyRule moves the rule at the position from of the rules of the topology to the position to.
+func (objMgr *ObjectManager) MoveDtcTopologyRule(topology string, from int, to int) (*DtcTopology, error) {
+	dtcTopology, err := objMgr.GetDtcTopology(topology)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := objMgr.getDtcTopologyRules(dtcTopology)
+	if err != nil {
+		return nil, err
+	}
+	if from < 0 || from >= len(rules) || to < 0 || to >= len(rules) {
+		return nil, fmt.Errorf("topology rule positions must be between 0 and %d", len(rules)-1)
+	}
+	rule := rules[from]
+	rules = append(rules[:from], rules[from+1:]...)
+	rules = append(rules[:to], append([]*DtcTopologyRule{rule}, rules[to:]...)...)
+	return objMgr.setDtcTopologyRules(dtcTopology, rules)
+}
+
+// DeleteDtcTopologyRule removes the rule at the given position of the rules of the topology.
+func (objMgr *ObjectManager) DeleteDtcTopologyRule(topology string, position int) (*DtcTopology, error) {
+	dtcTopology, err := objMgr.GetDtcTopology(topology)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := objMgr.getDtcTopologyRules(dtcTopology)
+	if err != nil {
+		return nil, err
+	}
+	if position < 0 || position >= len(rules) {
+		return nil, fmt.Errorf("topology rule positions must be between 0 and %d", len(rules)-1)
+	}
+	rules = append(rules[:position], rules[position+1:]...)
+	return objMgr.setDtcTopologyRules(dtcTopology, rules)
+}
+
+// GetDtcTopologyLabels returns the labels of the Topology DB, of the given
+// field only if it is not empty. Labels are read-only, they come with the
+// Topology DB.
+func (objMgr *ObjectManager) GetDtcTopologyLabels(field string) ([]DtcTopologyLabel, error) {
+	var res []DtcTopologyLabel
+	sf := map[string]string{}
+	if field != "" {
+		sf["field"] = field
+	}
+	err := objMgr.connector.GetObject(&DtcTopologyLabel{}, "", NewQueryParams(false, sf), &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
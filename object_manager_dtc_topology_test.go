@@ -0,0 +1,144 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: DTC topology", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	topologyRef := "dtc:topology/ZG5zLmlkbnNfdG9wb2xvZ3kkZ2VvLXRvcG8:geo-topo"
+	euPoolRef := "dtc:pool/ZG5zLmlkbnNfcG9vbCRldS1wb29s:eu-pool"
+	usPoolRef := "dtc:pool/ZG5zLmlkbnNfcG9vbCR1cy1wb29s:us-pool"
+	rule1Ref := "dtc:topology:rule/ZG5zLmlkbnNfdG9wb2xvZ3lfcnVsZSQx:geo-topo/1"
+	rule2Ref := "dtc:topology:rule/ZG5zLmlkbnNfdG9wb2xvZ3lfcnVsZSQy:geo-topo/2"
+	euSources := []*DtcTopologyRuleSource{{SourceType: "CONTINENT", SourceOp: "IS", SourceValue: "Europe"}}
+
+	Describe("Create topology", func() {
+		conn := &fakeConnector{
+			getObjectObj: map[string]interface{}{"DtcPool": &DtcPool{}},
+			resultObject: map[string]interface{}{
+				"DtcPool": []DtcPool{{Ref: euPoolRef, Name: utils.StringPtr("eu-pool")}},
+			},
+			createObjectObj: NewDtcTopology("geo-topo", "", []*DtcTopologyRule{
+				{DestType: "POOL", DestinationLink: &euPoolRef, ReturnType: "REGULAR", Sources: euSources},
+				{DestType: "POOL", DestinationLink: &usPoolRef, ReturnType: "REGULAR"},
+			}, nil),
+			fakeRefReturn: topologyRef,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should resolve the destinations of the rules", func() {
+			topology, err := objMgr.CreateDtcTopology("geo-topo", "", []DtcTopologyRuleParams{
+				{DestType: "POOL", Destination: "eu-pool", Sources: euSources},
+				{DestType: "POOL", Destination: usPoolRef},
+			}, nil)
+			Expect(err).To(BeNil())
+			Expect(topology.Ref).To(Equal(topologyRef))
+		})
+
+		It("should reject a default rule which is not the last one", func() {
+			_, err := objMgr.CreateDtcTopology("geo-topo", "", []DtcTopologyRuleParams{
+				{DestType: "POOL", Destination: usPoolRef},
+				{DestType: "POOL", Destination: "eu-pool", Sources: euSources},
+			}, nil)
+			Expect(err).To(Equal(fmt.Errorf("the default topology rule 0 must be the last one")))
+		})
+	})
+
+	Describe("Order rules", func() {
+		newConn := func(expected []*DtcTopologyRule) *fakeConnector {
+			upd := NewEmptyDtcTopology()
+			upd.Rules = expected
+			upd.Ea = EA{"Site": "HQ"}
+			return &fakeConnector{
+				getObjectObj: map[string]interface{}{"DtcTopology": &DtcTopology{}},
+				resultObject: map[string]interface{}{
+					"DtcTopology": []DtcTopology{{
+						Ref: topologyRef, Name: utils.StringPtr("geo-topo"), Ea: EA{"Site": "HQ"},
+						Rules: []*DtcTopologyRule{{Ref: rule1Ref}, {Ref: rule2Ref}}}},
+					// rules are not returned in their evaluation order
+					"DtcTopologyRule": []DtcTopologyRule{
+						{Ref: rule2Ref, DestType: "POOL", DestinationLink: &usPoolRef, ReturnType: "REGULAR", Topology: topologyRef},
+						{Ref: rule1Ref, DestType: "POOL", DestinationLink: &euPoolRef, ReturnType: "REGULAR", Topology: topologyRef, Sources: euSources},
+					},
+				},
+				updateObjectObj: upd,
+				updateObjectRef: topologyRef,
+				fakeRefReturn:   topologyRef,
+			}
+		}
+
+		It("should return the rules in their evaluation order", func() {
+			objMgr := NewObjectManager(newConn(nil), cmpType, tenantID)
+			rules, err := objMgr.GetDtcTopologyRules("geo-topo")
+			Expect(err).To(BeNil())
+			Expect(rules[0].Ref).To(Equal(rule1Ref))
+			Expect(rules[1].Ref).To(Equal(rule2Ref))
+		})
+
+		It("should insert a rule before the existing ones", func() {
+			objMgr := NewObjectManager(newConn([]*DtcTopologyRule{
+				{DestType: "SERVER", ReturnType: "NXDOMAIN",
+					Sources: []*DtcTopologyRuleSource{{SourceType: "SUBNET", SourceOp: "IS", SourceValue: "10.0.0.0/8"}}},
+				{DestType: "POOL", DestinationLink: &euPoolRef, ReturnType: "REGULAR", Sources: euSources},
+				{DestType: "POOL", DestinationLink: &usPoolRef, ReturnType: "REGULAR"},
+			}), cmpType, tenantID)
+			topology, err := objMgr.AddDtcTopologyRule("geo-topo", DtcTopologyRuleParams{
+				DestType: "SERVER", ReturnType: "NXDOMAIN",
+				Sources: []*DtcTopologyRuleSource{{SourceType: "SUBNET", SourceOp: "IS", SourceValue: "10.0.0.0/8"}}}, 0)
+			Expect(err).To(BeNil())
+			Expect(topology.Ref).To(Equal(topologyRef))
+		})
+
+		It("should not move the default rule before another one", func() {
+			objMgr := NewObjectManager(newConn(nil), cmpType, tenantID)
+			_, err := objMgr.MoveDtcTopologyRule("geo-topo", 1, 0)
+			Expect(err).To(Equal(fmt.Errorf("the default topology rule 0 must be the last one")))
+		})
+
+		It("should delete a rule", func() {
+			objMgr := NewObjectManager(newConn([]*DtcTopologyRule{
+				{DestType: "POOL", DestinationLink: &usPoolRef, ReturnType: "REGULAR"},
+			}), cmpType, tenantID)
+			_, err := objMgr.DeleteDtcTopologyRule("geo-topo", 0)
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("Decode topology", func() {
+		It("should accept rules given as references", func() {
+			var topology DtcTopology
+			err := json.Unmarshal([]byte(`{"_ref":"`+topologyRef+`","name":"geo-topo","rules":["`+rule1Ref+`"]}`), &topology)
+			Expect(err).To(BeNil())
+			Expect(topology.Rules).To(Equal([]*DtcTopologyRule{{Ref: rule1Ref}}))
+		})
+	})
+
+	Describe("Match rules", func() {
+		rules := []*DtcTopologyRule{
+			{DestType: "POOL", DestinationLink: &usPoolRef, Sources: []*DtcTopologyRuleSource{
+				{SourceType: "SUBNET", SourceOp: "IS", SourceValue: "10.0.0.0/8"},
+				{SourceType: "EA0", SourceOp: "IS_NOT", SourceValue: "lab"}}},
+			{DestType: "POOL", DestinationLink: &euPoolRef, Sources: euSources},
+			{DestType: "POOL", DestinationLink: &usPoolRef},
+		}
+
+		It("should return the first rule matching the client", func() {
+			Expect(MatchDtcTopologyRules(rules, DtcTopologyClient{IP: "10.1.1.1", Ea: map[string]string{"EA0": "prod"}})).To(Equal(0))
+			Expect(MatchDtcTopologyRules(rules, DtcTopologyClient{IP: "10.1.1.1", Continent: "Europe", Ea: map[string]string{"EA0": "lab"}})).To(Equal(1))
+			Expect(MatchDtcTopologyRules(rules, DtcTopologyClient{IP: "192.0.2.1", Continent: "Asia"})).To(Equal(2))
+			Expect(MatchDtcTopologyRules(rules[:2], DtcTopologyClient{IP: "192.0.2.1"})).To(Equal(-1))
+		})
+
+		It("should reject an invalid client address", func() {
+			_, err := MatchDtcTopologyRules(rules, DtcTopologyClient{IP: "client"})
+			Expect(err).To(Equal(fmt.Errorf("'client' is not a valid IP address")))
+		})
+	})
+})
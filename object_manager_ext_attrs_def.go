@@ -1,6 +1,94 @@
 package ibclient
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// eaDefFlagsOrder is the order in which WAPI requires the EA definition flags to be listed.
+const eaDefFlagsOrder = "ACGILMPRSV"
+
+// EADefFlags represents the 'flags' field of an extensible attribute definition.
+type EADefFlags struct {
+	Audited        bool // A
+	CloudAPI       bool // C
+	CloudGmaster   bool // G
+	Inheritable    bool // I
+	Listed         bool // L
+	Mandatory      bool // M
+	MgmPrivate     bool // P
+	ReadOnly       bool // R
+	SortEnumValues bool // S
+	MultipleValues bool // V
+}
+
+// String returns the flags in the order required by WAPI.
+func (f EADefFlags) String() string {
+	set := []bool{f.Audited, f.CloudAPI, f.CloudGmaster, f.Inheritable, f.Listed,
+		f.Mandatory, f.MgmPrivate, f.ReadOnly, f.SortEnumValues, f.MultipleValues}
+	var sb strings.Builder
+	for i, isSet := range set {
+		if isSet {
+			sb.WriteByte(eaDefFlagsOrder[i])
+		}
+	}
+	return sb.String()
+}
+
+// ParseEADefFlags parses the 'flags' field of an extensible attribute definition.
+func ParseEADefFlags(flags string) (EADefFlags, error) {
+	var f EADefFlags
+	fields := []*bool{&f.Audited, &f.CloudAPI, &f.CloudGmaster, &f.Inheritable, &f.Listed,
+		&f.Mandatory, &f.MgmPrivate, &f.ReadOnly, &f.SortEnumValues, &f.MultipleValues}
+	for _, c := range flags {
+		idx := strings.IndexRune(eaDefFlagsOrder, c)
+		if idx < 0 {
+			return f, fmt.Errorf("unknown extensible attribute definition flag '%c'", c)
+		}
+		*fields[idx] = true
+	}
+	return f, nil
+}
+
+// ValidateEADefFlags returns an error if the flags are unknown or are not
+// listed in the order required by WAPI.
+func ValidateEADefFlags(flags string) error {
+	f, err := ParseEADefFlags(flags)
+	if err != nil {
+		return err
+	}
+	if f.String() != flags {
+		return fmt.Errorf("extensible attribute definition flags '%s' must be listed in the order '%s'", flags, eaDefFlagsOrder)
+	}
+	return nil
+}
+
+func validateEADefinition(eadef EADefinition) error {
+	if eadef.Flags != nil {
+		if err := ValidateEADefFlags(*eadef.Flags); err != nil {
+			return err
+		}
+	}
+	if eadef.Min != nil && eadef.Max != nil && *eadef.Min > *eadef.Max {
+		return fmt.Errorf("minimum value %d of the extensible attribute definition exceeds maximum value %d", *eadef.Min, *eadef.Max)
+	}
+	return nil
+}
+
+// NewEmptyEADefinition returns an extensible attribute definition to be
+// read, whose return fields include the default value and the range of
+// INTEGER definitions.
+func NewEmptyEADefinition() *EADefinition {
+	eadef := NewEADefinition(EADefinition{})
+	eadef.SetReturnFields(append(eadef.ReturnFields(), "default_value", "max", "min"))
+	return eadef
+}
+
 func (objMgr *ObjectManager) CreateEADefinition(eadef EADefinition) (*EADefinition, error) {
+	if err := validateEADefinition(eadef); err != nil {
+		return nil, err
+	}
 	newEadef := NewEADefinition(eadef)
 
 	ref, err := objMgr.connector.CreateObject(newEadef)
@@ -12,7 +100,7 @@ func (objMgr *ObjectManager) CreateEADefinition(eadef EADefinition) (*EADefiniti
 func (objMgr *ObjectManager) GetEADefinition(name string) (*EADefinition, error) {
 	var res []EADefinition
 
-	eadef := NewEADefinition(EADefinition{Name: &name})
+	eadef := NewEmptyEADefinition()
 
 	sf := map[string]string{
 		"name": name,
@@ -26,3 +114,152 @@ func (objMgr *ObjectManager) GetEADefinition(name string) (*EADefinition, error)
 
 	return &res[0], nil
 }
+
+// GetAllEADefinitions returns the extensible attribute definitions matching the query parameters.
+func (objMgr *ObjectManager) GetAllEADefinitions(queryParams *QueryParams) ([]EADefinition, error) {
+	var res []EADefinition
+
+	eadef := NewEmptyEADefinition()
+	err := objMgr.connector.GetObject(eadef, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("error getting EA definitions, err: %s", err)
+	}
+
+	return res, nil
+}
+
+// GetEADefinitionByRef returns the extensible attribute definition by its reference.
+func (objMgr *ObjectManager) GetEADefinitionByRef(ref string) (*EADefinition, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty reference to an object is not allowed")
+	}
+	eadef := NewEmptyEADefinition()
+	err := objMgr.connector.GetObject(eadef, ref, NewQueryParams(false, nil), &eadef)
+	if err != nil {
+		return nil, err
+	}
+
+	return eadef, nil
+}
+
+// UpdateEADefinition updates the extensible attribute definition with the
+// fields set in eadef: comment, flags, list values, allowed object types,
+// min/max, default value and descendants action.
+func (objMgr *ObjectManager) UpdateEADefinition(ref string, eadef EADefinition) (*EADefinition, error) {
+	if err := validateEADefinition(eadef); err != nil {
+		return nil, err
+	}
+	newEadef := NewEADefinition(eadef)
+	newEadef.Ref = ""
+
+	newRef, err := objMgr.connector.UpdateObject(newEadef, ref)
+	if err != nil {
+		return nil, err
+	}
+	newEadef.Ref = newRef
+
+	return newEadef, nil
+}
+
+// UpdateEADefinitionListValues adds and removes values of an ENUM extensible
+// attribute definition, keeping the order of the existing values.
+func (objMgr *ObjectManager) UpdateEADefinitionListValues(ref string, add []string, remove []string) (*EADefinition, error) {
+	eadef, err := objMgr.GetEADefinitionByRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToUpper(eadef.Type) != "ENUM" {
+		return nil, fmt.Errorf("list values are applicable only to ENUM extensible attributes")
+	}
+
+	removed := make(map[string]bool)
+	for _, v := range remove {
+		removed[v] = true
+	}
+	present := make(map[string]bool)
+	listValues := []*EADefListValue{}
+	for _, lv := range eadef.ListValues {
+		if lv != nil && !removed[lv.Value] && !present[lv.Value] {
+			present[lv.Value] = true
+			listValues = append(listValues, lv)
+		}
+	}
+	for _, v := range add {
+		if !present[v] {
+			present[v] = true
+			listValues = append(listValues, &EADefListValue{Value: v})
+		}
+	}
+	if len(listValues) == 0 {
+		return nil, fmt.Errorf("an ENUM extensible attribute definition must have at least one list value")
+	}
+
+	return objMgr.UpdateEADefinition(ref, EADefinition{ListValues: listValues})
+}
+
+// DeleteEADefinition deletes the extensible attribute definition.
+func (objMgr *ObjectManager) DeleteEADefinition(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+// MigrateEA copies the value of the 'oldName' extensible attribute into the
+// 'newName' one on all objects of the given types which carry it, removing
+// 'oldName' from the objects when removeOld is set. The definition of
+// 'newName' must exist. It returns the number of objects migrated, even on error.
+func (objMgr *ObjectManager) MigrateEA(oldName string, newName string, objectTypes []string, removeOld bool) (int, error) {
+	if oldName == "" || newName == "" || oldName == newName {
+		return 0, fmt.Errorf("distinct old and new extensible attribute names are required for a migration")
+	}
+	if len(objectTypes) == 0 {
+		return 0, fmt.Errorf("at least one object type is required for a migration")
+	}
+	newDef, err := objMgr.GetEADefinition(newName)
+	if err != nil {
+		return 0, err
+	}
+	if newDef == nil {
+		return 0, NewNotFoundError(fmt.Sprintf("extensible attribute definition '%s' not found", newName))
+	}
+	oldDef, err := objMgr.GetEADefinition(oldName)
+	if err != nil {
+		return 0, err
+	}
+
+	// Objects carrying a textual EA can be found with a regular expression search,
+	// other ones are filtered out after listing all objects of the type.
+	sf := map[string]string{}
+	if oldDef != nil {
+		switch strings.ToUpper(oldDef.Type) {
+		case "STRING", "ENUM", "EMAIL", "URL":
+			sf[fmt.Sprintf("*%s~", oldName)] = "."
+		}
+	}
+
+	migrated := 0
+	for _, objType := range objectTypes {
+		found, err := objMgr.getPagedObjects(newEAObject(objType), sf)
+		if err != nil {
+			return migrated, fmt.Errorf("error getting %s objects, err: %s", objType, err)
+		}
+		for _, raw := range found {
+			var obj eaObject
+			if err = json.Unmarshal(raw, &obj); err != nil {
+				return migrated, err
+			}
+			val, ok := obj.Ea[oldName]
+			if !ok {
+				continue
+			}
+			update := &eaUpdate{objectType: objType, EaAdd: EA{newName: val}}
+			if removeOld {
+				update.EaRemove = map[string]struct{}{oldName: {}}
+			}
+			if _, err = objMgr.connector.UpdateObject(update, obj.Ref); err != nil {
+				return migrated, fmt.Errorf("error migrating extensible attribute of '%s', err: %s", obj.Ref, err)
+			}
+			migrated++
+		}
+	}
+
+	return migrated, nil
+}
@@ -1,6 +1,9 @@
 package ibclient
 
 import (
+	"encoding/json"
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -41,6 +44,15 @@ var _ = Describe("Object Manager: EA definition", func() {
 			Expect(actualEADef).To(Equal(eadFakeConnector.resultObject))
 			Expect(err).To(BeNil())
 		})
+
+		It("should validate the flags and the range", func() {
+			badFlags := "VC"
+			min, max := uint32(10), uint32(1)
+			_, err := objMgr.CreateEADefinition(EADefinition{Name: &name, Type: "STRING", Flags: &badFlags})
+			Expect(err).To(Equal(fmt.Errorf("extensible attribute definition flags 'VC' must be listed in the order 'ACGILMPRSV'")))
+			_, err = objMgr.CreateEADefinition(EADefinition{Name: &name, Type: "INTEGER", Min: &min, Max: &max})
+			Expect(err).To(Equal(fmt.Errorf("minimum value 10 of the extensible attribute definition exceeds maximum value 1")))
+		})
 	})
 
 	Describe("Get EA Definition", func() {
@@ -52,9 +64,6 @@ var _ = Describe("Object Manager: EA definition", func() {
 		name := "TestEA"
 		eaType := "string"
 		allowedTypes := []string{"arecord", "aaarecord", "ptrrecord"}
-		ead := EADefinition{
-			Name: &name,
-		}
 		fakeRefReturn := "extensibleattributedef/ZG5zLm5ldHdvcmtfdmlldyQyMw:TestEA"
 		eadRes := EADefinition{
 			Name:               &name,
@@ -73,7 +82,7 @@ var _ = Describe("Object Manager: EA definition", func() {
 			})
 
 		eadFakeConnector := &fakeConnector{
-			getObjectObj:         NewEADefinition(ead),
+			getObjectObj:         NewEmptyEADefinition(),
 			getObjectRef:         "",
 			getObjectQueryParams: queryParams,
 			resultObject:         []EADefinition{*NewEADefinition(eadRes)},
@@ -92,4 +101,138 @@ var _ = Describe("Object Manager: EA definition", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+	Describe("Update EA Definition", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		ref := "extensibleattributedef/ZG5zLm5ldHdvcmtfdmlldyQyMw:TestEA"
+		comment := "Updated Extensible Attribute"
+		flags := "IMV"
+		min := uint32(1)
+		max := uint32(100)
+		ead := EADefinition{
+			Comment: &comment,
+			Flags:   &flags,
+			Min:     &min,
+			Max:     &max,
+			DescendantsAction: &ExtensibleattributedefDescendants{
+				OptionWithEa:    "RETAIN",
+				OptionWithoutEa: "INHERIT",
+				OptionDeleteEa:  "REMOVE",
+			},
+		}
+		conn := &fakeConnector{
+			updateObjectObj: NewEADefinition(ead),
+			updateObjectRef: ref,
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected EA Definition Object to UpdateObject", func() {
+			actualEADef, err := objMgr.UpdateEADefinition(ref, ead)
+			Expect(err).To(BeNil())
+			Expect(actualEADef.Ref).To(Equal(ref))
+			Expect(actualEADef.DescendantsAction).To(Equal(ead.DescendantsAction))
+		})
+
+		It("should reject flags listed out of order", func() {
+			badFlags := "VI"
+			_, err := objMgr.UpdateEADefinition(ref, EADefinition{Flags: &badFlags})
+			Expect(err).To(Equal(fmt.Errorf("extensible attribute definition flags 'VI' must be listed in the order 'ACGILMPRSV'")))
+		})
+
+		It("should reject a minimum above the maximum", func() {
+			_, err := objMgr.UpdateEADefinition(ref, EADefinition{Min: &max, Max: &min})
+			Expect(err).To(Equal(fmt.Errorf("minimum value 100 of the extensible attribute definition exceeds maximum value 1")))
+		})
+	})
+
+	Describe("Update EA Definition list values", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		ref := "extensibleattributedef/ZG5zLm5ldHdvcmtfdmlldyQyMw:Site"
+		name := "Site"
+		current := EADefinition{
+			Ref:        ref,
+			Name:       &name,
+			Type:       "ENUM",
+			ListValues: []*EADefListValue{{Value: "Blr"}, {Value: "Hyd"}, {Value: "Pune"}},
+		}
+		conn := &fakeConnector{
+			getObjectObj:         NewEmptyEADefinition(),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         ref,
+			resultObject:         NewEADefinition(current),
+			updateObjectObj: NewEADefinition(EADefinition{
+				ListValues: []*EADefListValue{{Value: "Blr"}, {Value: "Pune"}, {Value: "Chennai"}},
+			}),
+			updateObjectRef: ref,
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should add and remove list values keeping the existing order", func() {
+			_, err := objMgr.UpdateEADefinitionListValues(ref, []string{"Chennai", "Blr"}, []string{"Hyd"})
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("Delete EA Definition", func() {
+		ref := "extensibleattributedef/ZG5zLm5ldHdvcmtfdmlldyQyMw:TestEA"
+		conn := &fakeConnector{
+			deleteObjectRef: ref,
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+		It("should pass expected reference to DeleteObject", func() {
+			actualRef, err := objMgr.DeleteEADefinition(ref)
+			Expect(err).To(BeNil())
+			Expect(actualRef).To(Equal(ref))
+		})
+	})
+
+	Describe("Migrate EA", func() {
+		oldName := "Location"
+		newName := "Site"
+		eaType := "STRING"
+		netRef := "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default"
+		conn := &fakeConnector{
+			getObjectObj: map[string]interface{}{
+				"EADefinition": NewEmptyEADefinition(),
+				"EAObjects":    newEAObject("network"),
+			},
+			resultObject: map[string]interface{}{
+				"EADefinition": []EADefinition{{Name: &newName, Type: eaType}},
+				"EAObjects": wapiPage{Result: []json.RawMessage{
+					json.RawMessage(`{"_ref":"` + netRef + `","extattrs":{"` + oldName + `":{"value":"Blr"},"Tenant":{"value":"t1"}}}`),
+					json.RawMessage(`{"_ref":"network/ZG5zLm5ldHdvcmskMTAuMC4xLjAvMjQvMA:10.0.1.0/24/default","extattrs":{}}`),
+				}},
+			},
+			updateObjectObj: &eaUpdate{
+				objectType: "network",
+				EaAdd:      EA{newName: "Blr"},
+				EaRemove:   map[string]struct{}{oldName: {}},
+			},
+			updateObjectRef: netRef,
+			fakeRefReturn:   netRef,
+		}
+		objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+		It("should copy the EA value of the objects carrying it and remove the old EA", func() {
+			migrated, err := objMgr.MigrateEA(oldName, newName, []string{"network"}, true)
+			Expect(err).To(BeNil())
+			Expect(migrated).To(Equal(1))
+		})
+	})
+
+	Describe("EA Definition flags", func() {
+		It("should parse and format flags in WAPI order", func() {
+			f, err := ParseEADefFlags("AIMV")
+			Expect(err).To(BeNil())
+			Expect(f).To(Equal(EADefFlags{Audited: true, Inheritable: true, Mandatory: true, MultipleValues: true}))
+			Expect(f.String()).To(Equal("AIMV"))
+			_, err = ParseEADefFlags("X")
+			Expect(err).NotTo(BeNil())
+		})
+	})
 })
@@ -243,3 +243,89 @@ func (objMgr *ObjectManager) GetAllFixedAddress(queryParams *QueryParams, isIpv6
 	}
 	return res, nil
 }
+
+// AllocateIpv6FixedAddress reserves for the DUID, depending on the address
+// type, an IPv6 address (ADDRESS), a delegated prefix (PREFIX) or both
+// (BOTH). The next available address of the network is reserved when no
+// address is given.
+func (objMgr *ObjectManager) AllocateIpv6FixedAddress(
+	netview string,
+	cidr string,
+	addressType string,
+	ipAddr string,
+	ipv6Prefix string,
+	ipv6PrefixBits uint32,
+	duid string,
+	name string,
+	comment string,
+	eas EA,
+	disable bool,
+	options []*Dhcpoption,
+	useOptions bool,
+) (*FixedAddress, error) {
+	if duid == "" {
+		return nil, fmt.Errorf("the DUID field cannot be left empty")
+	}
+	if addressType == "" {
+		addressType = "ADDRESS"
+	}
+	if netview == "" {
+		netview = "default"
+	}
+	withAddress, withPrefix := addressType == "ADDRESS" || addressType == "BOTH", addressType == "PREFIX" || addressType == "BOTH"
+	if !withAddress && !withPrefix {
+		return nil, fmt.Errorf("invalid address type '%s', must be ADDRESS, PREFIX or BOTH", addressType)
+	}
+	if withAddress {
+		if ipAddr != "" {
+			if ip := net.ParseIP(ipAddr); ip == nil || ip.To4() != nil {
+				return nil, fmt.Errorf("IP address must be an IPv6 address, not an IPv4 one")
+			}
+		} else {
+			ipAddress, _, err := net.ParseCIDR(cidr)
+			if err != nil || ipAddress.To4() != nil {
+				return nil, fmt.Errorf("an IPv6 address, or the IPv6 CIDR of the network to allocate it from, is required for the %s address type", addressType)
+			}
+			ipAddr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+		}
+	} else if ipAddr != "" {
+		return nil, fmt.Errorf("no IP address can be given for the PREFIX address type")
+	}
+	if withPrefix {
+		if ip := net.ParseIP(ipv6Prefix); ip == nil || ip.To4() != nil {
+			return nil, fmt.Errorf("a valid IPv6 prefix is required for the %s address type", addressType)
+		}
+		if ipv6PrefixBits == 0 || ipv6PrefixBits > 128 {
+			return nil, fmt.Errorf("prefix length must be between 1 and 128")
+		}
+	}
+
+	fixedAddr := NewFixedAddress(
+		netview, name, ipAddr, cidr, duid, nil, eas, "", true, comment, nil, nil, nil, nil, disable, options, useOptions)
+	fixedAddr.AddressType = addressType
+	if withPrefix {
+		fixedAddr.Ipv6Prefix = ipv6Prefix
+		fixedAddr.Ipv6PrefixBits = &ipv6PrefixBits
+	}
+	ref, err := objMgr.connector.CreateObject(fixedAddr)
+	if err != nil {
+		return nil, err
+	}
+	return objMgr.GetFixedAddressByRef(ref)
+}
+
+// GetIpv6FixedAddresses returns the IPv6 fixed addresses of the network
+// view, for the DUID and of the address type if they are given.
+func (objMgr *ObjectManager) GetIpv6FixedAddresses(netview string, duid string, addressType string) ([]FixedAddress, error) {
+	sf := map[string]string{}
+	if netview != "" {
+		sf["network_view"] = netview
+	}
+	if duid != "" {
+		sf["duid"] = duid
+	}
+	if addressType != "" {
+		sf["address_type"] = addressType
+	}
+	return objMgr.GetAllFixedAddress(NewQueryParams(false, sf), true)
+}
@@ -621,4 +621,88 @@ var _ = Describe("Object Manager: fixed address", func() {
 		Expect(err).To(BeNil())
 		Expect(actualObj).To(BeEquivalentTo(expectedObj))
 	})
+
+	Describe("Allocate IPv6 Fixed Address with a delegated prefix", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		netviewName := "private"
+		cidr := "2001:db8:abcd:12::/64"
+		duid := "00:01:00:01:2a:3b:4c:5d"
+		prefix := "2001:db8:abcd:1000::"
+		prefixBits := uint32(56)
+		fakeRefReturn := "ipv6fixedaddress/ZG5zLmJpbmRfY25h:2001%3Adb8%3Aabcd%3A12%3A%3A5/private"
+
+		expectedObj := NewFixedAddress(netviewName, "client", "func:nextavailableip:2001:db8:abcd:12::/64,private", cidr, duid,
+			nil, nil, "", true, "", nil, nil, nil, nil, false, nil, false)
+		expectedObj.AddressType = "BOTH"
+		expectedObj.Ipv6Prefix = prefix
+		expectedObj.Ipv6PrefixBits = &prefixBits
+
+		resultObj := NewFixedAddress(netviewName, "client", "2001:db8:abcd:12::5", cidr, duid,
+			nil, nil, fakeRefReturn, true, "", nil, nil, nil, nil, false, nil, false)
+		resultObj.AddressType = "BOTH"
+		resultObj.Ipv6Prefix = prefix
+		resultObj.Ipv6PrefixBits = &prefixBits
+
+		conn := &fakeConnector{
+			createObjectObj:      expectedObj,
+			getObjectObj:         NewEmptyFixedAddress(true),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         fakeRefReturn,
+			resultObject:         resultObj,
+			fakeRefReturn:        fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should reserve the next available address and the prefix for the DUID", func() {
+			actualObj, err := objMgr.AllocateIpv6FixedAddress(netviewName, cidr, "BOTH", "", prefix, prefixBits, duid, "client", "", nil, false, nil, false)
+			Expect(err).To(BeNil())
+			Expect(actualObj).To(Equal(resultObj))
+		})
+
+		It("should fail without the DUID", func() {
+			_, err := objMgr.AllocateIpv6FixedAddress(netviewName, cidr, "PREFIX", "", prefix, prefixBits, "", "client", "", nil, false, nil, false)
+			Expect(err).To(MatchError("the DUID field cannot be left empty"))
+		})
+
+		It("should fail if the prefix or its length is not valid", func() {
+			_, err := objMgr.AllocateIpv6FixedAddress(netviewName, cidr, "PREFIX", "", "10.0.0.0", prefixBits, duid, "client", "", nil, false, nil, false)
+			Expect(err).To(MatchError("a valid IPv6 prefix is required for the PREFIX address type"))
+			_, err = objMgr.AllocateIpv6FixedAddress(netviewName, cidr, "PREFIX", "", prefix, 0, duid, "client", "", nil, false, nil, false)
+			Expect(err).To(MatchError("prefix length must be between 1 and 128"))
+		})
+
+		It("should fail if an address is given for the PREFIX address type", func() {
+			_, err := objMgr.AllocateIpv6FixedAddress(netviewName, cidr, "PREFIX", "2001:db8:abcd:12::5", prefix, prefixBits, duid, "client", "", nil, false, nil, false)
+			Expect(err).To(MatchError("no IP address can be given for the PREFIX address type"))
+		})
+
+		It("should fail with an IPv4 network", func() {
+			_, err := objMgr.AllocateIpv6FixedAddress(netviewName, "10.0.0.0/24", "ADDRESS", "", "", 0, duid, "client", "", nil, false, nil, false)
+			Expect(err).To(MatchError("an IPv6 address, or the IPv6 CIDR of the network to allocate it from, is required for the ADDRESS address type"))
+		})
+	})
+
+	Describe("Get IPv6 Fixed Addresses of a DUID", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		duid := "00:01:00:01:2a:3b:4c:5d"
+		result := []FixedAddress{{Ref: "ipv6fixedaddress/ZG5zLmJpbmRfY25h:2001%3Adb8%3A%3A5/default", Duid: duid, AddressType: "PREFIX"}}
+		conn := &fakeConnector{
+			getObjectObj: NewEmptyFixedAddress(true),
+			getObjectQueryParams: NewQueryParams(false, map[string]string{
+				"network_view": "default",
+				"duid":         duid,
+				"address_type": "PREFIX",
+			}),
+			resultObject: result,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should search the fixed addresses by DUID and address type", func() {
+			actual, err := objMgr.GetIpv6FixedAddresses("default", duid, "PREFIX")
+			Expect(err).To(BeNil())
+			Expect(actual).To(Equal(result))
+		})
+	})
 })
@@ -0,0 +1,149 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IPAddressFilters restricts the addresses returned by GetIpv4Addresses and
+// GetIpv6Addresses.
+type IPAddressFilters struct {
+	Status         string            // USED, UNUSED or CONFLICT
+	DiscoveredData map[string]string // discovered data fields, such as device_vendor or os
+}
+
+func NewEmptyIPv4Address() *IPv4Address {
+	addr := &IPv4Address{}
+	addr.SetReturnFields(append(addr.ReturnFields(), "comment", "conflict_types", "discovered_data", "extattrs",
+		"fingerprint", "is_invalid_mac", "reserved_port"))
+	return addr
+}
+
+func NewEmptyIPv6Address() *IPv6Address {
+	addr := &IPv6Address{}
+	addr.SetReturnFields(append(addr.ReturnFields(), "comment", "conflict_types", "discovered_data", "extattrs",
+		"fingerprint", "reserved_port"))
+	return addr
+}
+
+// ipAddressSearchFields returns the search fields of the addresses of the
+// network matching the filters.
+func ipAddressSearchFields(netview string, cidr string, isIPv6 bool, filters IPAddressFilters) (map[string]string, error) {
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil || (ip.To4() == nil) != isIPv6 {
+		ipVer := "IPv4"
+		if isIPv6 {
+			ipVer = "IPv6"
+		}
+		return nil, fmt.Errorf("'%s' is not a valid %s network", cidr, ipVer)
+	}
+	if netview == "" {
+		netview = "default"
+	}
+	sf := map[string]string{
+		"network":      cidr,
+		"network_view": netview,
+	}
+	switch filters.Status {
+	case "":
+	case "USED", "UNUSED":
+		sf["status"] = filters.Status
+	case "CONFLICT":
+		sf["is_conflict"] = "true"
+	default:
+		return nil, fmt.Errorf("invalid status '%s', must be USED, UNUSED or CONFLICT", filters.Status)
+	}
+	for field, value := range filters.DiscoveredData {
+		if field == "" || strings.Contains(field, ".") {
+			return nil, fmt.Errorf("invalid discovered data field '%s'", field)
+		}
+		sf["discovered_data."+field] = value
+	}
+	return sf, nil
+}
+
+// GetIpv4Addresses returns the addresses of the IPv4 network, used or not,
+// along with their status and the objects they are associated with.
+func (objMgr *ObjectManager) GetIpv4Addresses(netview string, cidr string, filters IPAddressFilters) ([]IPv4Address, error) {
+	sf, err := ipAddressSearchFields(netview, cidr, false, filters)
+	if err != nil {
+		return nil, err
+	}
+	found, err := objMgr.getPagedObjects(NewEmptyIPv4Address(), sf)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting the addresses of network '%s': %s", cidr, err)
+	}
+	res := make([]IPv4Address, len(found))
+	for i, raw := range found {
+		if err = json.Unmarshal(raw, &res[i]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// GetIpv6Addresses returns the addresses of the IPv6 network which are used
+// or were discovered, along with their status and the objects they are
+// associated with.
+func (objMgr *ObjectManager) GetIpv6Addresses(netview string, cidr string, filters IPAddressFilters) ([]IPv6Address, error) {
+	sf, err := ipAddressSearchFields(netview, cidr, true, filters)
+	if err != nil {
+		return nil, err
+	}
+	found, err := objMgr.getPagedObjects(NewEmptyIPv6Address(), sf)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting the addresses of network '%s': %s", cidr, err)
+	}
+	res := make([]IPv6Address, len(found))
+	for i, raw := range found {
+		if err = json.Unmarshal(raw, &res[i]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// ClearIPAddress frees the IP address by deleting every object it is
+// associated with, such as its host record, fixed address and A or PTR
+// records, and returns the reference of the address.
+func (objMgr *ObjectManager) ClearIPAddress(netview string, ipAddr string) (string, error) {
+	ip := net.ParseIP(ipAddr)
+	if ip == nil {
+		return "", fmt.Errorf("'%s' is not a valid IP address", ipAddr)
+	}
+	if netview == "" {
+		netview = "default"
+	}
+	qp := NewQueryParams(false, map[string]string{
+		"ip_address":   ipAddr,
+		"network_view": netview,
+	})
+
+	var ref, status string
+	if ip.To4() != nil {
+		var res []IPv4Address
+		if err := objMgr.connector.GetObject(NewEmptyIPv4Address(), "", qp, &res); err != nil {
+			return "", err
+		}
+		if len(res) > 0 {
+			ref, status = res[0].Ref, res[0].Status
+		}
+	} else {
+		var res []IPv6Address
+		if err := objMgr.connector.GetObject(NewEmptyIPv6Address(), "", qp, &res); err != nil {
+			return "", err
+		}
+		if len(res) > 0 {
+			ref, status = res[0].Ref, res[0].Status
+		}
+	}
+	if ref == "" {
+		return "", NewNotFoundError(fmt.Sprintf("IP address '%s' not found in network view '%s'", ipAddr, netview))
+	}
+	if status == "UNUSED" {
+		return "", fmt.Errorf("IP address '%s' in network view '%s' is not used", ipAddr, netview)
+	}
+	return objMgr.connector.DeleteObject(ref)
+}
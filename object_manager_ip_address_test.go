@@ -0,0 +1,127 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: IP address", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	Describe("Get the addresses of an IPv4 network", func() {
+		conn := &fakeConnector{
+			getObjectObj: NewEmptyIPv4Address(),
+			getObjectQueryParams: NewQueryParams(false, map[string]string{
+				"network":                       "10.20.0.0/24",
+				"network_view":                  "default",
+				"status":                        "USED",
+				"discovered_data.device_vendor": "Cisco",
+				"_paging":                       "1",
+				"_return_as_object":             "1",
+				"_max_results":                  "1000",
+			}),
+			getObjectRef: "",
+			resultObject: wapiPage{Result: []json.RawMessage{
+				json.RawMessage(`{"_ref":"ipv4address/Li5pcHY0X2FkZHJlc3MkMTAuMjAuMC41LzA:10.20.0.5","ip_address":"10.20.0.5",` +
+					`"status":"USED","types":["HOST","A"],"names":["web.example.com"],"mac_address":"00:11:22:33:44:55",` +
+					`"lease_state":"FREE","usage":["DNS","DHCP"],"objects":["record:host/ZG5zLmhvc3QkLl9kZWZhdWx0:web.example.com/default"],` +
+					`"discovered_data":{"device_vendor":"Cisco"}}`),
+			}},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass the filters to WAPI and return the addresses", func() {
+			res, err := objMgr.GetIpv4Addresses("", "10.20.0.0/24", IPAddressFilters{
+				Status: "USED", DiscoveredData: map[string]string{"device_vendor": "Cisco"}})
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].IpAddress).To(Equal("10.20.0.5"))
+			Expect(res[0].Types).To(Equal([]string{"HOST", "A"}))
+			Expect(res[0].Names).To(Equal([]string{"web.example.com"}))
+			Expect(res[0].MacAddress).To(Equal("00:11:22:33:44:55"))
+			Expect(res[0].DiscoveredData.DeviceVendor).To(Equal("Cisco"))
+		})
+
+		It("should reject an invalid status", func() {
+			_, err := objMgr.GetIpv4Addresses("", "10.20.0.0/24", IPAddressFilters{Status: "FREE"})
+			Expect(err).To(MatchError("invalid status 'FREE', must be USED, UNUSED or CONFLICT"))
+		})
+
+		It("should reject an IPv6 network", func() {
+			_, err := objMgr.GetIpv4Addresses("", "2001:db8::/64", IPAddressFilters{})
+			Expect(err).To(MatchError("'2001:db8::/64' is not a valid IPv4 network"))
+		})
+	})
+
+	Describe("Get the conflicting addresses of an IPv6 network", func() {
+		conn := &fakeConnector{
+			getObjectObj: NewEmptyIPv6Address(),
+			getObjectQueryParams: NewQueryParams(false, map[string]string{
+				"network":           "2001:db8::/64",
+				"network_view":      "internal",
+				"is_conflict":       "true",
+				"_paging":           "1",
+				"_return_as_object": "1",
+				"_max_results":      "1000",
+			}),
+			getObjectRef: "",
+			resultObject: wapiPage{Result: []json.RawMessage{
+				json.RawMessage(`{"_ref":"ipv6address/Li5pcHY2X2FkZHJlc3MkMjAwMTpkYjg6OjUvMQ:2001%3Adb8%3A%3A5/internal",` +
+					`"ip_address":"2001:db8::5","status":"USED","is_conflict":true,"conflict_types":["DUID_CONFLICT"],"duid":"00:01:00:01"}`),
+			}},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should search the addresses in conflict", func() {
+			res, err := objMgr.GetIpv6Addresses("internal", "2001:db8::/64", IPAddressFilters{Status: "CONFLICT"})
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].IsConflict).To(BeTrue())
+			Expect(res[0].ConflictTypes).To(Equal([]string{"DUID_CONFLICT"}))
+		})
+	})
+
+	Describe("Clear an IP address", func() {
+		ref := "ipv4address/Li5pcHY0X2FkZHJlc3MkMTAuMjAuMC41LzA:10.20.0.5"
+		qp := NewQueryParams(false, map[string]string{"ip_address": "10.20.0.5", "network_view": "default"})
+
+		It("should delete the address along with its objects", func() {
+			conn := &fakeConnector{
+				getObjectObj:         NewEmptyIPv4Address(),
+				getObjectQueryParams: qp,
+				getObjectRef:         "",
+				resultObject:         []IPv4Address{{Ref: ref, IpAddress: "10.20.0.5", Status: "USED"}},
+				deleteObjectRef:      ref,
+				fakeRefReturn:        ref,
+			}
+			actualRef, err := NewObjectManager(conn, cmpType, tenantID).ClearIPAddress("", "10.20.0.5")
+			Expect(err).To(BeNil())
+			Expect(actualRef).To(Equal(ref))
+		})
+
+		It("should fail if the address is not used", func() {
+			conn := &fakeConnector{
+				getObjectObj:         NewEmptyIPv4Address(),
+				getObjectQueryParams: qp,
+				getObjectRef:         "",
+				resultObject:         []IPv4Address{{Ref: ref, IpAddress: "10.20.0.5", Status: "UNUSED"}},
+			}
+			_, err := NewObjectManager(conn, cmpType, tenantID).ClearIPAddress("", "10.20.0.5")
+			Expect(err).To(MatchError("IP address '10.20.0.5' in network view 'default' is not used"))
+		})
+
+		It("should fail if the address is not found", func() {
+			conn := &fakeConnector{
+				getObjectObj:         NewEmptyIPv4Address(),
+				getObjectQueryParams: qp,
+				getObjectRef:         "",
+				resultObject:         []IPv4Address{},
+			}
+			_, err := NewObjectManager(conn, cmpType, tenantID).ClearIPAddress("", "10.20.0.5")
+			Expect(err).To(BeAssignableToTypeOf(&NotFoundError{}))
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package ibclient
+
+import (
+	"fmt"
+)
+
+func (objMgr *ObjectManager) CreateIpv6FixedAddressTemplate(name string, numberOfAddresses uint32, offset uint32, comment string, ea EA,
+	options []*Dhcpoption, useOptions bool, domainName string) (*Ipv6fixedaddresstemplate, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name field is required to create an IPv6 Fixed Address Template object")
+	}
+	template := NewIpv6FixedAddressTemplate("", name, numberOfAddresses, offset, comment, ea, options, useOptions, domainName)
+	ref, err := objMgr.connector.CreateObject(template)
+	if err != nil {
+		return nil, fmt.Errorf("error creating IPv6 Fixed Address Template object %s, err: %s", name, err)
+	}
+	template.Ref = ref
+	return template, nil
+}
+
+func (objMgr *ObjectManager) DeleteIpv6FixedAddressTemplate(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func (objMgr *ObjectManager) GetAllIpv6FixedAddressTemplate(queryParams *QueryParams) ([]Ipv6fixedaddresstemplate, error) {
+	var res []Ipv6fixedaddresstemplate
+	template := NewEmptyIpv6FixedAddressTemplate()
+	err := objMgr.connector.GetObject(template, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting IPv6 Fixed Address Template Record: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) GetIpv6FixedAddressTemplateByRef(ref string) (*Ipv6fixedaddresstemplate, error) {
+	template := NewEmptyIpv6FixedAddressTemplate()
+	err := objMgr.connector.GetObject(template, ref, NewQueryParams(false, nil), &template)
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (objMgr *ObjectManager) UpdateIpv6FixedAddressTemplate(ref string, name string, numberOfAddresses uint32, offset uint32, comment string, ea EA,
+	options []*Dhcpoption, useOptions bool, domainName string) (*Ipv6fixedaddresstemplate, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name field is required to update an IPv6 Fixed Address Template object")
+	}
+	template := NewIpv6FixedAddressTemplate(ref, name, numberOfAddresses, offset, comment, ea, options, useOptions, domainName)
+	newRef, err := objMgr.connector.UpdateObject(template, ref)
+	if err != nil {
+		return nil, fmt.Errorf("error updating IPv6 Fixed Address Template object %s, err: %s", name, err)
+	}
+	template, err = objMgr.GetIpv6FixedAddressTemplateByRef(newRef)
+	if err != nil {
+		return nil, fmt.Errorf("error getting updated IPv6 Fixed Address Template object %s, err: %s", name, err)
+	}
+	return template, nil
+}
+
+func NewIpv6FixedAddressTemplate(ref string, name string, numberOfAddresses uint32, offset uint32, comment string, ea EA,
+	options []*Dhcpoption, useOptions bool, domainName string) *Ipv6fixedaddresstemplate {
+	template := NewEmptyIpv6FixedAddressTemplate()
+	template.Ref = ref
+	template.Name = &name
+	template.NumberOfAddresses = &numberOfAddresses
+	template.Offset = &offset
+	template.Comment = &comment
+	template.Ea = ea
+	template.Options = options
+	template.UseOptions = &useOptions
+	if domainName != "" {
+		useDomainName := true
+		template.DomainName = &domainName
+		template.UseDomainName = &useDomainName
+	}
+	return template
+}
+
+func NewEmptyIpv6FixedAddressTemplate() *Ipv6fixedaddresstemplate {
+	template := &Ipv6fixedaddresstemplate{}
+	template.SetReturnFields(append(template.ReturnFields(), "extattrs", "number_of_addresses", "offset", "options",
+		"use_options", "domain_name", "use_domain_name"))
+	return template
+}
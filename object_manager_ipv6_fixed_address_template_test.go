@@ -0,0 +1,99 @@
+package ibclient
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: IPv6 Fixed Address Template", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "Ipv6FixedAddressTemplate1"
+	comment := "test IPv6 fixed address template"
+	numberOfAddresses := uint32(10)
+	offset := uint32(5)
+	ea := EA{"Site": "Hokkaido"}
+	options := []*Dhcpoption{
+		{
+			Name:      "domain-search-list",
+			Num:       24,
+			Value:     "example.com",
+			UseOption: true,
+		},
+	}
+	fakeRefReturn := fmt.Sprintf("ipv6fixedaddresstemplate/ZG5zLmlwdjZfZml4ZWRfYWRkcmVzc190ZW1wbGF0ZSQw:%s", name)
+
+	Describe("Create IPv6 Fixed Address Template", func() {
+		conn := &fakeConnector{
+			createObjectObj: NewIpv6FixedAddressTemplate("", name, numberOfAddresses, offset, comment, ea, options, true, "example.com"),
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected IPv6 Fixed Address Template Object to CreateObject", func() {
+			actualRecord, err := objMgr.CreateIpv6FixedAddressTemplate(name, numberOfAddresses, offset, comment, ea, options, true, "example.com")
+			Expect(err).To(BeNil())
+			Expect(actualRecord.Ref).To(Equal(fakeRefReturn))
+			Expect(*actualRecord.UseDomainName).To(BeTrue())
+		})
+
+		It("should fail to create an IPv6 Fixed Address Template object without a name", func() {
+			actualRecord, err := objMgr.CreateIpv6FixedAddressTemplate("", numberOfAddresses, offset, comment, ea, options, true, "")
+			Expect(actualRecord).To(BeNil())
+			Expect(err).To(MatchError("name field is required to create an IPv6 Fixed Address Template object"))
+		})
+	})
+
+	Describe("Get IPv6 Fixed Address Template", func() {
+		queryParams := NewQueryParams(false, map[string]string{"name": name})
+		result := []Ipv6fixedaddresstemplate{*NewIpv6FixedAddressTemplate(fakeRefReturn, name, numberOfAddresses, offset, comment, ea, options, true, "")}
+		conn := &fakeConnector{
+			getObjectObj:         NewEmptyIpv6FixedAddressTemplate(),
+			getObjectQueryParams: queryParams,
+			resultObject:         result,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the IPv6 Fixed Address Templates", func() {
+			actual, err := objMgr.GetAllIpv6FixedAddressTemplate(queryParams)
+			Expect(err).To(BeNil())
+			Expect(actual).To(Equal(result))
+		})
+	})
+
+	Describe("Update IPv6 Fixed Address Template", func() {
+		resultObj := NewIpv6FixedAddressTemplate(fakeRefReturn, name, 20, offset, "updated", ea, nil, false, "")
+		conn := &fakeConnector{
+			updateObjectObj:      NewIpv6FixedAddressTemplate(fakeRefReturn, name, 20, offset, "updated", ea, nil, false, ""),
+			updateObjectRef:      fakeRefReturn,
+			getObjectObj:         NewEmptyIpv6FixedAddressTemplate(),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         fakeRefReturn,
+			resultObject:         resultObj,
+			fakeRefReturn:        fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should update the IPv6 Fixed Address Template and return it", func() {
+			actual, err := objMgr.UpdateIpv6FixedAddressTemplate(fakeRefReturn, name, 20, offset, "updated", ea, nil, false, "")
+			Expect(err).To(BeNil())
+			Expect(actual).To(Equal(resultObj))
+		})
+	})
+
+	Describe("Delete IPv6 Fixed Address Template", func() {
+		conn := &fakeConnector{
+			deleteObjectRef: fakeRefReturn,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected ref to DeleteObject", func() {
+			actualRef, err := objMgr.DeleteIpv6FixedAddressTemplate(fakeRefReturn)
+			Expect(err).To(BeNil())
+			Expect(actualRef).To(Equal(fakeRefReturn))
+		})
+	})
+})
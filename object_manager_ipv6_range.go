@@ -0,0 +1,179 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+func (d IPv6Range) MarshalJSON() ([]byte, error) {
+	type Alias IPv6Range
+	aux := &struct {
+		Member *Dhcpmember `json:"member"`
+		*Alias
+	}{
+		Member: d.Member,
+		Alias:  (*Alias)(&d),
+	}
+	return json.Marshal(aux)
+}
+
+func (d *IPv6Range) UnmarshalJSON(data []byte) error {
+	type Alias IPv6Range
+	aux := &struct {
+		Member *Dhcpmember `json:"member"`
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	d.Member = aux.Member
+	return nil
+}
+
+func NewEmptyIpv6Range() *IPv6Range {
+	newRange := &IPv6Range{}
+	newRange.SetReturnFields(append(newRange.ReturnFields(), "extattrs", "name", "disable", "address_type", "ipv6_start_prefix",
+		"ipv6_end_prefix", "ipv6_prefix_bits", "cloud_info", "member", "server_association_type", "recycle_leases", "use_recycle_leases"))
+	return newRange
+}
+
+func NewIpv6Range(comment string,
+	name string,
+	network *string,
+	addressType string,
+	startAddr string,
+	endAddr string,
+	ipv6StartPrefix string,
+	ipv6EndPrefix string,
+	ipv6PrefixBits uint32,
+	eas EA,
+	disable bool,
+	member *Dhcpmember,
+	serverAssociationType string,
+	template string,
+) *IPv6Range {
+	newRange := NewEmptyIpv6Range()
+	newRange.Comment = &comment
+	newRange.Name = &name
+	newRange.Network = network
+	newRange.AddressType = &addressType
+	if startAddr != "" {
+		newRange.StartAddr = &startAddr
+		newRange.EndAddr = &endAddr
+	}
+	if ipv6StartPrefix != "" {
+		newRange.Ipv6StartPrefix = &ipv6StartPrefix
+		newRange.Ipv6EndPrefix = &ipv6EndPrefix
+		newRange.Ipv6PrefixBits = &ipv6PrefixBits
+	}
+	newRange.Ea = eas
+	newRange.Disable = &disable
+	newRange.Member = member
+	if serverAssociationType != "" {
+		newRange.ServerAssociationType = &serverAssociationType
+	}
+	newRange.Template = template
+	return newRange
+}
+
+// validateIpv6Range checks that the addresses and the prefixes required by
+// the address type of the range are given. The type defaults to ADDRESS.
+func validateIpv6Range(addressType string, startAddr string, endAddr string, ipv6StartPrefix string, ipv6EndPrefix string, ipv6PrefixBits uint32) (string, error) {
+	if addressType == "" {
+		addressType = "ADDRESS"
+	}
+	hasAddresses, hasPrefixes := startAddr != "" || endAddr != "", ipv6StartPrefix != "" || ipv6EndPrefix != ""
+	switch addressType {
+	case "ADDRESS":
+		if !hasAddresses || hasPrefixes {
+			return "", fmt.Errorf("start and end addresses, and no prefixes, are required for an IPv6 range of the ADDRESS type")
+		}
+	case "PREFIX":
+		if !hasPrefixes || hasAddresses {
+			return "", fmt.Errorf("start and end prefixes, and no addresses, are required for an IPv6 range of the PREFIX type")
+		}
+	case "BOTH":
+		if !hasAddresses || !hasPrefixes {
+			return "", fmt.Errorf("start and end addresses and prefixes are required for an IPv6 range of the BOTH type")
+		}
+	default:
+		return "", fmt.Errorf("invalid address type '%s', must be ADDRESS, PREFIX or BOTH", addressType)
+	}
+	for _, addr := range []string{startAddr, endAddr, ipv6StartPrefix, ipv6EndPrefix} {
+		if ip := net.ParseIP(addr); addr != "" && (ip == nil || ip.To4() != nil) {
+			return "", fmt.Errorf("'%s' is not a valid IPv6 address", addr)
+		}
+	}
+	if hasPrefixes && (ipv6PrefixBits == 0 || ipv6PrefixBits > 128) {
+		return "", fmt.Errorf("prefix length of the delegated prefixes must be between 1 and 128")
+	}
+	return addressType, nil
+}
+
+func (objMgr *ObjectManager) CreateIpv6NetworkRange(comment string, name string, network string, networkView string, addressType string, startAddr string, endAddr string, ipv6StartPrefix string, ipv6EndPrefix string, ipv6PrefixBits uint32, disable bool, eas EA, member *Dhcpmember, serverAssociationType string, template string) (*IPv6Range, error) {
+	addressType, err := validateIpv6Range(addressType, startAddr, endAddr, ipv6StartPrefix, ipv6EndPrefix, ipv6PrefixBits)
+	if err != nil {
+		return nil, err
+	}
+	if networkView == "" {
+		networkView = "default"
+	}
+	var networkPointer *string
+	if network != "" {
+		networkPointer = &network
+	}
+	newRange := NewIpv6Range(comment, name, networkPointer, addressType, startAddr, endAddr, ipv6StartPrefix, ipv6EndPrefix, ipv6PrefixBits, eas, disable, member, serverAssociationType, template)
+	newRange.NetworkView = &networkView
+	ref, err := objMgr.connector.CreateObject(newRange)
+	if err != nil {
+		return nil, err
+	}
+	newRange.Ref = ref
+	return newRange, nil
+}
+
+func (objMgr *ObjectManager) GetIpv6NetworkRangeByRef(ref string) (*IPv6Range, error) {
+	networkRange := NewEmptyIpv6Range()
+	err := objMgr.connector.GetObject(
+		networkRange, ref, NewQueryParams(false, nil), &networkRange)
+
+	return networkRange, err
+}
+
+func (objMgr *ObjectManager) GetIpv6NetworkRange(queryParams *QueryParams) ([]IPv6Range, error) {
+	var res []IPv6Range
+	networkRange := NewEmptyIpv6Range()
+	err := objMgr.connector.GetObject(
+		networkRange, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting DHCP IPv6 Range: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) UpdateIpv6NetworkRange(ref string, comment string, name string, network string, networkView string, addressType string, startAddr string, endAddr string, ipv6StartPrefix string, ipv6EndPrefix string, ipv6PrefixBits uint32, disable bool, eas EA, member *Dhcpmember, serverAssociationType string) (*IPv6Range, error) {
+	addressType, err := validateIpv6Range(addressType, startAddr, endAddr, ipv6StartPrefix, ipv6EndPrefix, ipv6PrefixBits)
+	if err != nil {
+		return nil, err
+	}
+	var networkPointer *string
+	if network != "" {
+		networkPointer = &network
+	}
+	networkRange := NewIpv6Range(comment, name, networkPointer, addressType, startAddr, endAddr, ipv6StartPrefix, ipv6EndPrefix, ipv6PrefixBits, eas, disable, member, serverAssociationType, "")
+	if networkView != "" {
+		networkRange.NetworkView = &networkView
+	}
+	reference, err := objMgr.connector.UpdateObject(networkRange, ref)
+	if err != nil {
+		return nil, err
+	}
+	return objMgr.GetIpv6NetworkRangeByRef(reference)
+}
+
+func (objMgr *ObjectManager) DeleteIpv6NetworkRange(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
@@ -0,0 +1,114 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func (d Ipv6rangetemplate) MarshalJSON() ([]byte, error) {
+	type Alias Ipv6rangetemplate
+	aux := &struct {
+		Member *Dhcpmember `json:"member"`
+		*Alias
+	}{
+		Member: d.Member,
+		Alias:  (*Alias)(&d),
+	}
+	return json.Marshal(aux)
+}
+
+func (d *Ipv6rangetemplate) UnmarshalJSON(data []byte) error {
+	type Alias Ipv6rangetemplate
+	aux := &struct {
+		Member *Dhcpmember `json:"member"`
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	d.Member = aux.Member
+	return nil
+}
+
+func (objMgr *ObjectManager) CreateIpv6RangeTemplate(name string, numberOfAddresses uint32, offset uint32, comment string,
+	serverAssociationType string, member *Dhcpmember, delegatedMember *Dhcpmember, cloudApiCompatible bool) (*Ipv6rangetemplate, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name field is required to create an IPv6 Range Template object")
+	}
+	rangeTemplate := NewIpv6RangeTemplate("", name, numberOfAddresses, offset, comment, serverAssociationType, member,
+		delegatedMember, cloudApiCompatible)
+	ref, err := objMgr.connector.CreateObject(rangeTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("error creating IPv6 Range Template object %s, err: %s", name, err)
+	}
+	rangeTemplate.Ref = ref
+	return rangeTemplate, nil
+}
+
+func (objMgr *ObjectManager) DeleteIpv6RangeTemplate(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func (objMgr *ObjectManager) GetAllIpv6RangeTemplate(queryParams *QueryParams) ([]Ipv6rangetemplate, error) {
+	var res []Ipv6rangetemplate
+	rangeTemplate := NewEmptyIpv6RangeTemplate()
+	err := objMgr.connector.GetObject(rangeTemplate, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting IPv6 Range Template Record: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) GetIpv6RangeTemplateByRef(ref string) (*Ipv6rangetemplate, error) {
+	rangeTemplate := NewEmptyIpv6RangeTemplate()
+	err := objMgr.connector.GetObject(rangeTemplate, ref, NewQueryParams(false, nil), &rangeTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return rangeTemplate, nil
+}
+
+func (objMgr *ObjectManager) UpdateIpv6RangeTemplate(ref string, name string, numberOfAddresses uint32, offset uint32, comment string,
+	serverAssociationType string, member *Dhcpmember, delegatedMember *Dhcpmember, cloudApiCompatible bool) (*Ipv6rangetemplate, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name field is required to update an IPv6 Range Template object")
+	}
+	rangeTemplate := NewIpv6RangeTemplate(ref, name, numberOfAddresses, offset, comment, serverAssociationType, member,
+		delegatedMember, cloudApiCompatible)
+	newRef, err := objMgr.connector.UpdateObject(rangeTemplate, ref)
+	if err != nil {
+		return nil, fmt.Errorf("error updating IPv6 Range Template object %s, err: %s", name, err)
+	}
+	rangeTemplate, err = objMgr.GetIpv6RangeTemplateByRef(newRef)
+	if err != nil {
+		return nil, fmt.Errorf("error getting updated IPv6 Range Template object %s, err: %s", name, err)
+	}
+	return rangeTemplate, nil
+}
+
+// NewIpv6RangeTemplate returns an IPv6 range template. The delegated
+// member, if any, is the member the prefixes of the ranges created from the
+// template are delegated from.
+func NewIpv6RangeTemplate(ref string, name string, numberOfAddresses uint32, offset uint32, comment string,
+	serverAssociationType string, member *Dhcpmember, delegatedMember *Dhcpmember, cloudApiCompatible bool) *Ipv6rangetemplate {
+	rangeTemplate := NewEmptyIpv6RangeTemplate()
+	rangeTemplate.Ref = ref
+	rangeTemplate.Name = &name
+	rangeTemplate.NumberOfAddresses = &numberOfAddresses
+	rangeTemplate.Offset = &offset
+	rangeTemplate.Comment = &comment
+	rangeTemplate.ServerAssociationType = serverAssociationType
+	rangeTemplate.Member = member
+	rangeTemplate.DelegatedMember = delegatedMember
+	rangeTemplate.CloudApiCompatible = &cloudApiCompatible
+	return rangeTemplate
+}
+
+func NewEmptyIpv6RangeTemplate() *Ipv6rangetemplate {
+	rangeTemplate := &Ipv6rangetemplate{}
+	rangeTemplate.SetReturnFields(append(rangeTemplate.ReturnFields(), "server_association_type", "member",
+		"delegated_member", "cloud_api_compatible", "recycle_leases", "use_recycle_leases"))
+	return rangeTemplate
+}
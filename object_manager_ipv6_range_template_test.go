@@ -0,0 +1,93 @@
+package ibclient
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: IPv6 Range Template", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "Ipv6RangeTemplate1"
+	comment := "test IPv6 range template"
+	numberOfAddresses := uint32(100)
+	offset := uint32(16)
+	member := &Dhcpmember{
+		Ipv6Addr: "2403:8600:80cf:e10c:3a00::1192",
+		Name:     "infoblox.localdomain",
+	}
+	fakeRefReturn := fmt.Sprintf("ipv6rangetemplate/ZG5zLmlwdjZfcmFuZ2VfdGVtcGxhdGUkMA:%s", name)
+
+	Describe("Create IPv6 Range Template", func() {
+		conn := &fakeConnector{
+			createObjectObj: NewIpv6RangeTemplate("", name, numberOfAddresses, offset, comment, "MEMBER", member, member, false),
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected IPv6 Range Template Object to CreateObject", func() {
+			actualRecord, err := objMgr.CreateIpv6RangeTemplate(name, numberOfAddresses, offset, comment, "MEMBER", member, member, false)
+			Expect(err).To(BeNil())
+			Expect(actualRecord).To(Equal(NewIpv6RangeTemplate(fakeRefReturn, name, numberOfAddresses, offset, comment, "MEMBER", member, member, false)))
+		})
+
+		It("should fail to create an IPv6 Range Template object without a name", func() {
+			actualRecord, err := objMgr.CreateIpv6RangeTemplate("", numberOfAddresses, offset, comment, "MEMBER", member, member, false)
+			Expect(actualRecord).To(BeNil())
+			Expect(err).To(MatchError("name field is required to create an IPv6 Range Template object"))
+		})
+	})
+
+	Describe("Get IPv6 Range Template", func() {
+		queryParams := NewQueryParams(false, map[string]string{"name": name})
+		result := []Ipv6rangetemplate{*NewIpv6RangeTemplate(fakeRefReturn, name, numberOfAddresses, offset, comment, "MEMBER", member, nil, false)}
+		conn := &fakeConnector{
+			getObjectObj:         NewEmptyIpv6RangeTemplate(),
+			getObjectQueryParams: queryParams,
+			resultObject:         result,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the IPv6 Range Templates", func() {
+			actual, err := objMgr.GetAllIpv6RangeTemplate(queryParams)
+			Expect(err).To(BeNil())
+			Expect(actual).To(Equal(result))
+		})
+	})
+
+	Describe("Update IPv6 Range Template", func() {
+		resultObj := NewIpv6RangeTemplate(fakeRefReturn, name, 200, offset, "updated", "NONE", nil, nil, true)
+		conn := &fakeConnector{
+			updateObjectObj:      NewIpv6RangeTemplate(fakeRefReturn, name, 200, offset, "updated", "NONE", nil, nil, true),
+			updateObjectRef:      fakeRefReturn,
+			getObjectObj:         NewEmptyIpv6RangeTemplate(),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         fakeRefReturn,
+			resultObject:         resultObj,
+			fakeRefReturn:        fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should update the IPv6 Range Template and return it", func() {
+			actual, err := objMgr.UpdateIpv6RangeTemplate(fakeRefReturn, name, 200, offset, "updated", "NONE", nil, nil, true)
+			Expect(err).To(BeNil())
+			Expect(actual).To(Equal(resultObj))
+		})
+	})
+
+	Describe("Delete IPv6 Range Template", func() {
+		conn := &fakeConnector{
+			deleteObjectRef: fakeRefReturn,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected ref to DeleteObject", func() {
+			actualRef, err := objMgr.DeleteIpv6RangeTemplate(fakeRefReturn)
+			Expect(err).To(BeNil())
+			Expect(actualRef).To(Equal(fakeRefReturn))
+		})
+	})
+})
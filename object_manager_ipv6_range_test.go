@@ -0,0 +1,158 @@
+package ibclient
+
+import (
+	"fmt"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: IPv6 Network Range", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	netviewName := "default"
+	network := "2001:db8:abcd:12::/64"
+	networkPointer := utils.StringPtr(network)
+	member := &Dhcpmember{
+		Ipv6Addr: "2403:8600:80cf:e10c:3a00::1192",
+		Name:     "infoblox.localdomain",
+	}
+	eas := EA{"Site": "Hokkaido"}
+
+	Describe("Create an IPv6 Network Range of addresses", func() {
+		startAddr := "2001:db8:abcd:12::10"
+		endAddr := "2001:db8:abcd:12::20"
+		fakeRefReturn := fmt.Sprintf("ipv6range/ZG5zLmRoY3BfcmFuZ2UkMjAwMTpkYjg:%s/%s/%s", startAddr, endAddr, netviewName)
+
+		objectForCreation := NewIpv6Range("", "range1", networkPointer, "ADDRESS", startAddr, endAddr, "", "", 0, eas, false, member, "MEMBER", "")
+		objectForCreation.NetworkView = &netviewName
+		objectAsResult := NewIpv6Range("", "range1", networkPointer, "ADDRESS", startAddr, endAddr, "", "", 0, eas, false, member, "MEMBER", "")
+		objectAsResult.NetworkView = &netviewName
+		objectAsResult.Ref = fakeRefReturn
+
+		conn := &fakeConnector{
+			createObjectObj: objectForCreation,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should default the address type to ADDRESS", func() {
+			actualRecord, err := objMgr.CreateIpv6NetworkRange("", "range1", network, "", "", startAddr, endAddr, "", "", 0, false, eas, member, "MEMBER", "")
+			Expect(err).To(BeNil())
+			Expect(actualRecord).To(Equal(objectAsResult))
+		})
+
+		It("should fail if prefixes are given for the ADDRESS type", func() {
+			_, err := objMgr.CreateIpv6NetworkRange("", "range1", network, "", "ADDRESS", startAddr, endAddr, "2001:db8:1000::", "2001:db8:1f00::", 56, false, eas, member, "MEMBER", "")
+			Expect(err).To(MatchError("start and end addresses, and no prefixes, are required for an IPv6 range of the ADDRESS type"))
+		})
+
+		It("should fail with IPv4 addresses", func() {
+			_, err := objMgr.CreateIpv6NetworkRange("", "range1", network, "", "ADDRESS", "10.0.0.10", "10.0.0.20", "", "", 0, false, eas, member, "MEMBER", "")
+			Expect(err).To(MatchError("'10.0.0.10' is not a valid IPv6 address"))
+		})
+	})
+
+	Describe("Create an IPv6 Network Range for prefix delegation", func() {
+		startPrefix := "2001:db8:1000::"
+		endPrefix := "2001:db8:1f00::"
+		prefixBits := uint32(56)
+		fakeRefReturn := "ipv6range/ZG5zLmRoY3BfcmFuZ2UkMjAwMTpkYjg6MTAwMDo:prefixes/default"
+
+		objectForCreation := NewIpv6Range("delegated prefixes", "prefixes", nil, "PREFIX", "", "", startPrefix, endPrefix, prefixBits, nil, false, member, "", "")
+		objectForCreation.NetworkView = &netviewName
+		objectAsResult := NewIpv6Range("delegated prefixes", "prefixes", nil, "PREFIX", "", "", startPrefix, endPrefix, prefixBits, nil, false, member, "", "")
+		objectAsResult.NetworkView = &netviewName
+		objectAsResult.Ref = fakeRefReturn
+
+		conn := &fakeConnector{
+			createObjectObj: objectForCreation,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass the prefixes to CreateObject", func() {
+			actualRecord, err := objMgr.CreateIpv6NetworkRange("delegated prefixes", "prefixes", "", netviewName, "PREFIX", "", "", startPrefix, endPrefix, prefixBits, false, nil, member, "", "")
+			Expect(err).To(BeNil())
+			Expect(actualRecord).To(Equal(objectAsResult))
+		})
+
+		It("should fail without the prefix length", func() {
+			_, err := objMgr.CreateIpv6NetworkRange("", "prefixes", "", netviewName, "PREFIX", "", "", startPrefix, endPrefix, 0, false, nil, member, "", "")
+			Expect(err).To(MatchError("prefix length of the delegated prefixes must be between 1 and 128"))
+		})
+
+		It("should fail with an unknown address type", func() {
+			_, err := objMgr.CreateIpv6NetworkRange("", "prefixes", "", netviewName, "PREFIXES", "", "", startPrefix, endPrefix, prefixBits, false, nil, member, "", "")
+			Expect(err).To(MatchError("invalid address type 'PREFIXES', must be ADDRESS, PREFIX or BOTH"))
+		})
+	})
+
+	Describe("Get IPv6 Network Range", func() {
+		queryParams := NewQueryParams(false, map[string]string{"network": network})
+		result := []IPv6Range{*NewIpv6Range("", "range1", networkPointer, "ADDRESS", "2001:db8:abcd:12::10", "2001:db8:abcd:12::20", "", "", 0, eas, false, member, "MEMBER", "")}
+		conn := &fakeConnector{
+			getObjectObj:         NewEmptyIpv6Range(),
+			getObjectQueryParams: queryParams,
+			resultObject:         result,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the ranges of the network", func() {
+			actual, err := objMgr.GetIpv6NetworkRange(queryParams)
+			Expect(err).To(BeNil())
+			Expect(actual).To(Equal(result))
+		})
+	})
+
+	Describe("Update IPv6 Network Range", func() {
+		ref := "ipv6range/ZG5zLmRoY3BfcmFuZ2UkMjAwMTpkYjg:2001%3Adb8%3Aabcd%3A12%3A%3A10/2001%3Adb8%3Aabcd%3A12%3A%3A20/default"
+		startAddr := "2001:db8:abcd:12::10"
+		endAddr := "2001:db8:abcd:12::30"
+		startPrefix := "2001:db8:1000::"
+		endPrefix := "2001:db8:1f00::"
+		prefixBits := uint32(56)
+
+		updateObj := NewIpv6Range("both", "range1", networkPointer, "BOTH", startAddr, endAddr, startPrefix, endPrefix, prefixBits, eas, false, member, "MEMBER", "")
+		resultObj := NewIpv6Range("both", "range1", networkPointer, "BOTH", startAddr, endAddr, startPrefix, endPrefix, prefixBits, eas, false, member, "MEMBER", "")
+		resultObj.Ref = ref
+
+		conn := &fakeConnector{
+			updateObjectObj:      updateObj,
+			updateObjectRef:      ref,
+			getObjectObj:         NewEmptyIpv6Range(),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         ref,
+			resultObject:         resultObj,
+			fakeRefReturn:        ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should update the range and return it", func() {
+			actual, err := objMgr.UpdateIpv6NetworkRange(ref, "both", "range1", network, "", "BOTH", startAddr, endAddr, startPrefix, endPrefix, prefixBits, false, eas, member, "MEMBER")
+			Expect(err).To(BeNil())
+			Expect(actual).To(Equal(resultObj))
+		})
+
+		It("should fail without the prefixes for the BOTH type", func() {
+			_, err := objMgr.UpdateIpv6NetworkRange(ref, "both", "range1", network, "", "BOTH", startAddr, endAddr, "", "", 0, false, eas, member, "MEMBER")
+			Expect(err).To(MatchError("start and end addresses and prefixes are required for an IPv6 range of the BOTH type"))
+		})
+	})
+
+	Describe("Delete IPv6 Network Range", func() {
+		ref := "ipv6range/ZG5zLmRoY3BfcmFuZ2UkMjAwMTpkYjg:2001%3Adb8%3Aabcd%3A12%3A%3A10/2001%3Adb8%3Aabcd%3A12%3A%3A20/default"
+		conn := &fakeConnector{
+			deleteObjectRef: ref,
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected ref to DeleteObject", func() {
+			actualRef, err := objMgr.DeleteIpv6NetworkRange(ref)
+			Expect(err).To(BeNil())
+			Expect(actualRef).To(Equal(ref))
+		})
+	})
+})
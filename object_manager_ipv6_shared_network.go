@@ -0,0 +1,163 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+func (d *IPv6SharedNetwork) MarshalJSON() ([]byte, error) {
+	type Alias IPv6SharedNetwork
+	aux := &struct {
+		Networks []interface{} `json:"networks"`
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+
+	for _, network := range d.Networks {
+		if network != nil && network.Ref != "" {
+			if _, _, err := net.ParseCIDR(network.Ref); err == nil {
+				networkView := "default"
+				if network.NetworkView != nil && *network.NetworkView != "" {
+					networkView = *network.NetworkView
+				}
+				aux.Networks = append(aux.Networks, map[string]interface{}{
+					"_ref": map[string]string{
+						"network":      network.Ref,
+						"network_view": networkView,
+					},
+				})
+			} else {
+				aux.Networks = append(aux.Networks, map[string]string{"_ref": network.Ref})
+			}
+		}
+	}
+	return json.Marshal(aux)
+}
+
+func (d *IPv6SharedNetwork) UnmarshalJSON(data []byte) error {
+	type Alias IPv6SharedNetwork
+	aux := &struct {
+		*Alias
+		Networks []map[string]interface{} `json:"networks"`
+	}{
+		Alias: (*Alias)(d),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	d.Networks = make([]*Ipv6Network, len(aux.Networks))
+	for i, network := range aux.Networks {
+		if ref, ok := network["_ref"].(string); ok {
+			d.Networks[i] = &Ipv6Network{Ref: ref}
+		} else {
+			return fmt.Errorf("invalid network reference format")
+		}
+	}
+	return nil
+}
+
+// newIpv6SharedNetworkMembers returns the networks of a shared network,
+// given by their references or their IPv6 CIDRs.
+func newIpv6SharedNetworkMembers(networks []string, networkView string) ([]*Ipv6Network, error) {
+	var ipv6Networks []*Ipv6Network
+	for _, nw := range networks {
+		if nw == "" {
+			return nil, fmt.Errorf("networks cannot be empty")
+		}
+		if ip, _, err := net.ParseCIDR(nw); err == nil && ip.To4() != nil {
+			return nil, fmt.Errorf("network '%s' must be an IPv6 network", nw)
+		}
+		network := &Ipv6Network{Ref: nw}
+		if networkView != "" {
+			view := networkView
+			network.NetworkView = &view
+		}
+		ipv6Networks = append(ipv6Networks, network)
+	}
+	return ipv6Networks, nil
+}
+
+func (objMgr *ObjectManager) CreateIpv6SharedNetwork(name string, networks []string, networkView string, eas EA, comment string, disable bool, useOptions bool, options []*Dhcpoption) (*IPv6SharedNetwork, error) {
+	if name == "" || len(networks) == 0 {
+		return nil, fmt.Errorf("name and networks are required to create a shared network")
+	}
+	ipv6Networks, err := newIpv6SharedNetworkMembers(networks, networkView)
+	if err != nil {
+		return nil, err
+	}
+	if networkView == "" {
+		networkView = "default"
+	}
+
+	sharedNetwork := NewIpv6SharedNetwork("", name, ipv6Networks, eas, comment, disable, useOptions, options)
+	sharedNetwork.NetworkView = networkView
+	ref, err := objMgr.connector.CreateObject(sharedNetwork)
+	if err != nil {
+		return nil, err
+	}
+	sharedNetwork.Ref = ref
+	return sharedNetwork, nil
+}
+
+func NewEmptyIpv6SharedNetwork() *IPv6SharedNetwork {
+	sharedNetwork := &IPv6SharedNetwork{}
+	sharedNetwork.SetReturnFields(append(sharedNetwork.ReturnFields(), "extattrs", "disable", "use_options", "options"))
+	return sharedNetwork
+}
+
+func (objMgr *ObjectManager) GetIpv6SharedNetworkByRef(ref string) (*IPv6SharedNetwork, error) {
+	sharedNetwork := NewEmptyIpv6SharedNetwork()
+	err := objMgr.connector.GetObject(
+		sharedNetwork, ref, NewQueryParams(false, nil), &sharedNetwork)
+
+	return sharedNetwork, err
+}
+
+func (objMgr *ObjectManager) GetAllIpv6SharedNetwork(queryParams *QueryParams) ([]IPv6SharedNetwork, error) {
+	var res []IPv6SharedNetwork
+	sharedNetwork := NewEmptyIpv6SharedNetwork()
+	err := objMgr.connector.GetObject(
+		sharedNetwork, "", queryParams, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) UpdateIpv6SharedNetwork(ref string, name string, networks []string, networkView string, comment string, eas EA, disable bool, useOptions bool, options []*Dhcpoption) (*IPv6SharedNetwork, error) {
+	if name == "" || len(networks) == 0 {
+		return nil, fmt.Errorf("name and networks are required for a shared network")
+	}
+	ipv6Networks, err := newIpv6SharedNetworkMembers(networks, networkView)
+	if err != nil {
+		return nil, err
+	}
+	sharedNetwork := NewIpv6SharedNetwork(ref, name, ipv6Networks, eas, comment, disable, useOptions, options)
+	updatedRef, err := objMgr.connector.UpdateObject(sharedNetwork, ref)
+	if err != nil {
+		return nil, err
+	}
+	sharedNetwork.Ref = updatedRef
+	return sharedNetwork, nil
+}
+
+func (objMgr *ObjectManager) DeleteIpv6SharedNetwork(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func NewIpv6SharedNetwork(ref string, name string, networks []*Ipv6Network, eas EA, comment string, disable bool, useOptions bool, options []*Dhcpoption) *IPv6SharedNetwork {
+	sharedNetwork := NewEmptyIpv6SharedNetwork()
+	sharedNetwork.Ref = ref
+	sharedNetwork.Name = &name
+	sharedNetwork.Networks = networks
+	sharedNetwork.Ea = eas
+	sharedNetwork.Comment = &comment
+	sharedNetwork.Disable = &disable
+	sharedNetwork.UseOptions = &useOptions
+	if options != nil {
+		sharedNetwork.Options = options
+	}
+	return sharedNetwork
+}
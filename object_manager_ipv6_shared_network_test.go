@@ -0,0 +1,112 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: IPv6 SharedNetwork", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	networkView := "default"
+	name := "shared-network6"
+	comment := "Test creation"
+	ea := EA{"Site": "Hokkaido"}
+	options := []*Dhcpoption{
+		{
+			Name:  "domain-name",
+			Value: "aa.mm.ee",
+		},
+	}
+	fakeRefReturn := fmt.Sprintf("ipv6sharednetwork/ZG5zLmlwdjZfc2hhcmVkX25ldHdvcmskMA:%s/default", name)
+
+	Describe("Create IPv6 SharedNetwork", func() {
+		nw := []string{"2001:db8:abcd:12::/64", "ipv6network/ZG5zLm5ldHdvcmskMjAwMTpkYjg6YWJjZDoxMzo6LzY0LzA:2001%3Adb8%3Aabcd%3A13%3A%3A/64/default"}
+		networks, _ := newIpv6SharedNetworkMembers(nw, networkView)
+		conn := &fakeConnector{
+			createObjectObj: NewIpv6SharedNetwork("", name, networks, ea, comment, false, true, options),
+			fakeRefReturn:   fakeRefReturn,
+		}
+		conn.createObjectObj.(*IPv6SharedNetwork).NetworkView = networkView
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected IPv6 SharedNetwork Object to CreateObject", func() {
+			actualRecord, err := objMgr.CreateIpv6SharedNetwork(name, nw, networkView, ea, comment, false, true, options)
+			Expect(err).To(BeNil())
+			Expect(actualRecord.Ref).To(Equal(fakeRefReturn))
+		})
+
+		It("should refer to networks by CIDR or by reference", func() {
+			data, err := json.Marshal(conn.createObjectObj)
+			Expect(err).To(BeNil())
+			var body map[string]interface{}
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			Expect(body["networks"]).To(Equal([]interface{}{
+				map[string]interface{}{"_ref": map[string]interface{}{"network": nw[0], "network_view": networkView}},
+				map[string]interface{}{"_ref": nw[1]},
+			}))
+		})
+
+		It("should fail without a name or networks", func() {
+			actualRecord, err := objMgr.CreateIpv6SharedNetwork("", nil, networkView, ea, comment, false, false, nil)
+			Expect(actualRecord).To(BeNil())
+			Expect(err).To(MatchError("name and networks are required to create a shared network"))
+		})
+
+		It("should fail with an IPv4 network", func() {
+			_, err := objMgr.CreateIpv6SharedNetwork(name, []string{"12.12.23.0/24"}, networkView, ea, comment, false, false, nil)
+			Expect(err).To(MatchError("network '12.12.23.0/24' must be an IPv6 network"))
+		})
+	})
+
+	Describe("Get IPv6 SharedNetwork", func() {
+		queryParams := NewQueryParams(false, map[string]string{"name": name})
+		result := []IPv6SharedNetwork{*NewIpv6SharedNetwork(fakeRefReturn, name, []*Ipv6Network{{Ref: "ipv6network/ZG5zLm5ldHdvcmsk:2001%3Adb8%3Aabcd%3A12%3A%3A/64/default"}}, ea, comment, false, true, options)}
+		conn := &fakeConnector{
+			getObjectObj:         NewEmptyIpv6SharedNetwork(),
+			getObjectQueryParams: queryParams,
+			resultObject:         result,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the shared networks", func() {
+			actual, err := objMgr.GetAllIpv6SharedNetwork(queryParams)
+			Expect(err).To(BeNil())
+			Expect(actual).To(Equal(result))
+		})
+	})
+
+	Describe("Update IPv6 SharedNetwork", func() {
+		nw := []string{"ipv6network/ZG5zLm5ldHdvcmsk:2001%3Adb8%3Aabcd%3A12%3A%3A/64/default"}
+		networks, _ := newIpv6SharedNetworkMembers(nw, "")
+		conn := &fakeConnector{
+			updateObjectObj: NewIpv6SharedNetwork(fakeRefReturn, name, networks, ea, "updated", true, false, nil),
+			updateObjectRef: fakeRefReturn,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected IPv6 SharedNetwork Object to UpdateObject", func() {
+			actualRecord, err := objMgr.UpdateIpv6SharedNetwork(fakeRefReturn, name, nw, "", "updated", ea, true, false, nil)
+			Expect(err).To(BeNil())
+			Expect(actualRecord).To(Equal(conn.updateObjectObj))
+		})
+	})
+
+	Describe("Delete IPv6 SharedNetwork", func() {
+		conn := &fakeConnector{
+			deleteObjectRef: fakeRefReturn,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected ref to DeleteObject", func() {
+			actualRef, err := objMgr.DeleteIpv6SharedNetwork(fakeRefReturn)
+			Expect(err).To(BeNil())
+			Expect(actualRef).To(Equal(fakeRefReturn))
+		})
+	})
+})
@@ -0,0 +1,120 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+func validateNaptrRecArgs(name string, order uint32, preference uint32, flags string, regexp string, replacement string) error {
+	if name == "" {
+		return fmt.Errorf("'name' must not be empty")
+	}
+	if order > 65535 {
+		return fmt.Errorf("'order' must be in the range from 0 to 65535 inclusively")
+	}
+	if preference > 65535 {
+		return fmt.Errorf("'preference' must be in the range from 0 to 65535 inclusively")
+	}
+	for _, f := range strings.ToUpper(flags) {
+		if !strings.ContainsRune("USAP", f) {
+			return fmt.Errorf("'flags' value '%s' is invalid, only 'U', 'S', 'A' and 'P' are allowed", flags)
+		}
+	}
+	if replacement == "" {
+		return fmt.Errorf("'replacement' must not be empty, use '.' if there is no replacement")
+	}
+	if regexp != "" && replacement != "." {
+		return fmt.Errorf("'regexp' and 'replacement' are mutually exclusive, 'replacement' must be '.' when 'regexp' is set")
+	}
+	if strings.EqualFold(flags, "U") && regexp == "" {
+		return fmt.Errorf("'regexp' must not be empty when 'flags' is 'U'")
+	}
+	if replacement != "." {
+		if err := ValidateDomainName(replacement); err != nil {
+			return fmt.Errorf("validation of 'replacement' value failed: %s", err)
+		}
+	}
+	return nil
+}
+
+func NewEmptyRecordNAPTR() *RecordNaptr {
+	recordNAPTR := &RecordNaptr{}
+	recordNAPTR.SetReturnFields(append(recordNAPTR.ReturnFields(), "flags", "comment", "disable", "extattrs", "ttl", "use_ttl", "zone"))
+	return recordNAPTR
+}
+
+func NewRecordNAPTR(name string, dnsView string, order uint32, preference uint32, flags string, services string, regexp string, replacement string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) *RecordNaptr {
+	recordNAPTR := NewEmptyRecordNAPTR()
+	recordNAPTR.Name = &name
+	recordNAPTR.View = dnsView
+	recordNAPTR.Order = &order
+	recordNAPTR.Preference = &preference
+	recordNAPTR.Flags = &flags
+	recordNAPTR.Services = &services
+	recordNAPTR.Regexp = &regexp
+	recordNAPTR.Replacement = &replacement
+	recordNAPTR.Comment = &comment
+	recordNAPTR.Disable = &disable
+	recordNAPTR.Ea = ea
+	recordNAPTR.Ttl = &ttl
+	recordNAPTR.UseTtl = &useTtl
+	return recordNAPTR
+}
+
+// CreateNAPTRRecord creates a NAPTR-record.
+//
+// Also, it preforms validation of input parameters: name, order, preference, flags, regexp and replacement.
+func (objMgr *ObjectManager) CreateNAPTRRecord(name string, dnsView string, order uint32, preference uint32, flags string, services string, regexp string, replacement string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordNaptr, error) {
+	if err := validateNaptrRecArgs(name, order, preference, flags, regexp, replacement); err != nil {
+		return nil, err
+	}
+	if dnsView == "" {
+		dnsView = "default"
+	}
+	recordNAPTR := NewRecordNAPTR(name, dnsView, order, preference, flags, services, regexp, replacement, comment, disable, ea, ttl, useTtl)
+	ref, err := objMgr.connector.CreateObject(recordNAPTR)
+	if err != nil {
+		return nil, err
+	}
+	recordNAPTR.Ref = ref
+	return recordNAPTR, nil
+}
+
+func (objMgr *ObjectManager) GetNAPTRRecordByRef(ref string) (*RecordNaptr, error) {
+	recordNAPTR := NewEmptyRecordNAPTR()
+	err := objMgr.connector.GetObject(recordNAPTR, ref, NewQueryParams(false, nil), &recordNAPTR)
+	if err != nil {
+		return nil, err
+	}
+	return recordNAPTR, nil
+}
+
+func (objMgr *ObjectManager) GetAllNAPTRRecord(queryParams *QueryParams) ([]RecordNaptr, error) {
+	var res []RecordNaptr
+	recordNAPTR := NewEmptyRecordNAPTR()
+	err := objMgr.connector.GetObject(recordNAPTR, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting NAPTR Record: %s", err)
+	}
+	return res, nil
+}
+
+// UpdateNAPTRRecord updates the NAPTR-record.
+//
+// Also, it preforms validation of input parameters: name, order, preference, flags, regexp and replacement.
+func (objMgr *ObjectManager) UpdateNAPTRRecord(ref string, name string, order uint32, preference uint32, flags string, services string, regexp string, replacement string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordNaptr, error) {
+	if err := validateNaptrRecArgs(name, order, preference, flags, regexp, replacement); err != nil {
+		return nil, err
+	}
+	recordNAPTR := NewRecordNAPTR(name, "", order, preference, flags, services, regexp, replacement, comment, disable, ea, ttl, useTtl)
+	updatedRef, err := objMgr.connector.UpdateObject(recordNAPTR, ref)
+	if err != nil {
+		return nil, err
+	}
+	recordNAPTR.Ref = updatedRef
+	return recordNAPTR, nil
+}
+
+func (objMgr *ObjectManager) DeleteNAPTRRecord(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
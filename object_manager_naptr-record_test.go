@@ -0,0 +1,49 @@
+package ibclient
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Record NAPTR", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "example.com"
+	dnsView := "default"
+	fakeRefReturn := "record:naptr/ZG5zLmJpbmRfbmFwdHIkLl9kZWZhdWx0LmNvbS5leGFtcGxl:example.com/default"
+
+	Describe("Create NAPTR Record", func() {
+		conn := &fakeConnector{
+			createObjectObj: NewRecordNAPTR(name, dnsView, 100, 10, "U", "E2U+sip", "!^.*$!sip:info@example.com!", ".", "", false, nil, 0, false),
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected NAPTR record to CreateObject", func() {
+			recordNAPTR, err := objMgr.CreateNAPTRRecord(name, dnsView, 100, 10, "U", "E2U+sip", "!^.*$!sip:info@example.com!", ".", "", false, nil, 0, false)
+			Expect(err).To(BeNil())
+			Expect(recordNAPTR.Ref).To(Equal(fakeRefReturn))
+		})
+	})
+
+	Describe("Negative cases", func() {
+		objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+
+		It("should reject an out of range order", func() {
+			_, err := objMgr.CreateNAPTRRecord(name, dnsView, 70000, 10, "S", "SIP+D2U", "", "_sip._udp.example.com", "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("'order' must be in the range from 0 to 65535 inclusively")))
+		})
+
+		It("should reject unknown flags", func() {
+			_, err := objMgr.CreateNAPTRRecord(name, dnsView, 100, 10, "X", "SIP+D2U", "", "_sip._udp.example.com", "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("'flags' value 'X' is invalid, only 'U', 'S', 'A' and 'P' are allowed")))
+		})
+
+		It("should reject both a regexp and a replacement", func() {
+			_, err := objMgr.CreateNAPTRRecord(name, dnsView, 100, 10, "U", "E2U+sip", "!^.*$!sip:info@example.com!", "sip.example.com", "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("'regexp' and 'replacement' are mutually exclusive, 'replacement' must be '.' when 'regexp' is set")))
+		})
+	})
+})
@@ -0,0 +1,453 @@
+package ibclient
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// contiguousIpsMaxAttempts is the number of times the next available
+// addresses of a source are requested when looking for contiguous ones.
+const contiguousIpsMaxAttempts = 16
+
+// nextAvailableCreateAttempts is the number of times the addresses or the
+// network looked up in a source are created, other clients allocating
+// them in between.
+const nextAvailableCreateAttempts = 3
+
+// NextAvailableIpRequest describes IP addresses to allocate from the first
+// of several networks or address ranges which has enough of them.
+type NextAvailableIpRequest struct {
+	NetworkView string
+	IsIPv6      bool
+	Sources     []string          // network CIDRs or address ranges, such as 10.0.0.10-10.0.0.50, tried in order
+	SourceEAs   map[string]string // EA search fields of the networks tried after the sources, such as {"*Site": "Tokyo"}
+	Exclude     []string          // addresses which must not be allocated
+	Count       int               // number of addresses, 1 by default
+	Contiguous  bool
+}
+
+// NextAvailableIpResult holds the addresses allocated by
+// AllocateNextAvailableIps, and the network or address range they were
+// allocated from.
+type NextAvailableIpResult struct {
+	Source     string
+	Ips        []string
+	HostRecord *HostRecord // the host record the addresses are reserved by
+}
+
+// NextAvailableNetworkRequest describes a network to allocate from the first
+// of several network containers which has room for it.
+type NextAvailableNetworkRequest struct {
+	NetworkView  string
+	IsIPv6       bool
+	PrefixLen    uint
+	Containers   []string          // network container CIDRs, tried in order
+	ContainerEAs map[string]string // EA search fields of the containers tried after the ones above
+	Exclude      []string          // networks which must not be allocated
+}
+
+// NextAvailableNetworkResult holds the network allocated by
+// AllocateNextAvailableNetwork, and the network container it was allocated
+// from.
+type NextAvailableNetworkResult struct {
+	Source  string
+	Network *Network
+}
+
+// nextAvailableSource is a network, network container or address range
+// addresses or networks are allocated from.
+type nextAvailableSource struct {
+	name string
+	ref  string
+}
+
+// nextAvailableSources keeps the sources in the order they were added,
+// each one once.
+type nextAvailableSources struct {
+	list []nextAvailableSource
+	seen map[string]bool
+}
+
+func (s *nextAvailableSources) add(name string, ref string) {
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	if !s.seen[ref] {
+		s.seen[ref] = true
+		s.list = append(s.list, nextAvailableSource{name: name, ref: ref})
+	}
+}
+
+func isIPFamily(ip net.IP, isIPv6 bool) bool {
+	return ip != nil && (ip.To4() == nil) == isIPv6
+}
+
+func ipFamilyName(isIPv6 bool) string {
+	if isIPv6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+// eaSearchFields returns the search fields of the objects of the network
+// view matching the EA search fields.
+func eaSearchFields(netview string, eaSearch map[string]string) map[string]string {
+	sf := map[string]string{"network_view": netview}
+	for k, v := range eaSearch {
+		sf[k] = v
+	}
+	return sf
+}
+
+// getAddressRange returns the reference of the address range of the network
+// view going from the first address to the last one.
+func (objMgr *ObjectManager) getAddressRange(netview string, first string, last string, isIPv6 bool) (string, error) {
+	for _, addr := range []string{first, last} {
+		if !isIPFamily(net.ParseIP(addr), isIPv6) {
+			return "", fmt.Errorf("'%s' is not a valid %s address", addr, ipFamilyName(isIPv6))
+		}
+	}
+	qp := NewQueryParams(false, map[string]string{
+		"start_addr":   first,
+		"end_addr":     last,
+		"network_view": netview,
+	})
+	ref := ""
+	if isIPv6 {
+		var res []IPv6Range
+		if err := objMgr.connector.GetObject(NewEmptyIpv6Range(), "", qp, &res); err != nil {
+			return "", err
+		}
+		if len(res) > 0 {
+			ref = res[0].Ref
+		}
+	} else {
+		var res []Range
+		if err := objMgr.connector.GetObject(NewEmptyRange(), "", qp, &res); err != nil {
+			return "", err
+		}
+		if len(res) > 0 {
+			ref = res[0].Ref
+		}
+	}
+	if ref == "" {
+		return "", NewNotFoundError(fmt.Sprintf("address range %s-%s not found in network view '%s'", first, last, netview))
+	}
+	return ref, nil
+}
+
+// ipAllocationSources returns the networks and address ranges of the
+// request, in the order they are tried.
+func (objMgr *ObjectManager) ipAllocationSources(netview string, req NextAvailableIpRequest) ([]nextAvailableSource, error) {
+	var sources nextAvailableSources
+	for _, src := range req.Sources {
+		if first, last, isRange := strings.Cut(src, "-"); isRange {
+			ref, err := objMgr.getAddressRange(netview, first, last, req.IsIPv6)
+			if err != nil {
+				return nil, err
+			}
+			sources.add(src, ref)
+			continue
+		}
+		if ip, _, err := net.ParseCIDR(src); err != nil || !isIPFamily(ip, req.IsIPv6) {
+			return nil, fmt.Errorf("'%s' is neither an %s network nor an address range", src, ipFamilyName(req.IsIPv6))
+		}
+		network, err := objMgr.GetNetwork(netview, src, req.IsIPv6, nil)
+		if err != nil {
+			return nil, err
+		}
+		sources.add(src, network.Ref)
+	}
+	if len(req.SourceEAs) > 0 {
+		var networks []Network
+		err := objMgr.getObjects(NewNetwork(netview, "", req.IsIPv6, "", nil),
+			NewQueryParams(false, eaSearchFields(netview, req.SourceEAs)), &networks)
+		if err != nil {
+			return nil, err
+		}
+		for _, network := range networks {
+			sources.add(network.Cidr, network.Ref)
+		}
+	}
+	return sources.list, nil
+}
+
+// nextIP returns the address following the given one.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// lastIpRun returns the index of the first address of the last run of
+// consecutive addresses of the sorted list.
+func lastIpRun(ips []net.IP) int {
+	start := 0
+	for i := 1; i < len(ips); i++ {
+		if !ips[i].Equal(nextIP(ips[i-1])) {
+			start = i
+		}
+	}
+	return start
+}
+
+// nextAvailableIps returns the next available addresses of the network or
+// address range. Contiguous addresses are looked for by excluding the
+// available addresses which cannot start enough consecutive ones, until
+// the addresses returned by WAPI are consecutive.
+func nextAvailableIps(caller IBFunctionCaller, ref string, count int, contiguous bool, exclude []string) ([]string, error) {
+	attempts := 1
+	if contiguous {
+		attempts = contiguousIpsMaxAttempts
+	}
+	for ; attempts > 0; attempts-- {
+		args := map[string]interface{}{"num": count}
+		if len(exclude) > 0 {
+			args["exclude"] = exclude
+		}
+		var res struct {
+			Ips []string `json:"ips"`
+		}
+		if err := caller.CallFunction(ref, "next_available_ip", args, &res); err != nil {
+			return nil, err
+		}
+		if len(res.Ips) < count {
+			return nil, fmt.Errorf("only %d available IP addresses", len(res.Ips))
+		}
+		if !contiguous {
+			return res.Ips, nil
+		}
+
+		ips := make([]net.IP, len(res.Ips))
+		for i, addr := range res.Ips {
+			if ips[i] = net.ParseIP(addr); ips[i] == nil {
+				return nil, fmt.Errorf("invalid IP address '%s' returned", addr)
+			}
+			ips[i] = ips[i].To16()
+		}
+		sort.Slice(ips, func(i, j int) bool { return bytes.Compare(ips[i], ips[j]) < 0 })
+		start := lastIpRun(ips)
+		if start == 0 {
+			for i, ip := range ips {
+				res.Ips[i] = ip.String()
+			}
+			return res.Ips, nil
+		}
+		for _, ip := range ips[:start] {
+			exclude = append(exclude, ip.String())
+		}
+	}
+	return nil, fmt.Errorf("no %d contiguous available IP addresses found", count)
+}
+
+// hostRecordIps returns the addresses of the host record.
+func hostRecordIps(host *HostRecord) []string {
+	var ips []string
+	for _, addr := range host.Ipv4Addrs {
+		ips = append(ips, strValue(addr.Ipv4Addr))
+	}
+	for _, addr := range host.Ipv6Addrs {
+		ips = append(ips, strValue(addr.Ipv6Addr))
+	}
+	return ips
+}
+
+// reserveNextAvailableIps creates the host record reserving the next
+// available addresses of the source. WAPI allocates them while creating
+// the record, unless they must be contiguous or some addresses are
+// excluded: they are then looked up first, and looked up again if another
+// client takes them before the record is created.
+func (objMgr *ObjectManager) reserveNextAvailableIps(
+	netview string, src nextAvailableSource, req NextAvailableIpRequest, count int,
+	name string, comment string, eas EA) (*HostRecord, error) {
+
+	newHostRecord := func(ips []string) *HostRecord {
+		var ipv4Addrs []HostRecordIpv4Addr
+		var ipv6Addrs []HostRecordIpv6Addr
+		for _, ip := range ips {
+			if req.IsIPv6 {
+				ipv6Addrs = append(ipv6Addrs, *NewHostRecordIpv6Addr(ip, "", false, ""))
+			} else {
+				ipv4Addrs = append(ipv4Addrs, *NewHostRecordIpv4Addr(ip, "", false, ""))
+			}
+		}
+		return NewHostRecord(
+			netview, name, "", "", ipv4Addrs, ipv6Addrs, eas, false, "", "", "", false, 0, comment, nil, false)
+	}
+
+	if !req.Contiguous && len(req.Exclude) == 0 {
+		ips := make([]string, count)
+		for i := range ips {
+			ips[i] = fmt.Sprintf("func:nextavailableip:%s", src.ref)
+		}
+		ref, err := objMgr.connector.CreateObject(newHostRecord(ips))
+		if err != nil {
+			return nil, err
+		}
+		return objMgr.GetHostRecordByRef(ref)
+	}
+
+	caller, ok := objMgr.connector.(IBFunctionCaller)
+	if !ok {
+		return nil, fmt.Errorf("the connector does not support WAPI function calls")
+	}
+	var err error
+	for attempt := 0; attempt < nextAvailableCreateAttempts; attempt++ {
+		var ips []string
+		ips, err = nextAvailableIps(caller, src.ref, count, req.Contiguous, append([]string(nil), req.Exclude...))
+		if err != nil {
+			return nil, err
+		}
+		var ref string
+		if ref, err = objMgr.connector.CreateObject(newHostRecord(ips)); err == nil {
+			return objMgr.GetHostRecordByRef(ref)
+		}
+		err = fmt.Errorf("failed reserving IP addresses %s: %s", strings.Join(ips, ", "), err)
+	}
+	return nil, err
+}
+
+// AllocateNextAvailableIps reserves the next available addresses of the
+// first network or address range of the request which has enough of them,
+// trying the ones matching the EA search fields last. The addresses are
+// reserved by a host record not configured for DNS, with the given name.
+func (objMgr *ObjectManager) AllocateNextAvailableIps(req NextAvailableIpRequest, name string, comment string, eas EA) (*NextAvailableIpResult, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name of the host record reserving the addresses is required")
+	}
+	count := req.Count
+	if count == 0 {
+		count = 1
+	} else if count < 0 {
+		return nil, fmt.Errorf("number of IP addresses must be positive")
+	}
+	netview := req.NetworkView
+	if netview == "" {
+		netview = "default"
+	}
+	for _, addr := range req.Exclude {
+		if !isIPFamily(net.ParseIP(addr), req.IsIPv6) {
+			return nil, fmt.Errorf("excluded address '%s' is not a valid %s address", addr, ipFamilyName(req.IsIPv6))
+		}
+	}
+
+	sources, err := objMgr.ipAllocationSources(netview, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no network or address range to allocate IP addresses from")
+	}
+	var failures []string
+	for _, src := range sources {
+		recordHost, err := objMgr.reserveNextAvailableIps(netview, src, req, count, name, comment, eas)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", src.name, err))
+			continue
+		}
+		return &NextAvailableIpResult{Source: src.name, Ips: hostRecordIps(recordHost), HostRecord: recordHost}, nil
+	}
+	return nil, fmt.Errorf("no %d available IP addresses in %s", count, strings.Join(failures, "; "))
+}
+
+// createNextAvailableNetwork creates the next available network of the
+// network container. WAPI allocates it while creating the network, unless
+// some networks are excluded: it is then looked up first, and looked up
+// again if another client takes it before it is created.
+func (objMgr *ObjectManager) createNextAvailableNetwork(
+	netview string, src nextAvailableSource, req NextAvailableNetworkRequest, comment string, eas EA) (*Network, error) {
+
+	if len(req.Exclude) == 0 {
+		cidr := fmt.Sprintf("func:nextavailablenetwork:%s,%s,%d", src.name, netview, req.PrefixLen)
+		ref, err := objMgr.connector.CreateObject(NewNetwork(netview, cidr, req.IsIPv6, comment, eas))
+		if err != nil {
+			return nil, err
+		}
+		return objMgr.GetNetworkByRef(ref)
+	}
+
+	caller, ok := objMgr.connector.(IBFunctionCaller)
+	if !ok {
+		return nil, fmt.Errorf("the connector does not support WAPI function calls")
+	}
+	args := map[string]interface{}{"cidr": req.PrefixLen, "num": 1, "exclude": req.Exclude}
+	var err error
+	for attempt := 0; attempt < nextAvailableCreateAttempts; attempt++ {
+		var res struct {
+			Networks []string `json:"networks"`
+		}
+		if err = caller.CallFunction(src.ref, "next_available_network", args, &res); err != nil {
+			return nil, err
+		}
+		if len(res.Networks) == 0 {
+			return nil, fmt.Errorf("no available network")
+		}
+		var network *Network
+		if network, err = objMgr.CreateNetwork(netview, res.Networks[0], req.IsIPv6, comment, eas); err == nil {
+			return network, nil
+		}
+		err = fmt.Errorf("failed creating network '%s': %s", res.Networks[0], err)
+	}
+	return nil, err
+}
+
+// AllocateNextAvailableNetwork creates the next available network of the
+// first network container of the request which has room for it, trying the
+// ones matching the EA search fields last.
+func (objMgr *ObjectManager) AllocateNextAvailableNetwork(req NextAvailableNetworkRequest, comment string, eas EA) (*NextAvailableNetworkResult, error) {
+	if req.PrefixLen == 0 {
+		return nil, fmt.Errorf("prefix length of the network is required")
+	}
+	netview := req.NetworkView
+	if netview == "" {
+		netview = "default"
+	}
+	for _, cidr := range req.Exclude {
+		if ip, _, err := net.ParseCIDR(cidr); err != nil || !isIPFamily(ip, req.IsIPv6) {
+			return nil, fmt.Errorf("excluded network '%s' is not a valid %s network", cidr, ipFamilyName(req.IsIPv6))
+		}
+	}
+
+	var sources nextAvailableSources
+	for _, cidr := range req.Containers {
+		container, err := objMgr.GetNetworkContainer(netview, cidr, req.IsIPv6, nil)
+		if err != nil {
+			return nil, err
+		}
+		sources.add(cidr, container.Ref)
+	}
+	if len(req.ContainerEAs) > 0 {
+		var containers []NetworkContainer
+		err := objMgr.getObjects(NewNetworkContainer(netview, "", req.IsIPv6, "", nil),
+			NewQueryParams(false, eaSearchFields(netview, req.ContainerEAs)), &containers)
+		if err != nil {
+			return nil, err
+		}
+		for _, container := range containers {
+			sources.add(container.Cidr, container.Ref)
+		}
+	}
+	if len(sources.list) == 0 {
+		return nil, fmt.Errorf("no network container to allocate a network from")
+	}
+
+	var failures []string
+	for _, src := range sources.list {
+		network, err := objMgr.createNextAvailableNetwork(netview, src, req, comment, eas)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", src.name, err))
+			continue
+		}
+		return &NextAvailableNetworkResult{Source: src.name, Network: network}, nil
+	}
+	return nil, fmt.Errorf("no available /%d network in %s", req.PrefixLen, strings.Join(failures, "; "))
+}
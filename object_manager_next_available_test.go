@@ -0,0 +1,338 @@
+package ibclient
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// nextAvailableGridConnector keeps in memory the networks, network
+// containers and address ranges of a grid, and their available addresses
+// and networks, answering the next available functions the way WAPI does.
+// The addresses of raced are taken by another client when they are about
+// to be reserved.
+type nextAvailableGridConnector struct {
+	networks   map[string]string // CIDR to reference
+	containers map[string]string
+	ranges     map[string]string // first-last to reference
+	eaNetworks []Network         // networks matching any EA search
+	available  map[string][]string
+	raced      map[string]bool
+	calls      []string
+	created    []IBObject
+}
+
+// take removes the address from the available ones of its source. The
+// first available address of the source is taken for a next available
+// function.
+func (c *nextAvailableGridConnector) take(addr string) (string, error) {
+	if ref := strings.TrimPrefix(addr, "func:nextavailableip:"); ref != addr {
+		if len(c.available[ref]) == 0 {
+			return "", fmt.Errorf("Cannot find 1 available IP address(es) in this network")
+		}
+		addr, c.available[ref] = c.available[ref][0], c.available[ref][1:]
+		return addr, nil
+	}
+	for ref, found := range c.available {
+		for i, v := range found {
+			if v == addr {
+				c.available[ref] = append(found[:i:i], found[i+1:]...)
+			}
+		}
+	}
+	return addr, nil
+}
+
+func (c *nextAvailableGridConnector) CreateObject(obj IBObject) (string, error) {
+	switch o := obj.(type) {
+	case *HostRecord:
+		host := *o
+		host.Ipv4Addrs = nil
+		for _, addr := range o.Ipv4Addrs {
+			if c.raced[*addr.Ipv4Addr] {
+				delete(c.raced, *addr.Ipv4Addr)
+				_, _ = c.take(*addr.Ipv4Addr)
+				return "", fmt.Errorf("The IP address %s is already in use", *addr.Ipv4Addr)
+			}
+			ip, err := c.take(*addr.Ipv4Addr)
+			if err != nil {
+				return "", err
+			}
+			host.Ipv4Addrs = append(host.Ipv4Addrs, *NewHostRecordIpv4Addr(ip, "", false, ""))
+		}
+		c.created = append(c.created, &host)
+		return fmt.Sprintf("record:host/ZG5zLmhvc3QkLl9kZWZhdWx0:%s/default", *o.Name), nil
+	case *Network:
+		network := *o
+		if fn := strings.TrimPrefix(o.Cidr, "func:nextavailablenetwork:"); fn != o.Cidr {
+			args := strings.Split(fn, ",")
+			found := c.available[c.containers[args[0]]]
+			if len(found) == 0 {
+				return "", fmt.Errorf("Cannot find 1 available network for container %s", args[0])
+			}
+			network.Cidr = found[0]
+			c.available[c.containers[args[0]]] = found[1:]
+		}
+		c.created = append(c.created, &network)
+		return fmt.Sprintf("network/ZG5zLm5ldHdvcmskMTAuMA:%s/%s", network.Cidr, network.NetviewName), nil
+	}
+	return "", fmt.Errorf("unsupported object type")
+}
+
+func (c *nextAvailableGridConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res interface{}) error {
+	sf := qp.searchFields
+	switch obj.(type) {
+	case *HostRecord:
+		host := *c.created[len(c.created)-1].(*HostRecord)
+		host.Ref = ref
+		**res.(**HostRecord) = host
+	case *Network:
+		if ref != "" {
+			network := *c.created[len(c.created)-1].(*Network)
+			network.Ref = ref
+			*res.(*Network) = network
+		} else if sf["network"] == "" {
+			*res.(*[]Network) = c.eaNetworks
+		} else if nwRef, ok := c.networks[sf["network"]]; ok {
+			*res.(*[]Network) = []Network{{Ref: nwRef, Cidr: sf["network"]}}
+		}
+	case *NetworkContainer:
+		if ncRef, ok := c.containers[sf["network"]]; ok {
+			*res.(*[]NetworkContainer) = []NetworkContainer{{Ref: ncRef, Cidr: sf["network"]}}
+		}
+	case *Range:
+		if rangeRef, ok := c.ranges[sf["start_addr"]+"-"+sf["end_addr"]]; ok {
+			*res.(*[]Range) = []Range{{Ref: rangeRef}}
+		}
+	default:
+		return fmt.Errorf("unsupported object type")
+	}
+	return nil
+}
+
+func (c *nextAvailableGridConnector) DeleteObject(ref string) (string, error) {
+	return "", fmt.Errorf("unexpected call")
+}
+
+func (c *nextAvailableGridConnector) UpdateObject(obj IBObject, ref string) (string, error) {
+	return "", fmt.Errorf("unexpected call")
+}
+
+func (c *nextAvailableGridConnector) CallFunction(ref string, function string, args interface{}, res interface{}) error {
+	c.calls = append(c.calls, ref)
+	params := args.(map[string]interface{})
+	excluded := map[string]bool{}
+	if exclude, ok := params["exclude"]; ok {
+		for _, addr := range exclude.([]string) {
+			excluded[addr] = true
+		}
+	}
+	var found []string
+	for _, addr := range c.available[ref] {
+		if !excluded[addr] {
+			found = append(found, addr)
+		}
+	}
+
+	switch function {
+	case "next_available_ip":
+		num := params["num"].(int)
+		if len(found) < num {
+			return fmt.Errorf("Cannot find %d available IP address(es) in this network", num)
+		}
+		res.(*struct {
+			Ips []string `json:"ips"`
+		}).Ips = found[:num]
+	case "next_available_network":
+		if len(found) == 0 {
+			return fmt.Errorf("Cannot find 1 available network for container %s", ref)
+		}
+		res.(*struct {
+			Networks []string `json:"networks"`
+		}).Networks = found[:1]
+	}
+	return nil
+}
+
+var _ = Describe("Object Manager: next available allocation", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	fullRef := "network/ZG5zLm5ldHdvcmskMTAuMjAuMC4wLzI0LzA:10.20.0.0/24/default"
+	spareRef := "network/ZG5zLm5ldHdvcmskMTAuMjAuMS4wLzI0LzA:10.20.1.0/24/default"
+	rangeRef := "range/ZG5zLmRoY3BfcmFuZ2UkMTAuMjAuMi4xMC8xMC4yMC4yLjUwLy8vMC8:10.20.2.10/10.20.2.50/default"
+	siteRef := "network/ZG5zLm5ldHdvcmskMTAuMzAuMC4wLzI0LzA:10.30.0.0/24/default"
+
+	newGrid := func() (*nextAvailableGridConnector, IBObjectManager) {
+		conn := &nextAvailableGridConnector{
+			networks: map[string]string{"10.20.0.0/24": fullRef, "10.20.1.0/24": spareRef},
+			ranges:   map[string]string{"10.20.2.10-10.20.2.50": rangeRef},
+			eaNetworks: []Network{
+				{Ref: fullRef, Cidr: "10.20.0.0/24"},
+				{Ref: siteRef, Cidr: "10.30.0.0/24"},
+			},
+			available: map[string][]string{
+				fullRef:  {"10.20.0.254"},
+				spareRef: {"10.20.1.5", "10.20.1.7", "10.20.1.8", "10.20.1.9", "10.20.1.10"},
+				rangeRef: {"10.20.2.10", "10.20.2.11"},
+				siteRef:  {"10.30.0.1", "10.30.0.2", "10.30.0.3"},
+			},
+		}
+		return conn, NewObjectManager(conn, cmpType, tenantID)
+	}
+
+	Describe("Allocate IP addresses from several sources", func() {
+		It("should fall back to the next network and report it", func() {
+			conn, objMgr := newGrid()
+			res, err := objMgr.AllocateNextAvailableIps(NextAvailableIpRequest{
+				Sources: []string{"10.20.0.0/24", "10.20.1.0/24"},
+				Count:   2,
+			}, "vm1", "reserved", nil)
+			Expect(err).To(BeNil())
+			Expect(res.Source).To(Equal("10.20.1.0/24"))
+			Expect(res.Ips).To(Equal([]string{"10.20.1.5", "10.20.1.7"}))
+			Expect(res.HostRecord.Ref).To(Equal("record:host/ZG5zLmhvc3QkLl9kZWZhdWx0:vm1/default"))
+			Expect(*res.HostRecord.EnableDns).To(BeFalse())
+			Expect(res.HostRecord.Ipv4Addrs).To(HaveLen(2))
+			// the addresses are allocated while creating the host record
+			Expect(conn.calls).To(BeEmpty())
+			Expect(conn.available[spareRef]).To(Equal([]string{"10.20.1.8", "10.20.1.9", "10.20.1.10"}))
+		})
+
+		It("should skip the excluded addresses", func() {
+			_, objMgr := newGrid()
+			res, err := objMgr.AllocateNextAvailableIps(NextAvailableIpRequest{
+				Sources: []string{"10.20.1.0/24"},
+				Exclude: []string{"10.20.1.5"},
+			}, "vm1", "", nil)
+			Expect(err).To(BeNil())
+			Expect(res.Ips).To(Equal([]string{"10.20.1.7"}))
+		})
+
+		It("should allocate contiguous addresses", func() {
+			conn, objMgr := newGrid()
+			res, err := objMgr.AllocateNextAvailableIps(NextAvailableIpRequest{
+				Sources:    []string{"10.20.1.0/24"},
+				Count:      3,
+				Contiguous: true,
+			}, "cluster", "", nil)
+			Expect(err).To(BeNil())
+			Expect(res.Ips).To(Equal([]string{"10.20.1.7", "10.20.1.8", "10.20.1.9"}))
+			Expect(conn.calls).To(HaveLen(2))
+		})
+
+		It("should look the contiguous addresses up again when another client takes them", func() {
+			conn, objMgr := newGrid()
+			conn.raced = map[string]bool{"10.20.1.8": true}
+			res, err := objMgr.AllocateNextAvailableIps(NextAvailableIpRequest{
+				Sources:    []string{"10.20.1.0/24"},
+				Count:      2,
+				Contiguous: true,
+			}, "cluster", "", nil)
+			Expect(err).To(BeNil())
+			Expect(res.Ips).To(Equal([]string{"10.20.1.9", "10.20.1.10"}))
+			Expect(conn.created).To(HaveLen(1))
+		})
+
+		It("should use address ranges and the networks matching the EAs", func() {
+			_, objMgr := newGrid()
+			res, err := objMgr.AllocateNextAvailableIps(NextAvailableIpRequest{
+				Sources:   []string{"10.20.2.10-10.20.2.50"},
+				SourceEAs: map[string]string{"*Site": "Tokyo"},
+				Count:     3,
+			}, "vm1", "", nil)
+			Expect(err).To(BeNil())
+			Expect(res.Source).To(Equal("10.30.0.0/24"))
+		})
+
+		It("should report every source when none has enough addresses", func() {
+			_, objMgr := newGrid()
+			_, err := objMgr.AllocateNextAvailableIps(NextAvailableIpRequest{
+				Sources:    []string{"10.20.0.0/24", "10.20.1.0/24"},
+				Count:      5,
+				Contiguous: true,
+			}, "vm1", "", nil)
+			Expect(err).To(MatchError("no 5 available IP addresses in " +
+				"10.20.0.0/24: Cannot find 5 available IP address(es) in this network; " +
+				"10.20.1.0/24: Cannot find 5 available IP address(es) in this network"))
+		})
+
+		It("should fail if a source does not exist", func() {
+			_, objMgr := newGrid()
+			_, err := objMgr.AllocateNextAvailableIps(NextAvailableIpRequest{
+				Sources: []string{"10.40.0.0/24"},
+			}, "vm1", "", nil)
+			Expect(err).To(BeAssignableToTypeOf(&NotFoundError{}))
+		})
+
+		It("should validate the request", func() {
+			_, objMgr := newGrid()
+			_, err := objMgr.AllocateNextAvailableIps(NextAvailableIpRequest{
+				Sources: []string{"2001:db8::/64"},
+			}, "vm1", "", nil)
+			Expect(err).To(MatchError("'2001:db8::/64' is neither an IPv4 network nor an address range"))
+			_, err = objMgr.AllocateNextAvailableIps(NextAvailableIpRequest{
+				Sources: []string{"10.20.1.0/24"},
+				Exclude: []string{"10.20.1"},
+			}, "vm1", "", nil)
+			Expect(err).To(MatchError("excluded address '10.20.1' is not a valid IPv4 address"))
+		})
+	})
+
+	Describe("Allocate a network from several containers", func() {
+		It("should create the network in the first container with room for it", func() {
+			conn := &nextAvailableGridConnector{
+				containers: map[string]string{
+					"10.0.0.0/16": "networkcontainer/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMTYvMA:10.0.0.0/16/default",
+					"10.1.0.0/16": "networkcontainer/ZG5zLm5ldHdvcmskMTAuMS4wLjAvMTYvMA:10.1.0.0/16/default",
+				},
+				available: map[string][]string{
+					"networkcontainer/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMTYvMA:10.0.0.0/16/default": {"10.0.255.0/24"},
+					"networkcontainer/ZG5zLm5ldHdvcmskMTAuMS4wLjAvMTYvMA:10.1.0.0/16/default": {"10.1.0.0/24", "10.1.1.0/24"},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			res, err := objMgr.AllocateNextAvailableNetwork(NextAvailableNetworkRequest{
+				Containers: []string{"10.0.0.0/16", "10.1.0.0/16"},
+				PrefixLen:  24,
+				Exclude:    []string{"10.0.255.0/24", "10.1.0.0/24"},
+			}, "app network", EA{"Site": "Tokyo"})
+			Expect(err).To(BeNil())
+			Expect(res.Source).To(Equal("10.1.0.0/16"))
+			Expect(res.Network.Cidr).To(Equal("10.1.1.0/24"))
+			Expect(res.Network.Ref).To(Equal("network/ZG5zLm5ldHdvcmskMTAuMA:10.1.1.0/24/default"))
+		})
+
+		It("should let WAPI allocate the network when none is excluded", func() {
+			ncRef := "networkcontainer/ZG5zLm5ldHdvcmskMTAuMS4wLjAvMTYvMA:10.1.0.0/16/default"
+			conn := &nextAvailableGridConnector{
+				containers: map[string]string{"10.1.0.0/16": ncRef},
+				available:  map[string][]string{ncRef: {"10.1.0.0/24", "10.1.1.0/24"}},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			res, err := objMgr.AllocateNextAvailableNetwork(NextAvailableNetworkRequest{
+				Containers: []string{"10.1.0.0/16"},
+				PrefixLen:  24,
+			}, "app network", nil)
+			Expect(err).To(BeNil())
+			Expect(res.Network.Cidr).To(Equal("10.1.0.0/24"))
+			Expect(conn.calls).To(BeEmpty())
+		})
+
+		It("should require the prefix length", func() {
+			_, err := NewObjectManager(&nextAvailableGridConnector{}, cmpType, tenantID).AllocateNextAvailableNetwork(
+				NextAvailableNetworkRequest{Containers: []string{"10.0.0.0/16"}}, "", nil)
+			Expect(err).To(MatchError("prefix length of the network is required"))
+		})
+	})
+
+	Describe("Find consecutive addresses", func() {
+		It("should return the start of the last run", func() {
+			ips := []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.3"), net.ParseIP("10.0.0.4")}
+			Expect(lastIpRun(ips)).To(Equal(1))
+			Expect(nextIP(net.ParseIP("10.0.0.255")).Equal(net.ParseIP("10.0.1.0"))).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,507 @@
+package ibclient
+
+import (
+	"fmt"
+	"net"
+)
+
+// nsgroupUpdate is the Nsgroup sent on update. The server lists are kept
+// behind pointers so that an empty list is sent rather than omitted.
+type nsgroupUpdate struct {
+	IBBase              `json:"-"`
+	Name                *string          `json:"name,omitempty"`
+	Comment             *string          `json:"comment,omitempty"`
+	GridPrimary         *[]*Memberserver `json:"grid_primary,omitempty"`
+	GridSecondaries     *[]*Memberserver `json:"grid_secondaries,omitempty"`
+	ExternalPrimaries   *[]NameServer    `json:"external_primaries,omitempty"`
+	ExternalSecondaries *[]NameServer    `json:"external_secondaries,omitempty"`
+	UseExternalPrimary  *bool            `json:"use_external_primary,omitempty"`
+	IsGridDefault       *bool            `json:"is_grid_default,omitempty"`
+	Ea                  EA               `json:"extattrs"`
+}
+
+func (nsgroupUpdate) ObjectType() string {
+	return "nsgroup"
+}
+
+func validateNsgroupMembers(field string, members []*Memberserver, seen map[string]bool) error {
+	for _, m := range members {
+		if m == nil || m.Name == "" {
+			return fmt.Errorf("name of every member in '%s' is required", field)
+		}
+		if seen[m.Name] {
+			return fmt.Errorf("member '%s' is listed more than once", m.Name)
+		}
+		seen[m.Name] = true
+	}
+	return nil
+}
+
+func validateNsgroupExternalServers(field string, servers []NameServer) error {
+	for _, s := range servers {
+		if s.Name == "" || s.Address == "" {
+			return fmt.Errorf("name and address of every server in '%s' are required", field)
+		}
+		if err := ValidateDomainName(s.Name); err != nil {
+			return err
+		}
+		if net.ParseIP(s.Address) == nil {
+			return fmt.Errorf("address '%s' of server '%s' in '%s' is not a valid IP address", s.Address, s.Name, field)
+		}
+	}
+	return nil
+}
+
+func validateNsgroupArgs(name string, gridPrimary []*Memberserver, gridSecondaries []*Memberserver,
+	externalPrimaries []NameServer, externalSecondaries []NameServer, useExternalPrimary bool) error {
+	if name == "" {
+		return fmt.Errorf("name of the name server group is required")
+	}
+	if useExternalPrimary {
+		if len(externalPrimaries) == 0 {
+			return fmt.Errorf("external primaries are required when using an external primary")
+		}
+		if len(gridPrimary) > 0 {
+			return fmt.Errorf("grid primaries are not allowed when using an external primary")
+		}
+		if len(gridSecondaries) == 0 {
+			return fmt.Errorf("at least one grid secondary is required when using an external primary")
+		}
+	} else {
+		if len(gridPrimary) == 0 {
+			return fmt.Errorf("at least one grid primary is required")
+		}
+		if len(externalPrimaries) > 0 {
+			return fmt.Errorf("external primaries are only allowed when using an external primary")
+		}
+	}
+
+	seen := make(map[string]bool)
+	if err := validateNsgroupMembers("grid_primary", gridPrimary, seen); err != nil {
+		return err
+	}
+	if err := validateNsgroupMembers("grid_secondaries", gridSecondaries, seen); err != nil {
+		return err
+	}
+	if err := validateNsgroupExternalServers("external_primaries", externalPrimaries); err != nil {
+		return err
+	}
+	return validateNsgroupExternalServers("external_secondaries", externalSecondaries)
+}
+
+func NewEmptyNsgroup() *Nsgroup {
+	nsgroup := &Nsgroup{}
+	nsgroup.SetReturnFields(append(nsgroup.ReturnFields(), "extattrs", "external_primaries", "external_secondaries",
+		"grid_primary", "grid_secondaries", "is_grid_default", "is_multimaster", "use_external_primary"))
+	return nsgroup
+}
+
+func NewNsgroup(name string, comment string, gridPrimary []*Memberserver, gridSecondaries []*Memberserver,
+	externalPrimaries []NameServer, externalSecondaries []NameServer, useExternalPrimary bool, isGridDefault bool, ea EA) *Nsgroup {
+	nsgroup := NewEmptyNsgroup()
+	nsgroup.Name = &name
+	nsgroup.Comment = &comment
+	nsgroup.GridPrimary = gridPrimary
+	nsgroup.GridSecondaries = gridSecondaries
+	nsgroup.ExternalPrimaries = externalPrimaries
+	nsgroup.ExternalSecondaries = externalSecondaries
+	nsgroup.UseExternalPrimary = &useExternalPrimary
+	nsgroup.IsGridDefault = &isGridDefault
+	nsgroup.Ea = ea
+	return nsgroup
+}
+
+// CreateNsgroup creates an authoritative name server group made of grid
+// members and external name servers.
+func (objMgr *ObjectManager) CreateNsgroup(name string, comment string, gridPrimary []*Memberserver, gridSecondaries []*Memberserver,
+	externalPrimaries []NameServer, externalSecondaries []NameServer, useExternalPrimary bool, isGridDefault bool, ea EA) (*Nsgroup, error) {
+	if err := validateNsgroupArgs(name, gridPrimary, gridSecondaries, externalPrimaries, externalSecondaries, useExternalPrimary); err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroup(name, comment, gridPrimary, gridSecondaries, externalPrimaries, externalSecondaries, useExternalPrimary, isGridDefault, ea)
+	ref, err := objMgr.connector.CreateObject(nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup.Ref = ref
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetNsgroupByRef(ref string) (*Nsgroup, error) {
+	nsgroup := NewEmptyNsgroup()
+	err := objMgr.connector.GetObject(nsgroup, ref, NewQueryParams(false, nil), &nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetAllNsgroup(queryParams *QueryParams) ([]Nsgroup, error) {
+	var res []Nsgroup
+	nsgroup := NewEmptyNsgroup()
+	err := objMgr.connector.GetObject(nsgroup, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting Nsgroup: %s", err)
+	}
+	return res, nil
+}
+
+// UpdateNsgroup replaces the settings and the server lists of the name
+// server group; empty lists remove all the servers of that kind.
+func (objMgr *ObjectManager) UpdateNsgroup(ref string, name string, comment string, gridPrimary []*Memberserver, gridSecondaries []*Memberserver,
+	externalPrimaries []NameServer, externalSecondaries []NameServer, useExternalPrimary bool, isGridDefault bool, ea EA) (*Nsgroup, error) {
+	if err := validateNsgroupArgs(name, gridPrimary, gridSecondaries, externalPrimaries, externalSecondaries, useExternalPrimary); err != nil {
+		return nil, err
+	}
+	upd := &nsgroupUpdate{
+		Name:                &name,
+		Comment:             &comment,
+		GridPrimary:         &gridPrimary,
+		GridSecondaries:     &gridSecondaries,
+		ExternalPrimaries:   &externalPrimaries,
+		ExternalSecondaries: &externalSecondaries,
+		UseExternalPrimary:  &useExternalPrimary,
+		IsGridDefault:       &isGridDefault,
+		Ea:                  ea,
+	}
+	if gridPrimary == nil {
+		upd.GridPrimary = &[]*Memberserver{}
+	}
+	if gridSecondaries == nil {
+		upd.GridSecondaries = &[]*Memberserver{}
+	}
+	if externalPrimaries == nil {
+		upd.ExternalPrimaries = &[]NameServer{}
+	}
+	if externalSecondaries == nil {
+		upd.ExternalSecondaries = &[]NameServer{}
+	}
+	updatedRef, err := objMgr.connector.UpdateObject(upd, ref)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroup(name, comment, gridPrimary, gridSecondaries, externalPrimaries, externalSecondaries, useExternalPrimary, isGridDefault, ea)
+	nsgroup.Ref = updatedRef
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) DeleteNsgroup(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func validateNsgroupDelegationArgs(name string, delegateTo []NameServer) error {
+	if name == "" {
+		return fmt.Errorf("name of the name server group is required")
+	}
+	if len(delegateTo) == 0 {
+		return fmt.Errorf("at least one name server to delegate to is required")
+	}
+	return validateNsgroupExternalServers("delegate_to", delegateTo)
+}
+
+func NewEmptyNsgroupDelegation() *NsgroupDelegation {
+	nsgroup := &NsgroupDelegation{}
+	nsgroup.SetReturnFields(append(nsgroup.ReturnFields(), "comment", "extattrs"))
+	return nsgroup
+}
+
+func NewNsgroupDelegation(name string, delegateTo []NameServer, comment string, ea EA) *NsgroupDelegation {
+	nsgroup := NewEmptyNsgroupDelegation()
+	nsgroup.Name = &name
+	nsgroup.DelegateTo = delegateTo
+	nsgroup.Comment = &comment
+	nsgroup.Ea = ea
+	return nsgroup
+}
+
+// CreateNsgroupDelegation creates a group of name servers to which zones are delegated.
+func (objMgr *ObjectManager) CreateNsgroupDelegation(name string, delegateTo []NameServer, comment string, ea EA) (*NsgroupDelegation, error) {
+	if err := validateNsgroupDelegationArgs(name, delegateTo); err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroupDelegation(name, delegateTo, comment, ea)
+	ref, err := objMgr.connector.CreateObject(nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup.Ref = ref
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetNsgroupDelegationByRef(ref string) (*NsgroupDelegation, error) {
+	nsgroup := NewEmptyNsgroupDelegation()
+	err := objMgr.connector.GetObject(nsgroup, ref, NewQueryParams(false, nil), &nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetAllNsgroupDelegation(queryParams *QueryParams) ([]NsgroupDelegation, error) {
+	var res []NsgroupDelegation
+	nsgroup := NewEmptyNsgroupDelegation()
+	err := objMgr.connector.GetObject(nsgroup, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting Nsgroup Delegation: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) UpdateNsgroupDelegation(ref string, name string, delegateTo []NameServer, comment string, ea EA) (*NsgroupDelegation, error) {
+	if err := validateNsgroupDelegationArgs(name, delegateTo); err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroupDelegation(name, delegateTo, comment, ea)
+	updatedRef, err := objMgr.connector.UpdateObject(nsgroup, ref)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup.Ref = updatedRef
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) DeleteNsgroupDelegation(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func validateNsgroupForwardingMemberArgs(name string, forwardingServers []*Forwardingmemberserver) error {
+	if name == "" {
+		return fmt.Errorf("name of the name server group is required")
+	}
+	if len(forwardingServers) == 0 {
+		return fmt.Errorf("at least one forwarding member is required")
+	}
+	seen := make(map[string]bool)
+	for _, s := range forwardingServers {
+		if s == nil || s.Name == "" {
+			return fmt.Errorf("name of every forwarding member is required")
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("member '%s' is listed more than once", s.Name)
+		}
+		seen[s.Name] = true
+		if s.UseOverrideForwarders && len(s.ForwardTo.NameServers) == 0 {
+			return fmt.Errorf("forwarders of member '%s' are required to override the zone forwarders", s.Name)
+		}
+		if err := validateNsgroupExternalServers("forward_to", s.ForwardTo.NameServers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewEmptyNsgroupForwardingMember() *NsgroupForwardingmember {
+	nsgroup := &NsgroupForwardingmember{}
+	nsgroup.SetReturnFields(append(nsgroup.ReturnFields(), "comment", "extattrs"))
+	return nsgroup
+}
+
+func NewNsgroupForwardingMember(name string, forwardingServers []*Forwardingmemberserver, comment string, ea EA) *NsgroupForwardingmember {
+	nsgroup := NewEmptyNsgroupForwardingMember()
+	nsgroup.Name = &name
+	nsgroup.ForwardingServers = forwardingServers
+	nsgroup.Comment = &comment
+	nsgroup.Ea = ea
+	return nsgroup
+}
+
+// CreateNsgroupForwardingMember creates a group of grid members serving forward zones.
+func (objMgr *ObjectManager) CreateNsgroupForwardingMember(name string, forwardingServers []*Forwardingmemberserver, comment string, ea EA) (*NsgroupForwardingmember, error) {
+	if err := validateNsgroupForwardingMemberArgs(name, forwardingServers); err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroupForwardingMember(name, forwardingServers, comment, ea)
+	ref, err := objMgr.connector.CreateObject(nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup.Ref = ref
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetNsgroupForwardingMemberByRef(ref string) (*NsgroupForwardingmember, error) {
+	nsgroup := NewEmptyNsgroupForwardingMember()
+	err := objMgr.connector.GetObject(nsgroup, ref, NewQueryParams(false, nil), &nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetAllNsgroupForwardingMember(queryParams *QueryParams) ([]NsgroupForwardingmember, error) {
+	var res []NsgroupForwardingmember
+	nsgroup := NewEmptyNsgroupForwardingMember()
+	err := objMgr.connector.GetObject(nsgroup, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting Nsgroup Forwarding Member: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) UpdateNsgroupForwardingMember(ref string, name string, forwardingServers []*Forwardingmemberserver, comment string, ea EA) (*NsgroupForwardingmember, error) {
+	if err := validateNsgroupForwardingMemberArgs(name, forwardingServers); err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroupForwardingMember(name, forwardingServers, comment, ea)
+	updatedRef, err := objMgr.connector.UpdateObject(nsgroup, ref)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup.Ref = updatedRef
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) DeleteNsgroupForwardingMember(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func validateNsgroupForwardStubServerArgs(name string, externalServers []NameServer) error {
+	if name == "" {
+		return fmt.Errorf("name of the name server group is required")
+	}
+	if len(externalServers) == 0 {
+		return fmt.Errorf("at least one external server is required")
+	}
+	return validateNsgroupExternalServers("external_servers", externalServers)
+}
+
+func NewEmptyNsgroupForwardStubServer() *NsgroupForwardstubserver {
+	nsgroup := &NsgroupForwardstubserver{}
+	nsgroup.SetReturnFields(append(nsgroup.ReturnFields(), "comment", "extattrs"))
+	return nsgroup
+}
+
+func NewNsgroupForwardStubServer(name string, externalServers []NameServer, comment string, ea EA) *NsgroupForwardstubserver {
+	nsgroup := NewEmptyNsgroupForwardStubServer()
+	nsgroup.Name = &name
+	nsgroup.ExternalServers = externalServers
+	nsgroup.Comment = &comment
+	nsgroup.Ea = ea
+	return nsgroup
+}
+
+// CreateNsgroupForwardStubServer creates a group of external name servers
+// which forward and stub zones are pointed at.
+func (objMgr *ObjectManager) CreateNsgroupForwardStubServer(name string, externalServers []NameServer, comment string, ea EA) (*NsgroupForwardstubserver, error) {
+	if err := validateNsgroupForwardStubServerArgs(name, externalServers); err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroupForwardStubServer(name, externalServers, comment, ea)
+	ref, err := objMgr.connector.CreateObject(nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup.Ref = ref
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetNsgroupForwardStubServerByRef(ref string) (*NsgroupForwardstubserver, error) {
+	nsgroup := NewEmptyNsgroupForwardStubServer()
+	err := objMgr.connector.GetObject(nsgroup, ref, NewQueryParams(false, nil), &nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetAllNsgroupForwardStubServer(queryParams *QueryParams) ([]NsgroupForwardstubserver, error) {
+	var res []NsgroupForwardstubserver
+	nsgroup := NewEmptyNsgroupForwardStubServer()
+	err := objMgr.connector.GetObject(nsgroup, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting Nsgroup Forward Stub Server: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) UpdateNsgroupForwardStubServer(ref string, name string, externalServers []NameServer, comment string, ea EA) (*NsgroupForwardstubserver, error) {
+	if err := validateNsgroupForwardStubServerArgs(name, externalServers); err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroupForwardStubServer(name, externalServers, comment, ea)
+	updatedRef, err := objMgr.connector.UpdateObject(nsgroup, ref)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup.Ref = updatedRef
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) DeleteNsgroupForwardStubServer(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func validateNsgroupStubMemberArgs(name string, stubMembers []*Memberserver) error {
+	if name == "" {
+		return fmt.Errorf("name of the name server group is required")
+	}
+	if len(stubMembers) == 0 {
+		return fmt.Errorf("at least one stub member is required")
+	}
+	return validateNsgroupMembers("stub_members", stubMembers, make(map[string]bool))
+}
+
+func NewEmptyNsgroupStubMember() *NsgroupStubmember {
+	nsgroup := &NsgroupStubmember{}
+	nsgroup.SetReturnFields(append(nsgroup.ReturnFields(), "comment", "extattrs", "stub_members"))
+	return nsgroup
+}
+
+func NewNsgroupStubMember(name string, stubMembers []*Memberserver, comment string, ea EA) *NsgroupStubmember {
+	nsgroup := NewEmptyNsgroupStubMember()
+	nsgroup.Name = &name
+	nsgroup.StubMembers = stubMembers
+	nsgroup.Comment = &comment
+	nsgroup.Ea = ea
+	return nsgroup
+}
+
+// CreateNsgroupStubMember creates a group of grid members serving stub zones.
+func (objMgr *ObjectManager) CreateNsgroupStubMember(name string, stubMembers []*Memberserver, comment string, ea EA) (*NsgroupStubmember, error) {
+	if err := validateNsgroupStubMemberArgs(name, stubMembers); err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroupStubMember(name, stubMembers, comment, ea)
+	ref, err := objMgr.connector.CreateObject(nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup.Ref = ref
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetNsgroupStubMemberByRef(ref string) (*NsgroupStubmember, error) {
+	nsgroup := NewEmptyNsgroupStubMember()
+	err := objMgr.connector.GetObject(nsgroup, ref, NewQueryParams(false, nil), &nsgroup)
+	if err != nil {
+		return nil, err
+	}
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) GetAllNsgroupStubMember(queryParams *QueryParams) ([]NsgroupStubmember, error) {
+	var res []NsgroupStubmember
+	nsgroup := NewEmptyNsgroupStubMember()
+	err := objMgr.connector.GetObject(nsgroup, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting Nsgroup Stub Member: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) UpdateNsgroupStubMember(ref string, name string, stubMembers []*Memberserver, comment string, ea EA) (*NsgroupStubmember, error) {
+	if err := validateNsgroupStubMemberArgs(name, stubMembers); err != nil {
+		return nil, err
+	}
+	nsgroup := NewNsgroupStubMember(name, stubMembers, comment, ea)
+	updatedRef, err := objMgr.connector.UpdateObject(nsgroup, ref)
+	if err != nil {
+		return nil, err
+	}
+	nsgroup.Ref = updatedRef
+	return nsgroup, nil
+}
+
+func (objMgr *ObjectManager) DeleteNsgroupStubMember(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
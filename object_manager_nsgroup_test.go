@@ -0,0 +1,122 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: name server group", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "corp-ns"
+	ref := "nsgroup/ZG5zLm5zX2dyb3VwJGNvcnAtbnM:corp-ns"
+	primary := []*Memberserver{{Name: "infoblox.localdomain"}}
+	secondaries := []*Memberserver{{Name: "member2.localdomain", Stealth: true}}
+	externals := []NameServer{{Name: "ns.partner.com", Address: "198.51.100.53"}}
+
+	Describe("Create Nsgroup", func() {
+		conn := &fakeConnector{
+			createObjectObj: NewNsgroup(name, "", primary, secondaries, nil, externals, false, false, nil),
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected Nsgroup Object to CreateObject", func() {
+			nsgroup, err := objMgr.CreateNsgroup(name, "", primary, secondaries, nil, externals, false, false, nil)
+			Expect(err).To(BeNil())
+			Expect(nsgroup.Ref).To(Equal(ref))
+		})
+
+		It("should require a grid primary unless using an external one", func() {
+			_, err := objMgr.CreateNsgroup(name, "", nil, secondaries, nil, nil, false, false, nil)
+			Expect(err).To(Equal(fmt.Errorf("at least one grid primary is required")))
+			_, err = objMgr.CreateNsgroup(name, "", primary, secondaries, externals, nil, true, false, nil)
+			Expect(err).To(Equal(fmt.Errorf("grid primaries are not allowed when using an external primary")))
+		})
+
+		It("should reject a member being both primary and secondary", func() {
+			_, err := objMgr.CreateNsgroup(name, "", primary, primary, nil, nil, false, false, nil)
+			Expect(err).To(Equal(fmt.Errorf("member 'infoblox.localdomain' is listed more than once")))
+		})
+
+		It("should reject an external server without a valid address", func() {
+			_, err := objMgr.CreateNsgroup(name, "", primary, nil, nil, []NameServer{{Name: "ns.partner.com", Address: "ns.partner.com"}}, false, false, nil)
+			Expect(err).To(Equal(fmt.Errorf("address 'ns.partner.com' of server 'ns.partner.com' in 'external_secondaries' is not a valid IP address")))
+		})
+	})
+
+	Describe("Update Nsgroup", func() {
+		comment := "no secondaries"
+		useExternalPrimary := false
+		isGridDefault := false
+		noMembers := []*Memberserver{}
+		noServers := []NameServer{}
+		conn := &fakeConnector{
+			updateObjectObj: &nsgroupUpdate{
+				Name:                &name,
+				Comment:             &comment,
+				GridPrimary:         &primary,
+				GridSecondaries:     &noMembers,
+				ExternalPrimaries:   &noServers,
+				ExternalSecondaries: &noServers,
+				UseExternalPrimary:  &useExternalPrimary,
+				IsGridDefault:       &isGridDefault,
+			},
+			updateObjectRef: ref,
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should send empty server lists to remove the servers", func() {
+			nsgroup, err := objMgr.UpdateNsgroup(ref, name, comment, primary, nil, nil, nil, false, false, nil)
+			Expect(err).To(BeNil())
+			Expect(nsgroup.Ref).To(Equal(ref))
+			data, err := json.Marshal(conn.updateObjectObj)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(ContainSubstring(`"grid_secondaries":[]`))
+		})
+	})
+
+	Describe("Create Nsgroup Delegation", func() {
+		delegationRef := "nsgroup:delegation/ZG5zLmRlbGVnYXRpb24kcGFydG5lcg:partner"
+		conn := &fakeConnector{
+			createObjectObj: NewNsgroupDelegation("partner", externals, "", nil),
+			fakeRefReturn:   delegationRef,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected NsgroupDelegation Object to CreateObject", func() {
+			nsgroup, err := objMgr.CreateNsgroupDelegation("partner", externals, "", nil)
+			Expect(err).To(BeNil())
+			Expect(nsgroup.Ref).To(Equal(delegationRef))
+		})
+
+		It("should require name servers to delegate to", func() {
+			_, err := objMgr.CreateNsgroupDelegation("partner", nil, "", nil)
+			Expect(err).To(Equal(fmt.Errorf("at least one name server to delegate to is required")))
+		})
+	})
+
+	Describe("Create forwarding, forward/stub and stub member groups", func() {
+		objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+
+		It("should require override forwarders", func() {
+			_, err := objMgr.CreateNsgroupForwardingMember("fwd", []*Forwardingmemberserver{
+				{Name: "infoblox.localdomain", UseOverrideForwarders: true}}, "", nil)
+			Expect(err).To(Equal(fmt.Errorf("forwarders of member 'infoblox.localdomain' are required to override the zone forwarders")))
+		})
+
+		It("should require external servers", func() {
+			_, err := objMgr.CreateNsgroupForwardStubServer("ext", nil, "", nil)
+			Expect(err).To(Equal(fmt.Errorf("at least one external server is required")))
+		})
+
+		It("should require stub members", func() {
+			_, err := objMgr.CreateNsgroupStubMember("stub", []*Memberserver{{}}, "", nil)
+			Expect(err).To(Equal(fmt.Errorf("name of every member in 'stub_members' is required")))
+		})
+	})
+})
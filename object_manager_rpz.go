@@ -0,0 +1,658 @@
+package ibclient
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Triggers of the RPZ rules.
+const (
+	RpzTriggerDomain    = "DOMAIN"     // the queried domain name (QNAME)
+	RpzTriggerIpAddress = "IP_ADDRESS" // an address in the response
+	RpzTriggerClientIp  = "CLIENT_IP"  // the address of the querying client
+)
+
+// Actions of the RPZ rules.
+const (
+	RpzActionNxdomain   = "NXDOMAIN"
+	RpzActionNodata     = "NODATA"
+	RpzActionPassthru   = "PASSTHRU"
+	RpzActionDrop       = "DROP" // client IP triggers only
+	RpzActionSubstitute = "SUBSTITUTE"
+)
+
+// RpzZoneParams holds the settings of a local response policy zone to be created.
+type RpzZoneParams struct {
+	Fqdn            string
+	View            string // "default" if empty
+	Policy          string // GIVEN (default), NXDOMAIN, NODATA, PASSTHRU, SUBSTITUTE or DISABLED
+	SubstituteName  string // required by the SUBSTITUTE policy
+	Severity        string // CRITICAL, MAJOR, WARNING or INFORMATIONAL
+	NsGroup         string
+	GridPrimary     []*Memberserver
+	GridSecondaries []*Memberserver
+	Comment         string
+	Disable         bool
+	Ea              EA
+}
+
+// RpzRule is a rule of a response policy zone, whatever the record type
+// the grid stores it as.
+type RpzRule struct {
+	Ref     string
+	Trigger string // DOMAIN, IP_ADDRESS or CLIENT_IP
+	Action  string // NXDOMAIN, NODATA, PASSTHRU, DROP or SUBSTITUTE
+	// Name is the domain name, IP address or network the rule applies to,
+	// without the name of the response policy zone.
+	Name   string
+	RpZone string
+	View   string
+	// Substitute is the domain name, IPv4 or IPv6 address or text
+	// returned by a SUBSTITUTE rule.
+	Substitute string
+	// SubstituteType is one of CNAME, A, AAAA or TXT; it is inferred from
+	// Substitute when empty.
+	SubstituteType string
+	Comment        string
+	Disable        bool
+	Ea             EA
+}
+
+// NewEmptyZoneRp returns a ZoneRp with the return fields used by the RPZ management methods.
+func NewEmptyZoneRp() *ZoneRp {
+	zone := &ZoneRp{}
+	zone.SetReturnFields(append(zone.ReturnFields(),
+		"comment", "disable", "extattrs", "grid_primary", "grid_secondaries", "locked",
+		"ns_group", "rpz_policy", "rpz_priority", "rpz_severity", "rpz_type", "substitute_name"))
+	return zone
+}
+
+// zoneRpPolicyUpdate sets the policy of a response policy zone, leaving its
+// other fields untouched. The substitute name is always sent, so that it is
+// cleared along with the SUBSTITUTE policy.
+type zoneRpPolicyUpdate struct {
+	IBBase         `json:"-"`
+	RpzPolicy      string `json:"rpz_policy"`
+	SubstituteName string `json:"substitute_name"`
+}
+
+func (zoneRpPolicyUpdate) ObjectType() string {
+	return "zone_rp"
+}
+
+func validateRpzPolicy(policy string, substituteName string) error {
+	switch policy {
+	case "", "GIVEN", "NXDOMAIN", "NODATA", "PASSTHRU", "DISABLED":
+		if substituteName != "" {
+			return fmt.Errorf("substitute name is only allowed with the SUBSTITUTE policy")
+		}
+	case "SUBSTITUTE":
+		if substituteName == "" {
+			return fmt.Errorf("substitute name is required by the SUBSTITUTE policy")
+		}
+		if err := ValidateDomainName(substituteName); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid RPZ policy '%s', must be one of GIVEN, NXDOMAIN, NODATA, PASSTHRU, SUBSTITUTE or DISABLED", policy)
+	}
+	return nil
+}
+
+func validateRpzZoneParams(params RpzZoneParams) error {
+	if params.Fqdn == "" {
+		return fmt.Errorf("FQDN is required to create a response policy zone")
+	}
+	if err := validateRpzPolicy(params.Policy, params.SubstituteName); err != nil {
+		return err
+	}
+	switch params.Severity {
+	case "", "CRITICAL", "MAJOR", "WARNING", "INFORMATIONAL":
+	default:
+		return fmt.Errorf("invalid RPZ severity '%s', must be one of CRITICAL, MAJOR, WARNING or INFORMATIONAL", params.Severity)
+	}
+	if params.NsGroup != "" && (len(params.GridPrimary) > 0 || len(params.GridSecondaries) > 0) {
+		return fmt.Errorf("name server group and grid primaries or secondaries are mutually exclusive")
+	}
+	return nil
+}
+
+// CreateRpzZone creates a local response policy zone with the given settings.
+func (objMgr *ObjectManager) CreateRpzZone(params RpzZoneParams) (*ZoneRp, error) {
+	if err := validateRpzZoneParams(params); err != nil {
+		return nil, err
+	}
+
+	zone := NewEmptyZoneRp()
+	zone.Fqdn = params.Fqdn
+	view := params.View
+	if view == "" {
+		view = "default"
+	}
+	zone.View = &view
+	zone.RpzPolicy = params.Policy
+	if params.SubstituteName != "" {
+		zone.SubstituteName = &params.SubstituteName
+	}
+	zone.RpzSeverity = params.Severity
+	if params.NsGroup != "" {
+		zone.NsGroup = &params.NsGroup
+	}
+	zone.GridPrimary = params.GridPrimary
+	zone.GridSecondaries = params.GridSecondaries
+	if params.Comment != "" {
+		zone.Comment = &params.Comment
+	}
+	zone.Disable = &params.Disable
+	zone.Ea = params.Ea
+
+	ref, err := objMgr.connector.CreateObject(zone)
+	if err != nil {
+		return nil, err
+	}
+	zone.Ref = ref
+	return zone, nil
+}
+
+// GetRpzZone returns the response policy zone with the given FQDN in the given DNS view.
+func (objMgr *ObjectManager) GetRpzZone(fqdn string, view string) (*ZoneRp, error) {
+	if fqdn == "" {
+		return nil, fmt.Errorf("FQDN of the response policy zone is required")
+	}
+	if view == "" {
+		view = "default"
+	}
+	var res []ZoneRp
+	sf := map[string]string{
+		"fqdn": fqdn,
+		"view": view,
+	}
+	err := objMgr.connector.GetObject(NewEmptyZoneRp(), "", NewQueryParams(false, sf), &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, NewNotFoundError(
+			fmt.Sprintf("response policy zone '%s' not found in DNS view '%s'", fqdn, view))
+	}
+	return &res[0], nil
+}
+
+// GetAllRpzZones returns the response policy zones of the given DNS view.
+func (objMgr *ObjectManager) GetAllRpzZones(view string) ([]ZoneRp, error) {
+	if view == "" {
+		view = "default"
+	}
+	var res []ZoneRp
+	sf := map[string]string{
+		"view": view,
+	}
+	err := objMgr.connector.GetObject(NewEmptyZoneRp(), "", NewQueryParams(false, sf), &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting response policy zones: %s", err)
+	}
+	return res, nil
+}
+
+// SetRpzZonePolicy overrides the policy of the rules of the response
+// policy zone; GIVEN applies the policy set by each rule.
+func (objMgr *ObjectManager) SetRpzZonePolicy(ref string, policy string, substituteName string) (*ZoneRp, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty reference to an object is not allowed")
+	}
+	if policy == "" {
+		return nil, fmt.Errorf("RPZ policy is required")
+	}
+	if err := validateRpzPolicy(policy, substituteName); err != nil {
+		return nil, err
+	}
+	update := &zoneRpPolicyUpdate{RpzPolicy: policy, SubstituteName: substituteName}
+	newRef, err := objMgr.connector.UpdateObject(update, ref)
+	if err != nil {
+		return nil, err
+	}
+	zone := &ZoneRp{Ref: newRef, RpzPolicy: policy}
+	if substituteName != "" {
+		zone.SubstituteName = &substituteName
+	}
+	return zone, nil
+}
+
+// DeleteRpzZone deletes the response policy zone together with its rules.
+func (objMgr *ObjectManager) DeleteRpzZone(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func newOrderedResponsePolicyZones() *Orderedresponsepolicyzones {
+	order := &Orderedresponsepolicyzones{}
+	order.SetReturnFields(append(order.ReturnFields(), "rp_zones"))
+	return order
+}
+
+func (objMgr *ObjectManager) getRpzZoneOrder(view string) (*Orderedresponsepolicyzones, error) {
+	if view == "" {
+		view = "default"
+	}
+	var res []Orderedresponsepolicyzones
+	sf := map[string]string{
+		"view": view,
+	}
+	err := objMgr.connector.GetObject(newOrderedResponsePolicyZones(), "", NewQueryParams(false, sf), &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, NewNotFoundError(fmt.Sprintf("order of response policy zones not found for DNS view '%s'", view))
+	}
+	return &res[0], nil
+}
+
+// GetRpzZoneOrder returns the names of the response policy zones of the
+// DNS view in the order they are applied.
+func (objMgr *ObjectManager) GetRpzZoneOrder(view string) ([]string, error) {
+	order, err := objMgr.getRpzZoneOrder(view)
+	if err != nil {
+		return nil, err
+	}
+	return order.RpZones, nil
+}
+
+// SetRpzZoneOrder sets the order in which the response policy zones of the
+// DNS view are applied. Every zone of the view must be listed, the grid
+// rejecting disabled zones and zones without a primary name server.
+func (objMgr *ObjectManager) SetRpzZoneOrder(view string, zones []string) (*Orderedresponsepolicyzones, error) {
+	seen := make(map[string]bool, len(zones))
+	for _, zone := range zones {
+		if seen[zone] {
+			return nil, fmt.Errorf("response policy zone '%s' is listed more than once", zone)
+		}
+		seen[zone] = true
+	}
+	current, err := objMgr.getRpzZoneOrder(view)
+	if err != nil {
+		return nil, err
+	}
+	order := newOrderedResponsePolicyZones()
+	order.RpZones = zones
+	ref, err := objMgr.connector.UpdateObject(order, current.Ref)
+	if err != nil {
+		return nil, err
+	}
+	order.Ref = ref
+	order.View = current.View
+	return order, nil
+}
+
+func validateRpzRule(rule RpzRule) error {
+	if rule.RpZone == "" {
+		return fmt.Errorf("response policy zone of the rule is required")
+	}
+	if rule.Name == "" {
+		return fmt.Errorf("name of the rule is required")
+	}
+	switch rule.Trigger {
+	case RpzTriggerDomain:
+		if err := ValidateDomainName(strings.TrimPrefix(rule.Name, "*.")); err != nil {
+			return err
+		}
+	case RpzTriggerIpAddress, RpzTriggerClientIp:
+		if net.ParseIP(rule.Name) == nil {
+			if _, _, err := net.ParseCIDR(rule.Name); err != nil {
+				return fmt.Errorf("'%s' is neither an IP address nor a network", rule.Name)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid RPZ trigger '%s', must be one of DOMAIN, IP_ADDRESS or CLIENT_IP", rule.Trigger)
+	}
+
+	switch rule.Action {
+	case RpzActionNxdomain, RpzActionNodata, RpzActionPassthru:
+	case RpzActionDrop:
+		if rule.Trigger != RpzTriggerClientIp {
+			return fmt.Errorf("the DROP action is only allowed for CLIENT_IP triggers")
+		}
+	case RpzActionSubstitute:
+		if rule.Substitute == "" {
+			return fmt.Errorf("substitute is required by the SUBSTITUTE action")
+		}
+		switch rpzSubstituteType(rule) {
+		case "CNAME":
+			if err := ValidateDomainName(rule.Substitute); err != nil {
+				return err
+			}
+		case "A":
+			if ip := net.ParseIP(rule.Substitute); ip == nil || ip.To4() == nil {
+				return fmt.Errorf("'%s' is not a valid IPv4 address", rule.Substitute)
+			}
+		case "AAAA":
+			if ip := net.ParseIP(rule.Substitute); ip == nil || ip.To4() != nil {
+				return fmt.Errorf("'%s' is not a valid IPv6 address", rule.Substitute)
+			}
+		case "TXT":
+		default:
+			return fmt.Errorf("invalid substitute type '%s', must be one of CNAME, A, AAAA or TXT", rule.SubstituteType)
+		}
+	default:
+		return fmt.Errorf("invalid RPZ action '%s', must be one of NXDOMAIN, NODATA, PASSTHRU, DROP or SUBSTITUTE", rule.Action)
+	}
+	return nil
+}
+
+func rpzSubstituteType(rule RpzRule) string {
+	if rule.SubstituteType != "" {
+		return rule.SubstituteType
+	}
+	if ip := net.ParseIP(rule.Substitute); ip != nil {
+		if ip.To4() != nil {
+			return "A"
+		}
+		return "AAAA"
+	}
+	return "CNAME"
+}
+
+// rpzCanonical returns the canonical name the grid uses to encode the
+// action of a CNAME based rule.
+func rpzCanonical(rule RpzRule) string {
+	switch rule.Action {
+	case RpzActionNxdomain:
+		return ""
+	case RpzActionNodata:
+		return "*"
+	case RpzActionPassthru:
+		if rule.Trigger == RpzTriggerClientIp {
+			return "rpz-passthru"
+		}
+		return rule.Name
+	case RpzActionDrop:
+		return "rpz-drop"
+	}
+	return rule.Substitute
+}
+
+// rpzRuleObject returns the record representing the rule on the grid.
+func rpzRuleObject(rule RpzRule) (IBObject, error) {
+	name := rule.Name + "." + rule.RpZone
+	view := rule.View
+	if view == "" {
+		view = "default"
+	}
+	comment := rule.Comment
+	disable := rule.Disable
+	canonical := rpzCanonical(rule)
+	substitute := rule.Substitute
+	substituteType := ""
+	if rule.Action == RpzActionSubstitute {
+		substituteType = rpzSubstituteType(rule)
+	}
+
+	switch rule.Trigger {
+	case RpzTriggerDomain:
+		switch substituteType {
+		case "A":
+			return &RecordRpzA{Name: &name, RpZone: &rule.RpZone, View: &view, Ipv4Addr: &substitute,
+				Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+		case "AAAA":
+			return &RecordRpzAaaa{Name: &name, RpZone: &rule.RpZone, View: &view, Ipv6Addr: &substitute,
+				Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+		case "TXT":
+			return &RecordRpzTxt{Name: &name, RpZone: &rule.RpZone, View: &view, Text: &substitute,
+				Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+		}
+		return &RecordRpzCname{Name: &name, RpZone: &rule.RpZone, View: &view, Canonical: &canonical,
+			Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+	case RpzTriggerIpAddress:
+		switch substituteType {
+		case "":
+			return &RecordRpzCnameIpaddress{Name: &name, RpZone: &rule.RpZone, View: &view, Canonical: &canonical,
+				Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+		case "CNAME":
+			return &RecordRpzCnameIpaddressdn{Name: &name, RpZone: &rule.RpZone, View: &view, Canonical: &canonical,
+				Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+		case "A":
+			return &RecordRpzAIpaddress{Name: &name, RpZone: &rule.RpZone, View: &view, Ipv4Addr: &substitute,
+				Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+		case "AAAA":
+			return &RecordRpzAaaaIpaddress{Name: &name, RpZone: &rule.RpZone, View: &view, Ipv6Addr: &substitute,
+				Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+		}
+	case RpzTriggerClientIp:
+		switch substituteType {
+		case "":
+			return &RecordRpzCnameClientipaddress{Name: &name, RpZone: &rule.RpZone, View: &view, Canonical: &canonical,
+				Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+		case "CNAME":
+			return &RecordRpzCnameClientipaddressdn{Name: &name, RpZone: &rule.RpZone, View: &view, Canonical: &canonical,
+				Comment: &comment, Disable: &disable, Ea: rule.Ea}, nil
+		}
+	}
+	return nil, fmt.Errorf("substitution by %s records is not supported for %s triggers", substituteType, rule.Trigger)
+}
+
+// CreateRpzRule adds a rule to a response policy zone.
+func (objMgr *ObjectManager) CreateRpzRule(rule RpzRule) (*RpzRule, error) {
+	if err := validateRpzRule(rule); err != nil {
+		return nil, err
+	}
+	obj, err := rpzRuleObject(rule)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := objMgr.connector.CreateObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	rule.Ref = ref
+	if rule.View == "" {
+		rule.View = "default"
+	}
+	if rule.Action == RpzActionSubstitute {
+		rule.SubstituteType = rpzSubstituteType(rule)
+	}
+	return &rule, nil
+}
+
+// DeleteRpzRule removes a rule of any kind from its response policy zone.
+func (objMgr *ObjectManager) DeleteRpzRule(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func newRpzRule(trigger string, ref string, name *string, rpZone string, view *string, comment *string, disable *bool, ea EA) RpzRule {
+	rule := RpzRule{Ref: ref, Trigger: trigger, RpZone: rpZone, Ea: ea}
+	if name != nil {
+		rule.Name = strings.TrimSuffix(*name, "."+rpZone)
+	}
+	if view != nil {
+		rule.View = *view
+	}
+	if comment != nil {
+		rule.Comment = *comment
+	}
+	if disable != nil {
+		rule.Disable = *disable
+	}
+	return rule
+}
+
+// setRpzCanonical sets the action of a CNAME based rule from its canonical name.
+func (rule *RpzRule) setRpzCanonical(canonical *string, substituteOnly bool) {
+	c := ""
+	if canonical != nil {
+		c = *canonical
+	}
+	switch {
+	case substituteOnly:
+		rule.Action = RpzActionSubstitute
+	case c == "":
+		rule.Action = RpzActionNxdomain
+	case c == "*":
+		rule.Action = RpzActionNodata
+	case c == "rpz-passthru" || c == rule.Name:
+		rule.Action = RpzActionPassthru
+	case c == "rpz-drop":
+		rule.Action = RpzActionDrop
+	default:
+		rule.Action = RpzActionSubstitute
+	}
+	if rule.Action == RpzActionSubstitute {
+		rule.Substitute = c
+		rule.SubstituteType = "CNAME"
+	}
+}
+
+func rpzRuleReturnFields(obj IBObject, fields ...string) {
+	obj.SetReturnFields(append(obj.ReturnFields(), append(fields, "comment", "disable", "extattrs")...))
+}
+
+// GetRpzRules returns the rules of every kind of the given response policy zone.
+func (objMgr *ObjectManager) GetRpzRules(rpZone string, view string) ([]RpzRule, error) {
+	if rpZone == "" {
+		return nil, fmt.Errorf("response policy zone is required")
+	}
+	if view == "" {
+		view = "default"
+	}
+	sf := map[string]string{"zone": rpZone, "view": view}
+	var rules []RpzRule
+	fail := func(err error) ([]RpzRule, error) {
+		return nil, fmt.Errorf("failed getting RPZ rules: %s", err)
+	}
+
+	var cnames []RecordRpzCname
+	cnameObj := &RecordRpzCname{}
+	rpzRuleReturnFields(cnameObj)
+	if err := objMgr.getAllObjects(cnameObj, sf, &cnames); err != nil {
+		return fail(err)
+	}
+	for _, r := range cnames {
+		rule := newRpzRule(RpzTriggerDomain, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.setRpzCanonical(r.Canonical, false)
+		rules = append(rules, rule)
+	}
+
+	var as []RecordRpzA
+	aObj := &RecordRpzA{}
+	rpzRuleReturnFields(aObj)
+	if err := objMgr.getAllObjects(aObj, sf, &as); err != nil {
+		return fail(err)
+	}
+	for _, r := range as {
+		rule := newRpzRule(RpzTriggerDomain, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.Action, rule.SubstituteType = RpzActionSubstitute, "A"
+		if r.Ipv4Addr != nil {
+			rule.Substitute = *r.Ipv4Addr
+		}
+		rules = append(rules, rule)
+	}
+
+	var aaaas []RecordRpzAaaa
+	aaaaObj := &RecordRpzAaaa{}
+	rpzRuleReturnFields(aaaaObj)
+	if err := objMgr.getAllObjects(aaaaObj, sf, &aaaas); err != nil {
+		return fail(err)
+	}
+	for _, r := range aaaas {
+		rule := newRpzRule(RpzTriggerDomain, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.Action, rule.SubstituteType = RpzActionSubstitute, "AAAA"
+		if r.Ipv6Addr != nil {
+			rule.Substitute = *r.Ipv6Addr
+		}
+		rules = append(rules, rule)
+	}
+
+	var txts []RecordRpzTxt
+	txtObj := &RecordRpzTxt{}
+	rpzRuleReturnFields(txtObj)
+	if err := objMgr.getAllObjects(txtObj, sf, &txts); err != nil {
+		return fail(err)
+	}
+	for _, r := range txts {
+		rule := newRpzRule(RpzTriggerDomain, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.Action, rule.SubstituteType = RpzActionSubstitute, "TXT"
+		if r.Text != nil {
+			rule.Substitute = *r.Text
+		}
+		rules = append(rules, rule)
+	}
+
+	var ipCnames []RecordRpzCnameIpaddress
+	ipCnameObj := &RecordRpzCnameIpaddress{}
+	rpzRuleReturnFields(ipCnameObj)
+	if err := objMgr.getAllObjects(ipCnameObj, sf, &ipCnames); err != nil {
+		return fail(err)
+	}
+	for _, r := range ipCnames {
+		rule := newRpzRule(RpzTriggerIpAddress, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.setRpzCanonical(r.Canonical, false)
+		rules = append(rules, rule)
+	}
+
+	var ipDns []RecordRpzCnameIpaddressdn
+	ipDnObj := &RecordRpzCnameIpaddressdn{}
+	rpzRuleReturnFields(ipDnObj)
+	if err := objMgr.getAllObjects(ipDnObj, sf, &ipDns); err != nil {
+		return fail(err)
+	}
+	for _, r := range ipDns {
+		rule := newRpzRule(RpzTriggerIpAddress, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.setRpzCanonical(r.Canonical, true)
+		rules = append(rules, rule)
+	}
+
+	var ipAs []RecordRpzAIpaddress
+	ipAObj := &RecordRpzAIpaddress{}
+	rpzRuleReturnFields(ipAObj)
+	if err := objMgr.getAllObjects(ipAObj, sf, &ipAs); err != nil {
+		return fail(err)
+	}
+	for _, r := range ipAs {
+		rule := newRpzRule(RpzTriggerIpAddress, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.Action, rule.SubstituteType = RpzActionSubstitute, "A"
+		if r.Ipv4Addr != nil {
+			rule.Substitute = *r.Ipv4Addr
+		}
+		rules = append(rules, rule)
+	}
+
+	var ipAaaas []RecordRpzAaaaIpaddress
+	ipAaaaObj := &RecordRpzAaaaIpaddress{}
+	rpzRuleReturnFields(ipAaaaObj)
+	if err := objMgr.getAllObjects(ipAaaaObj, sf, &ipAaaas); err != nil {
+		return fail(err)
+	}
+	for _, r := range ipAaaas {
+		rule := newRpzRule(RpzTriggerIpAddress, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.Action, rule.SubstituteType = RpzActionSubstitute, "AAAA"
+		if r.Ipv6Addr != nil {
+			rule.Substitute = *r.Ipv6Addr
+		}
+		rules = append(rules, rule)
+	}
+
+	var clientCnames []RecordRpzCnameClientipaddress
+	clientCnameObj := &RecordRpzCnameClientipaddress{}
+	rpzRuleReturnFields(clientCnameObj)
+	if err := objMgr.getAllObjects(clientCnameObj, sf, &clientCnames); err != nil {
+		return fail(err)
+	}
+	for _, r := range clientCnames {
+		rule := newRpzRule(RpzTriggerClientIp, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.setRpzCanonical(r.Canonical, false)
+		rules = append(rules, rule)
+	}
+
+	var clientDns []RecordRpzCnameClientipaddressdn
+	clientDnObj := &RecordRpzCnameClientipaddressdn{}
+	rpzRuleReturnFields(clientDnObj)
+	if err := objMgr.getAllObjects(clientDnObj, sf, &clientDns); err != nil {
+		return fail(err)
+	}
+	for _, r := range clientDns {
+		rule := newRpzRule(RpzTriggerClientIp, r.Ref, r.Name, rpZone, r.View, r.Comment, r.Disable, r.Ea)
+		rule.setRpzCanonical(r.Canonical, true)
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,203 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: response policy zone", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	rpZone := "rpz.example.com"
+	view := "default"
+	zoneRef := "zone_rp/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxlLnJweg:rpz.example.com/default"
+	ruleRef := "record:rpz:cname/ZG5zLmJpbmRfY25hbWUkLl9kZWZhdWx0LmNvbS5leGFtcGxlLnJwei5iYWQ:bad.com.rpz.example.com/default"
+
+	Describe("Create zone", func() {
+		substitute := "walled.example.com"
+		disable := false
+		expected := NewEmptyZoneRp()
+		expected.Fqdn = rpZone
+		expected.View = &view
+		expected.RpzPolicy = "SUBSTITUTE"
+		expected.SubstituteName = &substitute
+		expected.RpzSeverity = "MAJOR"
+		expected.GridPrimary = []*Memberserver{{Name: "infoblox.localdomain"}}
+		expected.Disable = &disable
+
+		conn := &fakeConnector{
+			createObjectObj: expected,
+			fakeRefReturn:   zoneRef,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected ZoneRp Object to CreateObject", func() {
+			zone, err := objMgr.CreateRpzZone(RpzZoneParams{
+				Fqdn:           rpZone,
+				Policy:         "SUBSTITUTE",
+				SubstituteName: substitute,
+				Severity:       "MAJOR",
+				GridPrimary:    []*Memberserver{{Name: "infoblox.localdomain"}},
+			})
+			Expect(err).To(BeNil())
+			Expect(zone.Ref).To(Equal(zoneRef))
+		})
+
+		It("should require a substitute name for the SUBSTITUTE policy", func() {
+			_, err := objMgr.CreateRpzZone(RpzZoneParams{Fqdn: rpZone, Policy: "SUBSTITUTE"})
+			Expect(err).To(Equal(fmt.Errorf("substitute name is required by the SUBSTITUTE policy")))
+		})
+	})
+
+	Describe("Set zone policy", func() {
+		conn := &fakeConnector{
+			updateObjectObj: &zoneRpPolicyUpdate{RpzPolicy: "NXDOMAIN"},
+			updateObjectRef: zoneRef,
+			fakeRefReturn:   zoneRef,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should override the policy of the zone", func() {
+			zone, err := objMgr.SetRpzZonePolicy(zoneRef, "NXDOMAIN", "")
+			Expect(err).To(BeNil())
+			Expect(zone.Ref).To(Equal(zoneRef))
+		})
+
+		It("should send only the policy, clearing the substitute name", func() {
+			data, err := json.Marshal(conn.updateObjectObj)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(`{"rpz_policy":"NXDOMAIN","substitute_name":""}`))
+		})
+
+		It("should reject an unknown policy", func() {
+			_, err := objMgr.SetRpzZonePolicy(zoneRef, "BLOCK", "")
+			Expect(err).To(Equal(fmt.Errorf("invalid RPZ policy 'BLOCK', must be one of GIVEN, NXDOMAIN, NODATA, PASSTHRU, SUBSTITUTE or DISABLED")))
+		})
+	})
+
+	Describe("Set zone order", func() {
+		orderRef := "orderedresponsepolicyzones/ZG5zLnZpZXckLl9kZWZhdWx0:default"
+		expected := newOrderedResponsePolicyZones()
+		expected.RpZones = []string{"rpz.example.com", "feed.example.com"}
+		conn := &fakeConnector{
+			getObjectObj:         newOrderedResponsePolicyZones(),
+			getObjectQueryParams: NewQueryParams(false, map[string]string{"view": view}),
+			getObjectRef:         "",
+			resultObject: []Orderedresponsepolicyzones{{
+				Ref: orderRef, View: &view, RpZones: []string{"feed.example.com", "rpz.example.com"}}},
+			updateObjectObj: expected,
+			updateObjectRef: orderRef,
+			fakeRefReturn:   orderRef,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the current order", func() {
+			zones, err := objMgr.GetRpzZoneOrder(view)
+			Expect(err).To(BeNil())
+			Expect(zones).To(Equal([]string{"feed.example.com", "rpz.example.com"}))
+		})
+
+		It("should update the order of the view", func() {
+			order, err := objMgr.SetRpzZoneOrder(view, []string{"rpz.example.com", "feed.example.com"})
+			Expect(err).To(BeNil())
+			Expect(order.Ref).To(Equal(orderRef))
+		})
+
+		It("should reject a zone listed twice", func() {
+			_, err := objMgr.SetRpzZoneOrder(view, []string{"rpz.example.com", "rpz.example.com"})
+			Expect(err).To(Equal(fmt.Errorf("response policy zone 'rpz.example.com' is listed more than once")))
+		})
+	})
+
+	Describe("Create rules", func() {
+		comment := ""
+		disable := false
+
+		It("should create a block (no data) rule", func() {
+			conn := &fakeConnector{
+				createObjectObj: &RecordRpzCname{
+					Name: utils.StringPtr("bad.com." + rpZone), RpZone: &rpZone, View: &view,
+					Canonical: utils.StringPtr("*"), Comment: &comment, Disable: &disable},
+				fakeRefReturn: ruleRef,
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			rule, err := objMgr.CreateRpzRule(RpzRule{Trigger: RpzTriggerDomain, Action: RpzActionNodata, Name: "bad.com", RpZone: rpZone})
+			Expect(err).To(BeNil())
+			Expect(rule.Ref).To(Equal(ruleRef))
+			Expect(rule.View).To(Equal(view))
+		})
+
+		It("should create a substitute rule for a response IP address", func() {
+			conn := &fakeConnector{
+				createObjectObj: &RecordRpzAIpaddress{
+					Name: utils.StringPtr("10.0.0.0/8." + rpZone), RpZone: &rpZone, View: &view,
+					Ipv4Addr: utils.StringPtr("192.0.2.1"), Comment: &comment, Disable: &disable},
+				fakeRefReturn: ruleRef,
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			rule, err := objMgr.CreateRpzRule(RpzRule{Trigger: RpzTriggerIpAddress, Action: RpzActionSubstitute,
+				Name: "10.0.0.0/8", RpZone: rpZone, Substitute: "192.0.2.1"})
+			Expect(err).To(BeNil())
+			Expect(rule.SubstituteType).To(Equal("A"))
+		})
+
+		It("should create a passthru rule for a client IP address", func() {
+			conn := &fakeConnector{
+				createObjectObj: &RecordRpzCnameClientipaddress{
+					Name: utils.StringPtr("192.168.1.10." + rpZone), RpZone: &rpZone, View: &view,
+					Canonical: utils.StringPtr("rpz-passthru"), Comment: &comment, Disable: &disable},
+				fakeRefReturn: ruleRef,
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			_, err := objMgr.CreateRpzRule(RpzRule{Trigger: RpzTriggerClientIp, Action: RpzActionPassthru, Name: "192.168.1.10", RpZone: rpZone})
+			Expect(err).To(BeNil())
+		})
+
+		It("should reject unsupported combinations", func() {
+			objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+			_, err := objMgr.CreateRpzRule(RpzRule{Trigger: RpzTriggerClientIp, Action: RpzActionSubstitute,
+				Name: "192.168.1.10", RpZone: rpZone, Substitute: "192.0.2.1"})
+			Expect(err).To(Equal(fmt.Errorf("substitution by A records is not supported for CLIENT_IP triggers")))
+			_, err = objMgr.CreateRpzRule(RpzRule{Trigger: RpzTriggerIpAddress, Action: RpzActionNxdomain, Name: "bad.com", RpZone: rpZone})
+			Expect(err).To(Equal(fmt.Errorf("'bad.com' is neither an IP address nor a network")))
+		})
+	})
+
+	Describe("List rules", func() {
+		conn := &fakeConnector{
+			getObjectObj: map[string]interface{}{"RecordRpzCname": nil},
+			resultObject: map[string]interface{}{
+				"RecordRpzCname": []RecordRpzCname{
+					{Ref: ruleRef, Name: utils.StringPtr("bad.com." + rpZone), View: &view, Canonical: utils.StringPtr("")},
+					{Ref: "record:rpz:cname/1", Name: utils.StringPtr("good.com." + rpZone), View: &view, Canonical: utils.StringPtr("good.com")},
+					{Ref: "record:rpz:cname/2", Name: utils.StringPtr("ads.com." + rpZone), View: &view, Canonical: utils.StringPtr("walled.example.com")},
+				},
+				"RecordRpzTxt": []RecordRpzTxt{
+					{Ref: "record:rpz:txt/1", Name: utils.StringPtr("txt.com." + rpZone), View: &view, Text: utils.StringPtr("blocked")},
+				},
+				"RecordRpzCnameClientipaddress": []RecordRpzCnameClientipaddress{
+					{Ref: "record:rpz:cname:clientipaddress/1", Name: utils.StringPtr("10.1.1.1." + rpZone), View: &view, Canonical: utils.StringPtr("rpz-drop")},
+				},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the rules of every kind", func() {
+			rules, err := objMgr.GetRpzRules(rpZone, view)
+			Expect(err).To(BeNil())
+			Expect(rules).To(Equal([]RpzRule{
+				{Ref: ruleRef, Trigger: RpzTriggerDomain, Action: RpzActionNxdomain, Name: "bad.com", RpZone: rpZone, View: view, Ea: EA{}},
+				{Ref: "record:rpz:cname/1", Trigger: RpzTriggerDomain, Action: RpzActionPassthru, Name: "good.com", RpZone: rpZone, View: view, Ea: EA{}},
+				{Ref: "record:rpz:cname/2", Trigger: RpzTriggerDomain, Action: RpzActionSubstitute, Name: "ads.com", RpZone: rpZone, View: view,
+					Substitute: "walled.example.com", SubstituteType: "CNAME", Ea: EA{}},
+				{Ref: "record:rpz:txt/1", Trigger: RpzTriggerDomain, Action: RpzActionSubstitute, Name: "txt.com", RpZone: rpZone, View: view,
+					Substitute: "blocked", SubstituteType: "TXT", Ea: EA{}},
+				{Ref: "record:rpz:cname:clientipaddress/1", Trigger: RpzTriggerClientIp, Action: RpzActionDrop, Name: "10.1.1.1", RpZone: rpZone, View: view, Ea: EA{}},
+			}))
+		})
+	})
+})
@@ -0,0 +1,178 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// SearchParams selects the objects returned by Search. At least one of
+// Address, Fqdn, MacAddress, SearchString or Ea must be given.
+type SearchParams struct {
+	Address      string
+	Fqdn         string
+	MacAddress   string
+	SearchString string // matched against the names, comments and EAs of the objects
+	Regex        bool   // Fqdn and SearchString are regular expressions
+	Ea           EA     // the objects must have all these EA values
+	ObjectTypes  []string
+	MaxResults   int // the maximum number of objects to return, 0 for the WAPI default
+}
+
+// SearchResult is an object found by Search. Object holds the decoded
+// object, such as a *RecordA or a *Network, if its type is known;
+// otherwise it is nil and Raw holds the object as returned by WAPI.
+type SearchResult struct {
+	ObjectType string
+	Ref        string
+	Object     IBObject
+	Raw        json.RawMessage
+}
+
+// searchResultTypes maps the WAPI object types to the objects search results are decoded into.
+var searchResultTypes = map[string]func() IBObject{
+	"record:a":             func() IBObject { return &RecordA{} },
+	"record:aaaa":          func() IBObject { return &RecordAAAA{} },
+	"record:alias":         func() IBObject { return &RecordAlias{} },
+	"record:caa":           func() IBObject { return &RecordCaa{} },
+	"record:cname":         func() IBObject { return &RecordCNAME{} },
+	"record:dhcid":         func() IBObject { return &RecordDhcid{} },
+	"record:dname":         func() IBObject { return &RecordDname{} },
+	"record:host":          func() IBObject { return &HostRecord{} },
+	"record:mx":            func() IBObject { return &RecordMX{} },
+	"record:naptr":         func() IBObject { return &RecordNaptr{} },
+	"record:ns":            func() IBObject { return &RecordNS{} },
+	"record:ptr":           func() IBObject { return &RecordPTR{} },
+	"record:srv":           func() IBObject { return &RecordSRV{} },
+	"record:tlsa":          func() IBObject { return &RecordTlsa{} },
+	"record:txt":           func() IBObject { return &RecordTXT{} },
+	"record:unknown":       func() IBObject { return &RecordUnknown{} },
+	"sharedrecord:a":       func() IBObject { return &SharedRecordA{} },
+	"sharedrecord:aaaa":    func() IBObject { return &SharedRecordAAAA{} },
+	"sharedrecord:mx":      func() IBObject { return &SharedRecordMX{} },
+	"sharedrecord:txt":     func() IBObject { return &SharedRecordTXT{} },
+	"network":              func() IBObject { return NewNetwork("", "", false, "", nil) },
+	"ipv6network":          func() IBObject { return NewNetwork("", "", true, "", nil) },
+	"networkcontainer":     func() IBObject { return NewNetworkContainer("", "", false, "", nil) },
+	"ipv6networkcontainer": func() IBObject { return NewNetworkContainer("", "", true, "", nil) },
+	"range":                func() IBObject { return &Range{} },
+	"ipv6range":            func() IBObject { return &IPv6Range{} },
+	"fixedaddress":         func() IBObject { return NewEmptyFixedAddress(false) },
+	"ipv6fixedaddress":     func() IBObject { return NewEmptyFixedAddress(true) },
+	"sharednetwork":        func() IBObject { return &SharedNetwork{} },
+	"ipv6sharednetwork":    func() IBObject { return &IPv6SharedNetwork{} },
+	"lease":                func() IBObject { return &Lease{} },
+	"macfilteraddress":     func() IBObject { return &MACFilterAddress{} },
+	"roaminghost":          func() IBObject { return &RoamingHost{} },
+	"networkview":          func() IBObject { return &NetworkView{} },
+	"view":                 func() IBObject { return &View{} },
+	"zone_auth":            func() IBObject { return &ZoneAuth{} },
+	"zone_delegated":       func() IBObject { return &ZoneDelegated{} },
+	"zone_forward":         func() IBObject { return &ZoneForward{} },
+	"zone_rp":              func() IBObject { return &ZoneRp{} },
+	"zone_stub":            func() IBObject { return &ZoneStub{} },
+}
+
+func searchFields(params SearchParams) (map[string]string, error) {
+	sf := make(map[string]string)
+	op := ""
+	if params.Regex {
+		op = "~"
+	}
+	if params.Address != "" {
+		if net.ParseIP(params.Address) == nil {
+			return nil, fmt.Errorf("'%s' is not a valid IP address", params.Address)
+		}
+		sf["address"] = params.Address
+	}
+	if params.Fqdn != "" {
+		sf["fqdn"+op] = params.Fqdn
+	}
+	if params.MacAddress != "" {
+		if _, err := net.ParseMAC(params.MacAddress); err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid MAC address", params.MacAddress)
+		}
+		sf["mac_address"] = params.MacAddress
+	}
+	if params.SearchString != "" {
+		sf["search_string"+op] = params.SearchString
+	}
+	for name, value := range params.Ea {
+		sf["*"+name] = fmt.Sprint(value)
+	}
+	if len(sf) == 0 {
+		return nil, fmt.Errorf("an address, FQDN, MAC address, search string or EA is required to search for objects")
+	}
+	// comma separated values are sent as several values of the same field
+	for name, value := range sf {
+		if strings.Contains(value, ",") {
+			return nil, fmt.Errorf("the value of the search field '%s' must not contain a comma", name)
+		}
+	}
+
+	for _, objType := range params.ObjectTypes {
+		if objType == "" || strings.Contains(objType, ",") {
+			return nil, fmt.Errorf("invalid object type '%s'", objType)
+		}
+	}
+	if len(params.ObjectTypes) > 0 {
+		sf["objtype"] = strings.Join(params.ObjectTypes, ",")
+	}
+	if params.MaxResults < 0 {
+		return nil, fmt.Errorf("the maximum number of results must not be negative")
+	}
+	if params.MaxResults > 0 {
+		sf["_max_results"] = strconv.Itoa(params.MaxResults)
+	}
+	return sf, nil
+}
+
+// decodeSearchResult decodes an object found by Search according to the type in its reference.
+func decodeSearchResult(raw json.RawMessage) (SearchResult, error) {
+	var base struct {
+		Ref string `json:"_ref"`
+	}
+	if err := json.Unmarshal(raw, &base); err != nil {
+		return SearchResult{}, err
+	}
+	res := SearchResult{
+		ObjectType: strings.SplitN(base.Ref, "/", 2)[0],
+		Ref:        base.Ref,
+		Raw:        raw,
+	}
+	newObj, ok := searchResultTypes[res.ObjectType]
+	if !ok {
+		return res, nil
+	}
+	obj := newObj()
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return SearchResult{}, fmt.Errorf("cannot decode the %s object '%s': %s", res.ObjectType, res.Ref, err)
+	}
+	res.Object = obj
+	return res, nil
+}
+
+// Search finds the objects of any type matching the given parameters,
+// using the WAPI search object.
+func (objMgr *ObjectManager) Search(params SearchParams) ([]SearchResult, error) {
+	sf, err := searchFields(params)
+	if err != nil {
+		return nil, err
+	}
+	var found []json.RawMessage
+	err = objMgr.connector.GetObject(&Search{}, "", NewQueryParams(false, sf), &found)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]SearchResult, 0, len(found))
+	for _, raw := range found {
+		result, err := decodeSearchResult(raw)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, result)
+	}
+	return res, nil
+}
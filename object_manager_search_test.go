@@ -0,0 +1,103 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: search", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	aRef := "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUsYXBwLDEwLjEuMi4z:app.example.com/default"
+	netRef := "network/ZG5zLm5ldHdvcmskMTAuMS4yLjAvMjQvMA:10.1.2.0/24/default"
+	leaseRef := "lease/ZG5zLmxlYXNlJDEwLjEuMi4z:10.1.2.3/default"
+	groupRef := "admingroup/b25lLmFkbWluX2dyb3VwJC5hZG1pbi1ncm91cA:admin-group"
+
+	Describe("Search by address", func() {
+		conn := &fakeConnector{
+			getObjectObj: &Search{},
+			getObjectQueryParams: NewQueryParams(false, map[string]string{
+				"address":      "10.1.2.3",
+				"objtype":      "record:a,network,lease,admingroup",
+				"_max_results": "10",
+			}),
+			getObjectRef: "",
+			resultObject: []json.RawMessage{
+				json.RawMessage(`{"_ref":"` + aRef + `","ipv4addr":"10.1.2.3","name":"app.example.com","view":"default"}`),
+				json.RawMessage(`{"_ref":"` + netRef + `","network":"10.1.2.0/24","network_view":"default","comment":"apps"}`),
+				json.RawMessage(`{"_ref":"` + leaseRef + `","address":"10.1.2.3","network_view":"default"}`),
+				json.RawMessage(`{"_ref":"` + groupRef + `"}`),
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should decode each object into its type", func() {
+			res, err := objMgr.Search(SearchParams{
+				Address:     "10.1.2.3",
+				ObjectTypes: []string{"record:a", "network", "lease", "admingroup"},
+				MaxResults:  10,
+			})
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(4))
+
+			Expect(res[0].ObjectType).To(Equal("record:a"))
+			Expect(res[0].Object).To(Equal(&RecordA{
+				Ref: aRef, Ipv4Addr: utils.StringPtr("10.1.2.3"), Name: utils.StringPtr("app.example.com"), View: "default"}))
+
+			network, ok := res[1].Object.(*Network)
+			Expect(ok).To(BeTrue())
+			Expect(network.Cidr).To(Equal("10.1.2.0/24"))
+			Expect(network.ObjectType()).To(Equal("network"))
+
+			lease, ok := res[2].Object.(*Lease)
+			Expect(ok).To(BeTrue())
+			Expect(lease.Ref).To(Equal(leaseRef))
+
+			Expect(res[3].ObjectType).To(Equal("admingroup"))
+			Expect(res[3].Object).To(BeNil())
+			Expect(res[3].Raw).To(Equal(json.RawMessage(`{"_ref":"` + groupRef + `"}`)))
+		})
+	})
+
+	Describe("Search by FQDN and EA", func() {
+		conn := &fakeConnector{
+			getObjectObj: &Search{},
+			getObjectQueryParams: NewQueryParams(false, map[string]string{
+				"fqdn~": "^app\\.",
+				"*Site": "Santa Clara",
+			}),
+			getObjectRef: "",
+			resultObject: []json.RawMessage{},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should use regular expressions", func() {
+			res, err := objMgr.Search(SearchParams{Fqdn: "^app\\.", Regex: true, Ea: EA{"Site": "Santa Clara"}})
+			Expect(err).To(BeNil())
+			Expect(res).To(BeEmpty())
+		})
+	})
+
+	Describe("Validation", func() {
+		objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+
+		It("should require a search criterion", func() {
+			_, err := objMgr.Search(SearchParams{ObjectTypes: []string{"record:a"}})
+			Expect(err).To(Equal(fmt.Errorf("an address, FQDN, MAC address, search string or EA is required to search for objects")))
+		})
+
+		It("should reject an invalid MAC address", func() {
+			_, err := objMgr.Search(SearchParams{MacAddress: "00:11:22"})
+			Expect(err).To(Equal(fmt.Errorf("'00:11:22' is not a valid MAC address")))
+		})
+
+		It("should reject values containing a comma", func() {
+			_, err := objMgr.Search(SearchParams{SearchString: "a{1,3}", Regex: true})
+			Expect(err).To(Equal(fmt.Errorf("the value of the search field 'search_string~' must not contain a comma")))
+		})
+	})
+})
@@ -0,0 +1,197 @@
+package ibclient
+
+import (
+	"fmt"
+)
+
+// zoneStubUpdate is the ZoneStub sent on update. The server lists and the
+// name server groups are always sent, so that the side which is not used
+// is cleared: empty lists and null groups.
+type zoneStubUpdate struct {
+	IBBase          `json:"-"`
+	Comment         *string         `json:"comment,omitempty"`
+	Disable         *bool           `json:"disable,omitempty"`
+	Locked          *bool           `json:"locked,omitempty"`
+	Ea              EA              `json:"extattrs"`
+	StubFrom        []NameServer    `json:"stub_from"`
+	StubMembers     []*Memberserver `json:"stub_members"`
+	NsGroup         *string         `json:"ns_group"`
+	ExternalNsGroup *string         `json:"external_ns_group"`
+}
+
+func (zoneStubUpdate) ObjectType() string {
+	return "zone_stub"
+}
+
+func validateZoneStubArgs(stubFrom []NameServer, stubMembers []*Memberserver, nsGroup string, externalNsGroup string) error {
+	if len(stubFrom) > 0 && externalNsGroup != "" {
+		return fmt.Errorf("stub_from and external_ns_group are mutually exclusive")
+	}
+	if len(stubFrom) == 0 && externalNsGroup == "" {
+		return fmt.Errorf("either stub_from or external_ns_group is required to get the zone data from")
+	}
+	if len(stubMembers) > 0 && nsGroup != "" {
+		return fmt.Errorf("stub_members and ns_group are mutually exclusive")
+	}
+	if len(stubMembers) == 0 && nsGroup == "" {
+		return fmt.Errorf("either stub_members or ns_group is required to serve the zone")
+	}
+	if err := validateNsgroupExternalServers("stub_from", stubFrom); err != nil {
+		return err
+	}
+	return validateNsgroupMembers("stub_members", stubMembers, make(map[string]bool))
+}
+
+func (objMgr *ObjectManager) CreateZoneStub(
+	comment string,
+	disable bool,
+	locked bool,
+	eas EA,
+	stubFrom []NameServer,
+	stubMembers []*Memberserver,
+	fqdn string,
+	nsGroup string,
+	externalNsGroup string,
+	view string,
+	zoneFormat string,
+	prefix string) (*ZoneStub, error) {
+	if fqdn == "" {
+		return nil, fmt.Errorf("FQDN is required to create a stub zone")
+	}
+	if err := validateZoneStubArgs(stubFrom, stubMembers, nsGroup, externalNsGroup); err != nil {
+		return nil, err
+	}
+	if prefix != "" && zoneFormat != "IPV4" {
+		return nil, fmt.Errorf("prefix is only allowed for IPV4 reverse zones")
+	}
+	zoneStub := NewZoneStub(comment, disable, locked, eas, stubFrom, stubMembers, fqdn, nsGroup, externalNsGroup, view, zoneFormat, prefix)
+	ref, err := objMgr.connector.CreateObject(zoneStub)
+	if err != nil {
+		return nil, err
+	}
+	zoneStub.Ref = ref
+	return zoneStub, nil
+}
+
+func (objMgr *ObjectManager) DeleteZoneStub(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
+
+func (objMgr *ObjectManager) GetZoneStubByRef(ref string) (*ZoneStub, error) {
+	zoneStub := NewEmptyZoneStub()
+	err := objMgr.connector.GetObject(zoneStub, ref, NewQueryParams(false, nil), &zoneStub)
+	if err != nil {
+		return nil, err
+	}
+	return zoneStub, nil
+}
+
+func (objMgr *ObjectManager) GetZoneStubFilters(queryParams *QueryParams) ([]ZoneStub, error) {
+	var res []ZoneStub
+	zoneStub := NewEmptyZoneStub()
+
+	err := objMgr.connector.GetObject(
+		zoneStub, "", queryParams, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, err
+}
+
+func (objMgr *ObjectManager) UpdateZoneStub(
+	ref string,
+	comment string,
+	disable bool,
+	locked bool,
+	eas EA,
+	stubFrom []NameServer,
+	stubMembers []*Memberserver,
+	nsGroup string,
+	externalNsGroup string) (*ZoneStub, error) {
+	if err := validateZoneStubArgs(stubFrom, stubMembers, nsGroup, externalNsGroup); err != nil {
+		return nil, err
+	}
+
+	upd := &zoneStubUpdate{
+		Comment:     &comment,
+		Disable:     &disable,
+		Locked:      &locked,
+		Ea:          eas,
+		StubFrom:    stubFrom,
+		StubMembers: stubMembers,
+	}
+	if nsGroup != "" {
+		upd.NsGroup = &nsGroup
+	}
+	if externalNsGroup != "" {
+		upd.ExternalNsGroup = &externalNsGroup
+	}
+
+	newRef, err := objMgr.connector.UpdateObject(upd, ref)
+	if err != nil {
+		return nil, err
+	}
+	zoneStub := NewEmptyZoneStub()
+	zoneStub.Ref = newRef
+	zoneStub.Comment = upd.Comment
+	zoneStub.Disable = upd.Disable
+	zoneStub.Locked = upd.Locked
+	zoneStub.Ea = eas
+	zoneStub.StubFrom = stubFrom
+	zoneStub.StubMembers = stubMembers
+	zoneStub.NsGroup = upd.NsGroup
+	zoneStub.ExternalNsGroup = upd.ExternalNsGroup
+	return zoneStub, nil
+}
+
+func NewEmptyZoneStub() *ZoneStub {
+	zoneStub := &ZoneStub{}
+	zoneStub.SetReturnFields(append(zoneStub.ReturnFields(), "zone_format", "prefix", "ns_group", "external_ns_group",
+		"stub_members", "comment", "disable", "locked", "extattrs"))
+	return zoneStub
+}
+
+func NewZoneStub(comment string,
+	disable bool,
+	locked bool,
+	eas EA,
+	stubFrom []NameServer,
+	stubMembers []*Memberserver,
+	fqdn string,
+	nsGroup string,
+	externalNsGroup string,
+	view string,
+	zoneFormat string,
+	prefix string) *ZoneStub {
+
+	zoneStub := NewEmptyZoneStub()
+
+	zoneStub.Comment = &comment
+	zoneStub.Disable = &disable
+	zoneStub.Locked = &locked
+	zoneStub.Ea = eas
+	zoneStub.StubFrom = stubFrom
+	zoneStub.StubMembers = stubMembers
+
+	zoneStub.Fqdn = fqdn
+	if nsGroup != "" {
+		zoneStub.NsGroup = &nsGroup
+	}
+	if externalNsGroup != "" {
+		zoneStub.ExternalNsGroup = &externalNsGroup
+	}
+	if view == "" {
+		view = "default"
+	}
+	zoneStub.View = &view
+	if zoneFormat == "" {
+		zoneFormat = "FORWARD"
+	}
+	zoneStub.ZoneFormat = zoneFormat
+	if prefix != "" {
+		zoneStub.Prefix = &prefix
+	}
+
+	return zoneStub
+}
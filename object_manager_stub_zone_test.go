@@ -0,0 +1,113 @@
+package ibclient
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: stub zone", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	fqdn := "partner.example.com"
+	stubFrom := []NameServer{{Name: "ns1.partner.example.com", Address: "198.51.100.53"}}
+	stubMembers := []*Memberserver{{Name: "infoblox.localdomain"}}
+	fakeRefReturn := fmt.Sprintf("zone_stub/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxlLnBhcnRuZXI:%s/default", fqdn)
+
+	Describe("Create Stub Zone", func() {
+		conn := &fakeConnector{
+			createObjectObj: NewZoneStub("partner", false, false, nil, stubFrom, stubMembers, fqdn, "", "", "", "", ""),
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected Stub Zone Object to CreateObject", func() {
+			zoneStub, err := objMgr.CreateZoneStub("partner", false, false, nil, stubFrom, stubMembers, fqdn, "", "", "", "", "")
+			Expect(err).To(BeNil())
+			Expect(zoneStub.Ref).To(Equal(fakeRefReturn))
+			Expect(*zoneStub.View).To(Equal("default"))
+		})
+
+		It("should return an error if required fields are not passed", func() {
+			_, err := objMgr.CreateZoneStub("", false, false, nil, stubFrom, stubMembers, "", "", "", "", "", "")
+			Expect(err).To(Equal(fmt.Errorf("FQDN is required to create a stub zone")))
+			_, err = objMgr.CreateZoneStub("", false, false, nil, nil, stubMembers, fqdn, "", "", "", "", "")
+			Expect(err).To(Equal(fmt.Errorf("either stub_from or external_ns_group is required to get the zone data from")))
+		})
+
+		It("should reject both stub members and a name server group", func() {
+			_, err := objMgr.CreateZoneStub("", false, false, nil, stubFrom, stubMembers, fqdn, "stub-members", "", "", "", "")
+			Expect(err).To(Equal(fmt.Errorf("stub_members and ns_group are mutually exclusive")))
+		})
+	})
+
+	Describe("Get Stub Zone", func() {
+		queryParams := NewQueryParams(false, map[string]string{"fqdn": fqdn})
+		conn := &fakeConnector{
+			getObjectObj:         NewEmptyZoneStub(),
+			getObjectQueryParams: queryParams,
+			getObjectRef:         "",
+			resultObject:         []ZoneStub{*NewZoneStub("", false, false, nil, stubFrom, stubMembers, fqdn, "", "", "", "", "")},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the stub zones matching the filters", func() {
+			res, err := objMgr.GetZoneStubFilters(queryParams)
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal(conn.resultObject))
+		})
+	})
+
+	Describe("Update Stub Zone", func() {
+		locked := true
+		disable := false
+		comment := "locked"
+		nsGroup := "stub-members"
+		expected := &zoneStubUpdate{
+			Comment:  &comment,
+			Disable:  &disable,
+			Locked:   &locked,
+			StubFrom: stubFrom,
+			NsGroup:  &nsGroup,
+		}
+		conn := &fakeConnector{
+			updateObjectObj: expected,
+			updateObjectRef: fakeRefReturn,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected Stub Zone Object to UpdateObject", func() {
+			zoneStub, err := objMgr.UpdateZoneStub(fakeRefReturn, comment, false, true, nil, stubFrom, nil, nsGroup, "")
+			Expect(err).To(BeNil())
+			Expect(zoneStub.Ref).To(Equal(fakeRefReturn))
+			Expect(*zoneStub.NsGroup).To(Equal(nsGroup))
+		})
+
+		It("should clear the members and the external group which are not used", func() {
+			body := (&WapiRequestBuilder{}).BuildBody(UPDATE, &zoneStubUpdate{
+				Comment:  &comment,
+				StubFrom: stubFrom,
+				NsGroup:  &nsGroup,
+			})
+			Expect(string(body)).To(Equal(`{"comment":"locked","extattrs":{},` +
+				`"stub_from":[{"address":"198.51.100.53","name":"ns1.partner.example.com"}],` +
+				`"stub_members":[],"ns_group":"stub-members","external_ns_group":null}`))
+		})
+	})
+
+	Describe("Delete Stub Zone", func() {
+		conn := &fakeConnector{
+			deleteObjectRef: fakeRefReturn,
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected reference to DeleteObject", func() {
+			ref, err := objMgr.DeleteZoneStub(fakeRefReturn)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(fakeRefReturn))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package ibclient
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	. "github.com/onsi/ginkgo/v2"
@@ -30,11 +31,26 @@ type fakeConnector struct {
 	// A reference to be returned by Create/Update/Delete (not Get) methods.
 	fakeRefReturn string
 
+	// expected reference, function name and arguments to be passed to CallFunction()
+	// and the result it is to return.
+	callFunctionRef    string
+	callFunctionName   string
+	callFunctionArgs   interface{}
+	callFunctionResult interface{}
+
+	// expected file name and content to be passed to UploadFile() and the
+	// token it is to return.
+	uploadFileName  string
+	uploadFileData  []byte
+	uploadFileToken string
+
 	// Error which fake Connector is to return on appropriate method call.
 	createObjectError error
 	getObjectError    error
 	updateObjectError error
 	deleteObjectError error
+	callFunctionError error
+	uploadFileError   error
 }
 
 func (c *fakeConnector) CreateObject(obj IBObject) (string, error) {
@@ -83,6 +99,18 @@ Describe("Create Dtc Lbdn with maximum parameters", func() {
 })
 */
 
+// newFakeWapiPage returns a page holding the objects of the slice.
+func newFakeWapiPage(objects interface{}) wapiPage {
+	var page wapiPage
+	rv := reflect.ValueOf(objects)
+	for i := 0; i < rv.Len(); i++ {
+		data, err := json.Marshal(rv.Index(i).Interface())
+		Expect(err).To(BeNil())
+		page.Result = append(page.Result, data)
+	}
+	return page
+}
+
 func (c *fakeConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res interface{}) (err error) {
 
 	if reflect.TypeOf(c.getObjectObj).Kind() == reflect.Map { //&& c.skipInternalGetcalls {
@@ -96,13 +124,52 @@ func (c *fakeConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res
 		case *DtcTopology:
 			*res.(*[]DtcTopology) = c.resultObject.(map[string]interface{})["DtcTopology"].([]DtcTopology)
 		case *ZoneAuth:
+			if expected, ok := c.getObjectObj.(map[string]interface{})["ZoneAuth"]; ok {
+				Expect(obj).To(Equal(expected))
+			}
 			*res.(*[]ZoneAuth) = c.resultObject.(map[string]interface{})["ZoneAuth"].([]ZoneAuth)
 		case *DtcServer:
-			*res.(*[]DtcServer) = c.resultObject.(map[string]interface{})["DtcServer"].([]DtcServer)
-		case *DtcMonitorHttp:
-			*res.(*[]DtcMonitorHttp) = c.resultObject.(map[string]interface{})["DtcMonitor"].([]DtcMonitorHttp)
+			if servers, ok := res.(*[]DtcServer); ok {
+				*servers = c.resultObject.(map[string]interface{})["DtcServer"].([]DtcServer)
+			} else {
+				**res.(**DtcServer) = *c.resultObject.(map[string]interface{})["DtcServer"].(*DtcServer)
+			}
+		case *DtcMonitorHttp, *DtcMonitorIcmp, *DtcMonitorTcp, *DtcMonitorSip, *DtcMonitorPdp, *DtcMonitorSnmp:
+			reflect.ValueOf(res).Elem().Set(reflect.ValueOf(c.resultObject.(map[string]interface{})["DtcMonitor"]))
 		case *DtcLbdn:
 			**res.(**DtcLbdn) = *c.resultObject.(map[string]interface{})["DtcLbdn"].(*DtcLbdn)
+		case *eaObject:
+			if page, ok := res.(*wapiPage); ok {
+				*page = c.resultObject.(map[string]interface{})["EAObjects"].(wapiPage)
+			} else if ref == "" {
+				*res.(*[]eaObject) = c.resultObject.(map[string]interface{})["EAObjects"].([]eaObject)
+			} else {
+				**res.(**eaObject) = *c.resultObject.(map[string]interface{})["EAObject"].(*eaObject)
+			}
+		case *EADefinition:
+			*res.(*[]EADefinition) = c.resultObject.(map[string]interface{})["EADefinition"].([]EADefinition)
+		case *RecordA, *RecordAAAA, *RecordCNAME, *RecordMX, *RecordSRV, *RecordTXT, *RecordPTR,
+			*RecordNS, *RecordCaa, *RecordNaptr, *HostRecord,
+			*RecordRpzCname, *RecordRpzA, *RecordRpzAaaa, *RecordRpzTxt, *RecordRpzCnameIpaddress,
+			*RecordRpzCnameIpaddressdn, *RecordRpzAIpaddress, *RecordRpzAaaaIpaddress,
+			*RecordRpzCnameClientipaddress, *RecordRpzCnameClientipaddressdn,
+			*Allrecords, *SharedRecordA, *DtcTopologyRule, *DtcObject,
+			*DtcRecordA, *DtcRecordAaaa, *DtcRecordCname, *DtcRecordNaptr, *DtcRecordSrv,
+			*DtcCertificate, *GridX509certificate, *Nsgroup:
+			// zone file, RPZ and zone listing tests only provide the record types present in the zone
+			val, ok := c.resultObject.(map[string]interface{})[reflect.TypeOf(obj).Elem().Name()]
+			if !ok {
+				return NewNotFoundError("not found")
+			}
+			if page, ok := res.(*wapiPage); ok {
+				if _, ok = val.(wapiPage); !ok {
+					*page = newFakeWapiPage(val)
+					return nil
+				}
+			}
+			reflect.ValueOf(res).Elem().Set(reflect.ValueOf(val))
+		case *guardObject:
+			*res.(*map[string]interface{}) = c.resultObject.(map[string]interface{})["Guard"].(map[string]interface{})
 		default:
 			return fmt.Errorf("unsupported object type")
 		}
@@ -111,7 +178,9 @@ func (c *fakeConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res
 		Expect(qp).To(Equal(c.getObjectQueryParams))
 		Expect(ref).To(Equal(c.getObjectRef))
 
-		if ref == "" {
+		if page, ok := res.(*wapiPage); ok {
+			*page = c.resultObject.(wapiPage)
+		} else if ref == "" {
 			switch obj.(type) {
 			case *NetworkView:
 				*res.(*[]NetworkView) = c.resultObject.([]NetworkView)
@@ -123,6 +192,17 @@ func (c *fakeConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res
 				*res.(*[]FixedAddress) = c.resultObject.([]FixedAddress)
 			case *EADefinition:
 				*res.(*[]EADefinition) = c.resultObject.([]EADefinition)
+			case *ZoneAuth:
+				*res.(*[]ZoneAuth) = c.resultObject.([]ZoneAuth)
+			case *Allrecords:
+				*res.(*[]Allrecords) = c.resultObject.([]Allrecords)
+			case *RecordDnskey:
+				*res.(*[]RecordDnskey) = c.resultObject.([]RecordDnskey)
+			case *Search:
+				*res.(*[]json.RawMessage) = c.resultObject.([]json.RawMessage)
+			case *DtcMonitorHttp, *DtcMonitorIcmp, *DtcMonitorTcp, *DtcMonitorSip, *DtcMonitorPdp, *DtcMonitorSnmp,
+				*DtcRecordA, *DtcRecordAaaa, *DtcRecordCname, *DtcRecordNaptr, *DtcRecordSrv:
+				reflect.ValueOf(res).Elem().Set(reflect.ValueOf(c.resultObject))
 			case *CapacityReport:
 				*res.(*[]CapacityReport) = c.resultObject.([]CapacityReport)
 			case *UpgradeStatus:
@@ -153,6 +233,8 @@ func (c *fakeConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res
 				*res.(*[]RecordMX) = c.resultObject.([]RecordMX)
 			case *ZoneForward:
 				*res.(*[]ZoneForward) = c.resultObject.([]ZoneForward)
+			case *ZoneStub:
+				*res.(*[]ZoneStub) = c.resultObject.([]ZoneStub)
 			case *DtcLbdn:
 				*res.(*[]DtcLbdn) = c.resultObject.([]DtcLbdn)
 			case *DtcPool:
@@ -171,11 +253,32 @@ func (c *fakeConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res
 				*res.(*[]RecordAlias) = c.resultObject.([]RecordAlias)
 			case *Rangetemplate:
 				*res.(*[]Rangetemplate) = c.resultObject.([]Rangetemplate)
+			case *IPv4Address:
+				*res.(*[]IPv4Address) = c.resultObject.([]IPv4Address)
+			case *IPv6Address:
+				*res.(*[]IPv6Address) = c.resultObject.([]IPv6Address)
+			case *IPv6Range:
+				*res.(*[]IPv6Range) = c.resultObject.([]IPv6Range)
+			case *IPv6SharedNetwork:
+				*res.(*[]IPv6SharedNetwork) = c.resultObject.([]IPv6SharedNetwork)
+			case *Ipv6rangetemplate:
+				*res.(*[]Ipv6rangetemplate) = c.resultObject.([]Ipv6rangetemplate)
+			case *Ipv6fixedaddresstemplate:
+				*res.(*[]Ipv6fixedaddresstemplate) = c.resultObject.([]Ipv6fixedaddresstemplate)
+			case *eaObject:
+				*res.(*[]eaObject) = c.resultObject.([]eaObject)
+			case *ZoneRp:
+				*res.(*[]ZoneRp) = c.resultObject.([]ZoneRp)
+			case *Orderedresponsepolicyzones:
+				*res.(*[]Orderedresponsepolicyzones) = c.resultObject.([]Orderedresponsepolicyzones)
 			}
 		} else {
 			switch obj.(type) {
 			case *ZoneAuth:
 				*res.(*ZoneAuth) = *c.resultObject.(*ZoneAuth)
+			case *DtcMonitorHttp, *DtcMonitorIcmp, *DtcMonitorTcp, *DtcMonitorSip, *DtcMonitorPdp, *DtcMonitorSnmp,
+				*DtcRecordA, *DtcRecordAaaa, *DtcRecordCname, *DtcRecordNaptr, *DtcRecordSrv:
+				reflect.ValueOf(res).Elem().Set(reflect.ValueOf(c.resultObject).Elem())
 			case *NetworkView:
 				*res.(*NetworkView) = *c.resultObject.(*NetworkView)
 			case *NetworkContainer:
@@ -206,6 +309,8 @@ func (c *fakeConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res
 				*res.(*[]Dhcp) = c.resultObject.([]Dhcp)
 			case *ZoneForward:
 				*res.(**ZoneForward) = c.resultObject.(*ZoneForward)
+			case *ZoneStub:
+				*res.(**ZoneStub) = c.resultObject.(*ZoneStub)
 			case *ZoneDelegated:
 				*res.(**ZoneDelegated) = c.resultObject.(*ZoneDelegated)
 			case *DtcLbdn:
@@ -220,6 +325,20 @@ func (c *fakeConnector) GetObject(obj IBObject, ref string, qp *QueryParams, res
 				**res.(**RecordNS) = *c.resultObject.(*RecordNS)
 			case *Rangetemplate:
 				**res.(**Rangetemplate) = *c.resultObject.(*Rangetemplate)
+			case *IPv6Range:
+				**res.(**IPv6Range) = *c.resultObject.(*IPv6Range)
+			case *IPv6SharedNetwork:
+				**res.(**IPv6SharedNetwork) = *c.resultObject.(*IPv6SharedNetwork)
+			case *Ipv6rangetemplate:
+				**res.(**Ipv6rangetemplate) = *c.resultObject.(*Ipv6rangetemplate)
+			case *Ipv6fixedaddresstemplate:
+				**res.(**Ipv6fixedaddresstemplate) = *c.resultObject.(*Ipv6fixedaddresstemplate)
+			case *EADefinition:
+				**res.(**EADefinition) = *c.resultObject.(*EADefinition)
+			case *View:
+				**res.(**View) = *c.resultObject.(*View)
+			case *guardObject:
+				*res.(*map[string]interface{}) = c.resultObject.(map[string]interface{})
 			}
 		}
 	}
@@ -241,6 +360,26 @@ func (c *fakeConnector) UpdateObject(obj IBObject, ref string) (string, error) {
 	return c.fakeRefReturn, c.updateObjectError
 }
 
+func (c *fakeConnector) CallFunction(ref string, function string, args interface{}, res interface{}) error {
+	Expect(ref).To(Equal(c.callFunctionRef))
+	Expect(function).To(Equal(c.callFunctionName))
+	Expect(args).To(Equal(c.callFunctionArgs))
+
+	if res != nil && c.callFunctionResult != nil {
+		data, err := json.Marshal(c.callFunctionResult)
+		Expect(err).To(BeNil())
+		Expect(json.Unmarshal(data, res)).To(BeNil())
+	}
+	return c.callFunctionError
+}
+
+func (c *fakeConnector) UploadFile(filename string, data []byte) (string, error) {
+	Expect(filename).To(Equal(c.uploadFileName))
+	Expect(data).To(Equal(c.uploadFileData))
+
+	return c.uploadFileToken, c.uploadFileError
+}
+
 var _ = Describe("Object Manager", func() {
 	Describe("Get Capacity report", func() {
 		cmpType := "Heka"
@@ -0,0 +1,128 @@
+package ibclient
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var tlsaNameRegExp = regexp.MustCompile(`^_[0-9]{1,5}\._(tcp|udp|sctp)\.`)
+
+func validateTlsaRecArgs(name string, certificateUsage uint32, selector uint32, matchedType uint32, certificateData string) error {
+	if name == "" {
+		return fmt.Errorf("'name' must not be empty")
+	}
+	if !tlsaNameRegExp.MatchString(name) {
+		return fmt.Errorf("TLSA-record's name '%s' does not conform to standards, expected '_<port>._<protocol>.<host>'", name)
+	}
+	if err := ValidateDomainName(tlsaNameRegExp.ReplaceAllString(name, "")); err != nil {
+		return err
+	}
+	if certificateUsage > 3 {
+		return fmt.Errorf("'certificate_usage' must be in the range from 0 to 3 inclusively")
+	}
+	if selector > 1 {
+		return fmt.Errorf("'selector' must be 0 (full certificate) or 1 (subject public key)")
+	}
+	if matchedType > 2 {
+		return fmt.Errorf("'matched_type' must be 0 (exact match), 1 (SHA-256) or 2 (SHA-512)")
+	}
+	if certificateData == "" {
+		return fmt.Errorf("'certificate_data' must not be empty")
+	}
+	data, err := hex.DecodeString(certificateData)
+	if err != nil {
+		return fmt.Errorf("'certificate_data' must be a hexadecimal string")
+	}
+	if matchedType == 1 && len(data) != 32 {
+		return fmt.Errorf("'certificate_data' must be a SHA-256 hash of 32 bytes, got %d bytes", len(data))
+	}
+	if matchedType == 2 && len(data) != 64 {
+		return fmt.Errorf("'certificate_data' must be a SHA-512 hash of 64 bytes, got %d bytes", len(data))
+	}
+	return nil
+}
+
+func NewEmptyRecordTLSA() *RecordTlsa {
+	recordTLSA := &RecordTlsa{}
+	recordTLSA.SetReturnFields(append(recordTLSA.ReturnFields(), "certificate_data", "certificate_usage", "matched_type", "selector", "comment", "disable", "extattrs", "ttl", "use_ttl", "zone"))
+	return recordTLSA
+}
+
+func NewRecordTLSA(name string, dnsView string, certificateUsage uint32, selector uint32, matchedType uint32, certificateData string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) *RecordTlsa {
+	recordTLSA := NewEmptyRecordTLSA()
+	recordTLSA.Name = &name
+	if dnsView != "" {
+		recordTLSA.View = &dnsView
+	}
+	recordTLSA.CertificateUsage = &certificateUsage
+	recordTLSA.Selector = &selector
+	recordTLSA.MatchedType = &matchedType
+	certificateData = strings.ToUpper(certificateData)
+	recordTLSA.CertificateData = &certificateData
+	recordTLSA.Comment = &comment
+	recordTLSA.Disable = &disable
+	recordTLSA.Ea = ea
+	recordTLSA.Ttl = &ttl
+	recordTLSA.UseTtl = &useTtl
+	return recordTLSA
+}
+
+// CreateTLSARecord creates a TLSA-record.
+//
+// Also, it preforms validation of input parameters: name, certificate usage, selector, matched type and certificate data.
+func (objMgr *ObjectManager) CreateTLSARecord(name string, dnsView string, certificateUsage uint32, selector uint32, matchedType uint32, certificateData string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordTlsa, error) {
+	if err := validateTlsaRecArgs(name, certificateUsage, selector, matchedType, certificateData); err != nil {
+		return nil, err
+	}
+	if dnsView == "" {
+		dnsView = "default"
+	}
+	recordTLSA := NewRecordTLSA(name, dnsView, certificateUsage, selector, matchedType, certificateData, comment, disable, ea, ttl, useTtl)
+	ref, err := objMgr.connector.CreateObject(recordTLSA)
+	if err != nil {
+		return nil, err
+	}
+	recordTLSA.Ref = ref
+	return recordTLSA, nil
+}
+
+func (objMgr *ObjectManager) GetTLSARecordByRef(ref string) (*RecordTlsa, error) {
+	recordTLSA := NewEmptyRecordTLSA()
+	err := objMgr.connector.GetObject(recordTLSA, ref, NewQueryParams(false, nil), &recordTLSA)
+	if err != nil {
+		return nil, err
+	}
+	return recordTLSA, nil
+}
+
+func (objMgr *ObjectManager) GetAllTLSARecord(queryParams *QueryParams) ([]RecordTlsa, error) {
+	var res []RecordTlsa
+	recordTLSA := NewEmptyRecordTLSA()
+	err := objMgr.connector.GetObject(recordTLSA, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting TLSA Record: %s", err)
+	}
+	return res, nil
+}
+
+// UpdateTLSARecord updates the TLSA-record.
+//
+// Also, it preforms validation of input parameters: name, certificate usage, selector, matched type and certificate data.
+func (objMgr *ObjectManager) UpdateTLSARecord(ref string, name string, certificateUsage uint32, selector uint32, matchedType uint32, certificateData string, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordTlsa, error) {
+	if err := validateTlsaRecArgs(name, certificateUsage, selector, matchedType, certificateData); err != nil {
+		return nil, err
+	}
+	recordTLSA := NewRecordTLSA(name, "", certificateUsage, selector, matchedType, certificateData, comment, disable, ea, ttl, useTtl)
+	updatedRef, err := objMgr.connector.UpdateObject(recordTLSA, ref)
+	if err != nil {
+		return nil, err
+	}
+	recordTLSA.Ref = updatedRef
+	return recordTLSA, nil
+}
+
+func (objMgr *ObjectManager) DeleteTLSARecord(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
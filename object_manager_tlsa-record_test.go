@@ -0,0 +1,52 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Record TLSA", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "_443._tcp.www.example.com"
+	dnsView := "default"
+	sha256 := strings.Repeat("ab", 32)
+	fakeRefReturn := "record:tlsa/ZG5zLmJpbmRfdGxzYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUud3d3Ll90Y3AuXzQ0Mw:_443._tcp.www.example.com/default"
+
+	Describe("Create TLSA Record", func() {
+		conn := &fakeConnector{
+			createObjectObj: NewRecordTLSA(name, dnsView, 3, 1, 1, sha256, "", false, nil, 0, false),
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected TLSA record to CreateObject", func() {
+			recordTLSA, err := objMgr.CreateTLSARecord(name, dnsView, 3, 1, 1, sha256, "", false, nil, 0, false)
+			Expect(err).To(BeNil())
+			Expect(recordTLSA.Ref).To(Equal(fakeRefReturn))
+			Expect(*recordTLSA.CertificateData).To(Equal(strings.ToUpper(sha256)))
+		})
+	})
+
+	Describe("Negative cases", func() {
+		objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+
+		It("should reject a name without port and protocol", func() {
+			_, err := objMgr.CreateTLSARecord("www.example.com", dnsView, 3, 1, 1, sha256, "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("TLSA-record's name 'www.example.com' does not conform to standards, expected '_<port>._<protocol>.<host>'")))
+		})
+
+		It("should reject an invalid certificate usage", func() {
+			_, err := objMgr.CreateTLSARecord(name, dnsView, 4, 1, 1, sha256, "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("'certificate_usage' must be in the range from 0 to 3 inclusively")))
+		})
+
+		It("should reject a hash of the wrong length", func() {
+			_, err := objMgr.CreateTLSARecord(name, dnsView, 3, 1, 2, sha256, "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("'certificate_data' must be a SHA-512 hash of 64 bytes, got 32 bytes")))
+		})
+	})
+})
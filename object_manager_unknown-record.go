@@ -0,0 +1,103 @@
+package ibclient
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var unknownRecTypeRegExp = regexp.MustCompile("^[A-Z][A-Z0-9-]*$")
+
+func validateUnknownRecArgs(name string, recordType string, subfieldValues []*Rdatasubfield) error {
+	if name == "" {
+		return fmt.Errorf("'name' must not be empty")
+	}
+	if !unknownRecTypeRegExp.MatchString(recordType) {
+		return fmt.Errorf("'record_type' value '%s' is invalid, expected a type mnemonic such as 'SPF' or 'TYPE65280'", recordType)
+	}
+	if len(subfieldValues) == 0 {
+		return fmt.Errorf("at least one RDATA subfield value is required")
+	}
+	for i, sf := range subfieldValues {
+		if sf == nil || len(sf.FieldType) != 1 || !strings.Contains("BSIH64NTX", sf.FieldType) {
+			return fmt.Errorf("RDATA subfield #%d has an invalid field type, it must be one of 'B', 'S', 'I', 'H', '6', '4', 'N', 'T' or 'X'", i+1)
+		}
+	}
+	return nil
+}
+
+func NewEmptyRecordUnknown() *RecordUnknown {
+	recordUnknown := &RecordUnknown{}
+	recordUnknown.SetReturnFields(append(recordUnknown.ReturnFields(), "record_type", "subfield_values", "display_rdata", "comment", "disable", "extattrs", "ttl", "use_ttl", "zone"))
+	return recordUnknown
+}
+
+func NewRecordUnknown(name string, dnsView string, recordType string, subfieldValues []*Rdatasubfield, comment string, disable bool, ea EA, ttl uint32, useTtl bool) *RecordUnknown {
+	recordUnknown := NewEmptyRecordUnknown()
+	recordUnknown.Name = &name
+	if dnsView != "" {
+		recordUnknown.View = &dnsView
+	}
+	recordUnknown.RecordType = &recordType
+	recordUnknown.SubfieldValues = subfieldValues
+	recordUnknown.Comment = &comment
+	recordUnknown.Disable = &disable
+	recordUnknown.Ea = ea
+	recordUnknown.Ttl = &ttl
+	recordUnknown.UseTtl = &useTtl
+	return recordUnknown
+}
+
+// CreateUnknownRecord creates a record of a type WAPI has no dedicated object for,
+// its RDATA being given as a list of typed subfields.
+func (objMgr *ObjectManager) CreateUnknownRecord(name string, dnsView string, recordType string, subfieldValues []*Rdatasubfield, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordUnknown, error) {
+	if err := validateUnknownRecArgs(name, recordType, subfieldValues); err != nil {
+		return nil, err
+	}
+	if dnsView == "" {
+		dnsView = "default"
+	}
+	recordUnknown := NewRecordUnknown(name, dnsView, recordType, subfieldValues, comment, disable, ea, ttl, useTtl)
+	ref, err := objMgr.connector.CreateObject(recordUnknown)
+	if err != nil {
+		return nil, err
+	}
+	recordUnknown.Ref = ref
+	return recordUnknown, nil
+}
+
+func (objMgr *ObjectManager) GetUnknownRecordByRef(ref string) (*RecordUnknown, error) {
+	recordUnknown := NewEmptyRecordUnknown()
+	err := objMgr.connector.GetObject(recordUnknown, ref, NewQueryParams(false, nil), &recordUnknown)
+	if err != nil {
+		return nil, err
+	}
+	return recordUnknown, nil
+}
+
+func (objMgr *ObjectManager) GetAllUnknownRecord(queryParams *QueryParams) ([]RecordUnknown, error) {
+	var res []RecordUnknown
+	recordUnknown := NewEmptyRecordUnknown()
+	err := objMgr.connector.GetObject(recordUnknown, "", queryParams, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting Unknown Record: %s", err)
+	}
+	return res, nil
+}
+
+func (objMgr *ObjectManager) UpdateUnknownRecord(ref string, name string, recordType string, subfieldValues []*Rdatasubfield, comment string, disable bool, ea EA, ttl uint32, useTtl bool) (*RecordUnknown, error) {
+	if err := validateUnknownRecArgs(name, recordType, subfieldValues); err != nil {
+		return nil, err
+	}
+	recordUnknown := NewRecordUnknown(name, "", recordType, subfieldValues, comment, disable, ea, ttl, useTtl)
+	updatedRef, err := objMgr.connector.UpdateObject(recordUnknown, ref)
+	if err != nil {
+		return nil, err
+	}
+	recordUnknown.Ref = updatedRef
+	return recordUnknown, nil
+}
+
+func (objMgr *ObjectManager) DeleteUnknownRecord(ref string) (string, error) {
+	return objMgr.connector.DeleteObject(ref)
+}
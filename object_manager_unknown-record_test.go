@@ -0,0 +1,35 @@
+package ibclient
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Record Unknown", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "host.example.com"
+	subfields := []*Rdatasubfield{{FieldType: "T", FieldValue: "v=spf1 -all", IncludeLength: "8_BIT"}}
+	fakeRefReturn := "record:unknown/ZG5zLnVua25vd25fcmVjb3JkJC5fZGVmYXVsdC5jb20uZXhhbXBsZS5ob3N0:host.example.com/default"
+
+	Describe("Create Unknown Record", func() {
+		conn := &fakeConnector{
+			createObjectObj: NewRecordUnknown(name, "default", "SPF", subfields, "", false, nil, 0, false),
+			fakeRefReturn:   fakeRefReturn,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected record to CreateObject", func() {
+			record, err := objMgr.CreateUnknownRecord(name, "", "SPF", subfields, "", false, nil, 0, false)
+			Expect(err).To(BeNil())
+			Expect(record.Ref).To(Equal(fakeRefReturn))
+		})
+
+		It("should reject an invalid subfield type", func() {
+			_, err := objMgr.CreateUnknownRecord(name, "", "SPF", []*Rdatasubfield{{FieldType: "Z"}}, "", false, nil, 0, false)
+			Expect(err).To(Equal(fmt.Errorf("RDATA subfield #1 has an invalid field type, it must be one of 'B', 'S', 'I', 'H', '6', '4', 'N', 'T' or 'X'")))
+		})
+	})
+})
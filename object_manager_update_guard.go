@@ -0,0 +1,241 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// guardObject is a minimal IBObject used to re-read only the fields
+// compared by a guarded update.
+type guardObject struct {
+	IBBase     `json:"-"`
+	objectType string
+}
+
+func (g *guardObject) ObjectType() string {
+	return g.objectType
+}
+
+func newGuardObject(objectType string, fields []string) *guardObject {
+	obj := &guardObject{objectType: objectType}
+	obj.returnFields = fields
+	return obj
+}
+
+// objectToMap converts an object into the JSON representation sent to WAPI.
+func objectToMap(obj interface{}) (map[string]interface{}, error) {
+	byteObj, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling JSON: %v", err)
+	}
+	res := make(map[string]interface{})
+	if err = json.Unmarshal(byteObj, &res); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %v", err)
+	}
+	return res, nil
+}
+
+// isZeroValue tells whether v is the JSON zero value of its type, which
+// the omitempty option drops from the objects sent to WAPI.
+func isZeroValue(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case float64:
+		return val == 0
+	case bool:
+		return !val
+	case []interface{}:
+		return len(val) == 0
+	case map[string]interface{}:
+		return len(val) == 0
+	}
+	return false
+}
+
+// diffFields returns the names of the given fields whose values differ
+// between prev and cur. A field missing from one of them is taken as
+// unchanged if the other one holds a zero value.
+func diffFields(prev map[string]interface{}, cur map[string]interface{}, fields []string) []string {
+	var changed []string
+	for _, f := range fields {
+		if isZeroValue(prev[f]) && isZeroValue(cur[f]) {
+			continue
+		}
+		if !reflect.DeepEqual(prev[f], cur[f]) {
+			changed = append(changed, f)
+		}
+	}
+	return changed
+}
+
+// checkScalarFields returns an error if one of the given fields holds a
+// list or an object, which cannot be used as a WAPI search field.
+func checkScalarFields(obj map[string]interface{}, fields []string) error {
+	for _, f := range fields {
+		switch obj[f].(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("field '%s' holds a list or an object and cannot guard an update", f)
+		}
+	}
+	return nil
+}
+
+// isSearchValue reports whether v may be used as the value of a WAPI search field.
+func isSearchValue(v interface{}) bool {
+	switch v.(type) {
+	case string, float64, bool:
+		return true
+	}
+	return false
+}
+
+// getGuardedFields reads the current values of the guarded fields of the
+// object, or its default fields if none are given.
+func (objMgr *ObjectManager) getGuardedFields(objType string, ref string, fields []string) (map[string]interface{}, error) {
+	var curObj map[string]interface{}
+	err := objMgr.connector.GetObject(
+		newGuardObject(objType, fields), ref, NewQueryParams(false, nil), &curObj)
+	if err != nil {
+		return nil, err
+	}
+	return curObj, nil
+}
+
+// UpdateObjectIfUnchanged updates the object referenced by ref only if the
+// given fields still hold the values they have in prev, the copy of the
+// object which the caller read before preparing obj. If any of the fields
+// was changed in the meantime, a *ConflictError listing them is returned
+// and the object is left untouched.
+//
+// The guarded fields must hold scalar values, lists and objects such as
+// extattrs cannot be guarded. The check is repeated inside a single WAPI
+// 'request' object, together with the update, so that both happen
+// atomically: the object is searched by its type, its identifying fields
+// (the fields WAPI returns by default) and the guarded values. Guarded
+// fields which are not set are only checked before the request.
+func (objMgr *ObjectManager) UpdateObjectIfUnchanged(
+	prev IBObject,
+	ref string,
+	fields []string,
+	obj IBObject) (string, error) {
+
+	if ref == "" {
+		return "", fmt.Errorf("empty reference to an object is not allowed")
+	}
+	if len(fields) == 0 {
+		return "", fmt.Errorf("at least one field is required to guard an update")
+	}
+
+	prevObj, err := objectToMap(prev)
+	if err != nil {
+		return "", err
+	}
+	if err = checkScalarFields(prevObj, fields); err != nil {
+		return "", err
+	}
+	curObj, err := objMgr.getGuardedFields(prev.ObjectType(), ref, fields)
+	if err != nil {
+		return "", err
+	}
+	if err = checkScalarFields(curObj, fields); err != nil {
+		return "", err
+	}
+	if changed := diffFields(prevObj, curObj, fields); len(changed) > 0 {
+		return "", NewConflictError(ref, changed)
+	}
+
+	if conn, ok := objMgr.wapiConnector(); ok {
+		// the multi-request goes around the connectors wrapping conn
+		if err = objMgr.validateObjectEAs(obj, false); err != nil {
+			return "", err
+		}
+		return objMgr.guardedUpdate(conn, prev.ObjectType(), ref, prevObj, curObj, fields, obj)
+	}
+
+	return objMgr.connector.UpdateObject(obj, ref)
+}
+
+// guardedUpdate searches the object by its identifying fields and the
+// current values of the guarded fields, and updates it within the same
+// multi-request, so the update fails if the object no longer matches.
+func (objMgr *ObjectManager) guardedUpdate(
+	conn *Connector,
+	objType string,
+	ref string,
+	prevObj map[string]interface{},
+	curObj map[string]interface{},
+	fields []string,
+	obj IBObject) (string, error) {
+
+	idObj, err := objMgr.getGuardedFields(objType, ref, nil)
+	if err != nil {
+		return "", err
+	}
+	searchData := make(map[string]interface{})
+	for f, v := range idObj {
+		if f != "_ref" && isSearchValue(v) {
+			searchData[f] = v
+		}
+	}
+	for _, f := range fields {
+		if isSearchValue(curObj[f]) {
+			searchData[f] = curObj[f]
+		} else {
+			delete(searchData, f)
+		}
+	}
+	updateData, err := objectToMap(obj)
+	if err != nil {
+		return "", err
+	}
+	delete(updateData, "_ref")
+
+	req := NewMultiRequest(
+		[]*RequestBody{
+			&RequestBody{
+				Method: "GET",
+				Object: objType,
+				Data:   searchData,
+				Args: map[string]string{
+					"_return_fields": strings.Join(fields, ","),
+				},
+				AssignState: map[string]string{
+					"GUARDED_REF": "_ref",
+				},
+				Discard: true,
+			},
+			&RequestBody{
+				Method:             "PUT",
+				Object:             "##STATE:GUARDED_REF:##",
+				Data:               updateData,
+				EnableSubstitution: true,
+			},
+		},
+	)
+
+	res, err := conn.makeRequest(CREATE, req, "", NewQueryParams(false, nil))
+	if err != nil {
+		// The object does not match when it was changed after it was read.
+		if curObj, getErr := objMgr.getGuardedFields(objType, ref, fields); getErr == nil {
+			if changed := diffFields(prevObj, curObj, fields); len(changed) > 0 {
+				return "", NewConflictError(ref, changed)
+			}
+		}
+		return "", fmt.Errorf("guarded update of '%s' failed: %s", ref, err)
+	}
+
+	var result []string
+	if err = json.Unmarshal(res, &result); err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", NewConflictError(ref, fields)
+	}
+
+	return result[0], nil
+}
@@ -0,0 +1,169 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: guarded update", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	name := "host.test.com"
+	ref := fmt.Sprintf("record:host/ZG5zLmJpbmRfY25h:%s/default", name)
+	fields := []string{"name", "comment"}
+
+	Describe("Update an object which was not changed", func() {
+		prev := NewHostRecord("", name, "", "", nil, nil, EA{"Site": "Blr"}, true, "default", "", ref, false, 0, "old comment", nil, false)
+		updated := NewHostRecord("", name, "", "", nil, nil, EA{"Site": "Blr"}, true, "default", "", "", false, 0, "new comment", nil, false)
+
+		conn := &fakeConnector{
+			getObjectObj:         newGuardObject("record:host", fields),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         ref,
+			resultObject: map[string]interface{}{
+				"_ref":    ref,
+				"name":    name,
+				"comment": "old comment",
+			},
+			updateObjectObj: updated,
+			updateObjectRef: ref,
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass the new object to UpdateObject", func() {
+			newRef, err := objMgr.UpdateObjectIfUnchanged(prev, ref, fields, updated)
+			Expect(err).To(BeNil())
+			Expect(newRef).To(Equal(ref))
+		})
+	})
+
+	Describe("Negative case: update an object changed concurrently", func() {
+		prev := NewHostRecord("", name, "", "", nil, nil, EA{"Site": "Blr"}, true, "default", "", ref, false, 0, "old comment", nil, false)
+		updated := NewHostRecord("", name, "", "", nil, nil, EA{"Site": "Blr"}, true, "default", "", "", false, 0, "new comment", nil, false)
+
+		conn := &fakeConnector{
+			getObjectObj:         newGuardObject("record:host", fields),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         ref,
+			resultObject: map[string]interface{}{
+				"_ref":    ref,
+				"name":    name,
+				"comment": "changed by someone else",
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return a conflict error listing the changed fields", func() {
+			newRef, err := objMgr.UpdateObjectIfUnchanged(prev, ref, fields, updated)
+			Expect(newRef).To(BeEmpty())
+			Expect(err).To(Equal(NewConflictError(ref, []string{"comment"})))
+		})
+	})
+
+	Describe("Negative case: guard an update with a list or an object", func() {
+		prev := NewHostRecord("", name, "", "", nil, nil, EA{"Site": "Blr"}, true, "default", "", ref, false, 0, "old comment", nil, false)
+		objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+
+		It("should reject the field", func() {
+			_, err := objMgr.UpdateObjectIfUnchanged(prev, ref, []string{"name", "extattrs"}, prev)
+			Expect(err).To(Equal(fmt.Errorf("field 'extattrs' holds a list or an object and cannot guard an update")))
+		})
+	})
+
+	Describe("Update an object whose guarded field was omitted", func() {
+		prev := NewEmptyHostRecord()
+		prev.Name = &name
+		updated := NewHostRecord("", name, "", "", nil, nil, nil, true, "default", "", "", false, 0, "new comment", nil, false)
+
+		conn := &fakeConnector{
+			getObjectObj:         newGuardObject("record:host", []string{"name", "comment"}),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         ref,
+			resultObject: map[string]interface{}{
+				"_ref":    ref,
+				"name":    name,
+				"comment": "",
+			},
+			updateObjectObj: updated,
+			updateObjectRef: ref,
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should take the empty value as unchanged", func() {
+			newRef, err := objMgr.UpdateObjectIfUnchanged(prev, ref, []string{"name", "comment"}, updated)
+			Expect(err).To(BeNil())
+			Expect(newRef).To(Equal(ref))
+		})
+	})
+
+	Describe("Update an object atomically with a multi-request", func() {
+		scalarFields := []string{"name", "comment"}
+		prev := NewHostRecord("", name, "", "", nil, nil, nil, true, "default", "", ref, false, 0, "old comment", nil, false)
+		updated := NewHostRecord("", name, "", "", nil, nil, nil, true, "default", "", "", false, 0, "new comment", nil, false)
+		current := []byte(`{"_ref":"` + ref + `","name":"` + name + `","comment":"old comment"}`)
+		defaults := []byte(`{"_ref":"` + ref + `","name":"` + name + `","view":"default",` +
+			`"ipv4addrs":[{"ipv4addr":"10.0.0.1"}]}`)
+
+		newConnector := func(fhr *fakeSequenceRequestor) IBObjectManager {
+			wrb, _ := NewWapiRequestBuilder(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"})
+			OrigValidateConnector := ValidateConnector
+			ValidateConnector = MockValidateConnector
+			defer func() { ValidateConnector = OrigValidateConnector }()
+			conn, err := NewConnector(HostConfig{Host: "172.22.18.66", Version: "2.12", Port: "443"},
+				AuthConfig{Username: "admin", Password: "infoblox"}, NewTransportConfig("false", 20, 10), wrb, fhr)
+			if err != nil {
+				Fail("Error creating Connector")
+			}
+			return NewObjectManager(conn, cmpType, tenantID)
+		}
+
+		It("should search the object by its identifying fields and guarded values", func() {
+			fhr := &fakeSequenceRequestor{res: [][]byte{current, defaults, []byte(`["` + ref + `"]`)}}
+			newRef, err := newConnector(fhr).UpdateObjectIfUnchanged(prev, ref, scalarFields, updated)
+			Expect(err).To(BeNil())
+			Expect(newRef).To(Equal(ref))
+			Expect(fhr.reqs).To(HaveLen(3))
+			Expect(fhr.reqs[1].URL.Query().Get("_return_fields")).To(BeEmpty())
+
+			var body []map[string]interface{}
+			data, _ := ioutil.ReadAll(fhr.reqs[2].Body)
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			Expect(body).To(HaveLen(2))
+			Expect(body[0]["method"]).To(Equal("GET"))
+			Expect(body[0]["object"]).To(Equal("record:host"))
+			Expect(body[0]["data"]).To(Equal(map[string]interface{}{"name": name, "view": "default", "comment": "old comment"}))
+			Expect(body[0]["assign_state"]).To(Equal(map[string]interface{}{"GUARDED_REF": "_ref"}))
+			Expect(body[1]["method"]).To(Equal("PUT"))
+			Expect(body[1]["object"]).To(Equal("##STATE:GUARDED_REF:##"))
+			Expect(body[1]["data"]).To(HaveKeyWithValue("comment", "new comment"))
+		})
+
+		It("should return a conflict error when the object changed before the update", func() {
+			wapiErr := fmt.Errorf("WAPI request error: 400('400 Bad Request')")
+			fhr := &fakeSequenceRequestor{
+				res:  [][]byte{current, defaults, nil, nil, []byte(`{"_ref":"` + ref + `","name":"` + name + `","comment":"changed"}`)},
+				errs: []error{nil, nil, wapiErr, wapiErr},
+			}
+			newRef, err := newConnector(fhr).UpdateObjectIfUnchanged(prev, ref, scalarFields, updated)
+			Expect(newRef).To(BeEmpty())
+			Expect(err).To(Equal(NewConflictError(ref, []string{"comment"})))
+		})
+	})
+
+	Describe("Negative case: update without guarded fields", func() {
+		conn := &fakeConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return an error", func() {
+			_, err := objMgr.UpdateObjectIfUnchanged(NewEmptyHostRecord(), ref, nil, NewEmptyHostRecord())
+			Expect(err).To(Equal(fmt.Errorf("at least one field is required to guard an update")))
+		})
+	})
+})
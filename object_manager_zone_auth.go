@@ -0,0 +1,231 @@
+package ibclient
+
+import (
+	"fmt"
+)
+
+// ZoneAuthSoa holds the SOA settings of an authoritative zone. Timers left
+// nil keep their current values or, on creation, the grid defaults.
+type ZoneAuthSoa struct {
+	DefaultTtl  *uint32
+	Expire      *uint32
+	NegativeTtl *uint32
+	Refresh     *uint32
+	Retry       *uint32
+	Email       *string
+}
+
+func (soa *ZoneAuthSoa) applyTo(zone *ZoneAuth) {
+	if soa.DefaultTtl != nil || soa.Expire != nil || soa.NegativeTtl != nil || soa.Refresh != nil || soa.Retry != nil {
+		useGridZoneTimer := true
+		zone.UseGridZoneTimer = &useGridZoneTimer
+		zone.SoaDefaultTtl = soa.DefaultTtl
+		zone.SoaExpire = soa.Expire
+		zone.SoaNegativeTtl = soa.NegativeTtl
+		zone.SoaRefresh = soa.Refresh
+		zone.SoaRetry = soa.Retry
+	}
+	if soa.Email != nil {
+		useSoaEmail := true
+		zone.UseSoaEmail = &useSoaEmail
+		zone.SoaEmail = soa.Email
+	}
+}
+
+// ZoneAuthParams holds the settings of an authoritative zone to be created.
+// The zone is served either by the name server group NsGroup or by the
+// given grid primaries and secondaries.
+type ZoneAuthParams struct {
+	Fqdn            string
+	View            string // "default" if empty
+	ZoneFormat      string // FORWARD (default), IPV4 or IPV6
+	Prefix          string // RFC 2317 prefix of an IPV4 reverse zone
+	NsGroup         string
+	GridPrimary     []*Memberserver
+	GridSecondaries []*Memberserver
+	Soa             *ZoneAuthSoa
+	Comment         string
+	Disable         bool
+	Ea              EA
+}
+
+// ZoneAuthUpdate holds the changes to apply to an authoritative zone;
+// nil fields are left unchanged. Setting NsGroup to an empty string
+// removes the name server group from the zone.
+type ZoneAuthUpdate struct {
+	NsGroup *string
+	Soa     *ZoneAuthSoa
+	Ea      EA
+	Comment *string
+	Disable *bool
+	Locked  *bool
+}
+
+// NewEmptyZoneAuth returns a ZoneAuth with the return fields used by the zone management methods.
+func NewEmptyZoneAuth() *ZoneAuth {
+	zone := NewZoneAuth(ZoneAuth{})
+	zone.SetReturnFields(append(zone.ReturnFields(),
+		"comment", "disable", "locked", "ns_group", "zone_format", "prefix",
+		"grid_primary", "grid_secondaries", "soa_default_ttl", "soa_expire",
+		"soa_negative_ttl", "soa_refresh", "soa_retry", "soa_email",
+		"soa_serial_number", "dns_soa_email", "use_grid_zone_timer", "use_soa_email"))
+	return zone
+}
+
+func validateZoneAuthParams(params ZoneAuthParams) error {
+	if params.Fqdn == "" {
+		return fmt.Errorf("FQDN is required to create an authoritative zone")
+	}
+	switch params.ZoneFormat {
+	case "", "FORWARD", "IPV4", "IPV6":
+	default:
+		return fmt.Errorf("invalid zone format '%s', must be one of FORWARD, IPV4 or IPV6", params.ZoneFormat)
+	}
+	if params.Prefix != "" && params.ZoneFormat != "IPV4" {
+		return fmt.Errorf("prefix is only allowed for IPV4 reverse zones")
+	}
+	if params.NsGroup != "" && (len(params.GridPrimary) > 0 || len(params.GridSecondaries) > 0) {
+		return fmt.Errorf("name server group and grid primaries or secondaries are mutually exclusive")
+	}
+	return nil
+}
+
+// CreateZoneAuthWithParams creates an authoritative zone with the given settings.
+func (objMgr *ObjectManager) CreateZoneAuthWithParams(params ZoneAuthParams) (*ZoneAuth, error) {
+	if err := validateZoneAuthParams(params); err != nil {
+		return nil, err
+	}
+
+	zone := NewEmptyZoneAuth()
+	zone.Fqdn = params.Fqdn
+	view := params.View
+	if view == "" {
+		view = "default"
+	}
+	zone.View = &view
+	zone.ZoneFormat = params.ZoneFormat
+	if zone.ZoneFormat == "" {
+		zone.ZoneFormat = "FORWARD"
+	}
+	if params.Prefix != "" {
+		zone.Prefix = &params.Prefix
+	}
+	if params.NsGroup != "" {
+		zone.NsGroup = &params.NsGroup
+	}
+	zone.GridPrimary = params.GridPrimary
+	zone.GridSecondaries = params.GridSecondaries
+	if params.Soa != nil {
+		params.Soa.applyTo(zone)
+	}
+	if params.Comment != "" {
+		zone.Comment = &params.Comment
+	}
+	zone.Disable = &params.Disable
+	zone.Ea = params.Ea
+
+	ref, err := objMgr.connector.CreateObject(zone)
+	if err != nil {
+		return nil, err
+	}
+	zone.Ref = ref
+	return zone, nil
+}
+
+// GetZoneAuthByFqdn returns the authoritative zone with the given FQDN in the given DNS view.
+func (objMgr *ObjectManager) GetZoneAuthByFqdn(fqdn string, view string) (*ZoneAuth, error) {
+	if fqdn == "" {
+		return nil, fmt.Errorf("FQDN of the zone is required")
+	}
+	if view == "" {
+		view = "default"
+	}
+	var res []ZoneAuth
+	sf := map[string]string{
+		"fqdn": fqdn,
+		"view": view,
+	}
+	err := objMgr.connector.GetObject(NewEmptyZoneAuth(), "", NewQueryParams(false, sf), &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, NewNotFoundError(
+			fmt.Sprintf("authoritative zone '%s' not found in DNS view '%s'", fqdn, view))
+	}
+	return &res[0], nil
+}
+
+// UpdateZoneAuth applies the given changes to the authoritative zone.
+// The zone is read first so that unchanged settings are sent back as they are.
+func (objMgr *ObjectManager) UpdateZoneAuth(ref string, upd ZoneAuthUpdate) (*ZoneAuth, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty reference to an object is not allowed")
+	}
+	current := NewEmptyZoneAuth()
+	err := objMgr.connector.GetObject(current, ref, NewQueryParams(false, nil), current)
+	if err != nil {
+		return nil, err
+	}
+
+	zone := NewEmptyZoneAuth()
+	zone.NsGroup = current.NsGroup
+	zone.Ea = current.Ea
+	zone.Comment = current.Comment
+	zone.Disable = current.Disable
+	zone.Locked = current.Locked
+
+	if upd.NsGroup != nil {
+		if *upd.NsGroup == "" {
+			zone.NsGroup = nil
+		} else {
+			zone.NsGroup = upd.NsGroup
+		}
+	}
+	if upd.Soa != nil {
+		upd.Soa.applyTo(zone)
+	}
+	if upd.Ea != nil {
+		zone.Ea = upd.Ea
+	}
+	if upd.Comment != nil {
+		zone.Comment = upd.Comment
+	}
+	if upd.Disable != nil {
+		zone.Disable = upd.Disable
+	}
+	if upd.Locked != nil {
+		zone.Locked = upd.Locked
+	}
+
+	newRef, err := objMgr.connector.UpdateObject(zone, ref)
+	if err != nil {
+		return nil, err
+	}
+	zone.Ref = newRef
+	zone.Fqdn = current.Fqdn
+	zone.View = current.View
+	return zone, nil
+}
+
+// GetZoneAuthRecords returns all the records of the given authoritative zone.
+func (objMgr *ObjectManager) GetZoneAuthRecords(fqdn string, view string) ([]Allrecords, error) {
+	if fqdn == "" {
+		return nil, fmt.Errorf("FQDN of the zone is required")
+	}
+	if view == "" {
+		view = "default"
+	}
+	var res []Allrecords
+	records := &Allrecords{}
+	records.SetReturnFields(append(records.ReturnFields(), "address", "disable", "record", "ttl", "creator"))
+	sf := map[string]string{
+		"zone": fqdn,
+		"view": view,
+	}
+	err := objMgr.connector.GetObject(records, "", NewQueryParams(false, sf), &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,153 @@
+package ibclient
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: authoritative zone", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	fqdn := "example.com"
+	view := "internal"
+	ref := "zone_auth/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxl:example.com/internal"
+
+	Describe("Create zone with settings", func() {
+		nsGroup := "ns-group-1"
+		refresh := uint32(3600)
+		email := "hostmaster@example.com"
+		comment := "managed zone"
+		disable := false
+		useGridZoneTimer := true
+		useSoaEmail := true
+		ea := EA{"Site": "Blr"}
+
+		expected := NewEmptyZoneAuth()
+		expected.Fqdn = fqdn
+		expected.View = &view
+		expected.ZoneFormat = "FORWARD"
+		expected.NsGroup = &nsGroup
+		expected.UseGridZoneTimer = &useGridZoneTimer
+		expected.SoaRefresh = &refresh
+		expected.UseSoaEmail = &useSoaEmail
+		expected.SoaEmail = &email
+		expected.Comment = &comment
+		expected.Disable = &disable
+		expected.Ea = ea
+
+		conn := &fakeConnector{
+			createObjectObj: expected,
+			fakeRefReturn:   ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should pass expected ZoneAuth Object to CreateObject", func() {
+			zone, err := objMgr.CreateZoneAuthWithParams(ZoneAuthParams{
+				Fqdn:    fqdn,
+				View:    view,
+				NsGroup: nsGroup,
+				Soa:     &ZoneAuthSoa{Refresh: &refresh, Email: &email},
+				Comment: comment,
+				Ea:      ea,
+			})
+			Expect(err).To(BeNil())
+			Expect(zone.Ref).To(Equal(ref))
+		})
+
+		It("should reject a prefix on a forward zone", func() {
+			_, err := objMgr.CreateZoneAuthWithParams(ZoneAuthParams{Fqdn: fqdn, Prefix: "0-25"})
+			Expect(err).To(Equal(fmt.Errorf("prefix is only allowed for IPV4 reverse zones")))
+		})
+
+		It("should reject both a name server group and grid primaries", func() {
+			_, err := objMgr.CreateZoneAuthWithParams(ZoneAuthParams{
+				Fqdn:        fqdn,
+				NsGroup:     nsGroup,
+				GridPrimary: []*Memberserver{{Name: "infoblox.localdomain"}},
+			})
+			Expect(err).To(Equal(fmt.Errorf("name server group and grid primaries or secondaries are mutually exclusive")))
+		})
+	})
+
+	Describe("Get zone by FQDN and view", func() {
+		conn := &fakeConnector{
+			getObjectObj:         NewEmptyZoneAuth(),
+			getObjectQueryParams: NewQueryParams(false, map[string]string{"fqdn": fqdn, "view": view}),
+			getObjectRef:         "",
+			resultObject:         []ZoneAuth{{Ref: ref, Fqdn: fqdn, View: &view}},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the zone", func() {
+			zone, err := objMgr.GetZoneAuthByFqdn(fqdn, view)
+			Expect(err).To(BeNil())
+			Expect(zone.Ref).To(Equal(ref))
+		})
+
+		It("should return NotFoundError if there is no such zone", func() {
+			conn.resultObject = []ZoneAuth{}
+			_, err := objMgr.GetZoneAuthByFqdn(fqdn, view)
+			Expect(err).To(Equal(NewNotFoundError("authoritative zone 'example.com' not found in DNS view 'internal'")))
+		})
+	})
+
+	Describe("Update zone", func() {
+		oldGroup := "ns-group-1"
+		comment := "managed zone"
+		locked := true
+		current := NewEmptyZoneAuth()
+		current.Ref = ref
+		current.Fqdn = fqdn
+		current.View = &view
+		current.NsGroup = &oldGroup
+		current.Comment = &comment
+		current.Ea = EA{"Site": "Blr"}
+
+		expected := NewEmptyZoneAuth()
+		expected.Comment = &comment
+		expected.Ea = EA{"Site": "Blr"}
+		expected.Locked = &locked
+
+		conn := &fakeConnector{
+			getObjectObj:         NewEmptyZoneAuth(),
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         ref,
+			resultObject:         current,
+			updateObjectObj:      expected,
+			updateObjectRef:      ref,
+			fakeRefReturn:        ref,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should keep unchanged settings and remove the name server group", func() {
+			noGroup := ""
+			zone, err := objMgr.UpdateZoneAuth(ref, ZoneAuthUpdate{NsGroup: &noGroup, Locked: &locked})
+			Expect(err).To(BeNil())
+			Expect(zone.Ref).To(Equal(ref))
+			Expect(zone.Fqdn).To(Equal(fqdn))
+		})
+	})
+
+	Describe("List zone records", func() {
+		records := &Allrecords{}
+		records.SetReturnFields(append(records.ReturnFields(), "address", "disable", "record", "ttl", "creator"))
+		conn := &fakeConnector{
+			getObjectObj:         records,
+			getObjectQueryParams: NewQueryParams(false, map[string]string{"zone": fqdn, "view": view}),
+			getObjectRef:         "",
+			resultObject: []Allrecords{
+				{Name: "www", Type: "record:a", Address: "10.0.0.1", Zone: fqdn, View: view},
+				{Name: "mail", Type: "record:mx", Zone: fqdn, View: view},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return all records of the zone", func() {
+			res, err := objMgr.GetZoneAuthRecords(fqdn, view)
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal(conn.resultObject))
+		})
+	})
+})
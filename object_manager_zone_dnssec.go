@@ -0,0 +1,328 @@
+package ibclient
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DNSSEC key types, as used by RolloverZoneKey.
+const (
+	DnssecKeyKsk = "KSK"
+	DnssecKeyZsk = "ZSK"
+)
+
+// DS digest types, see RFC 4034, RFC 4509 and RFC 6605.
+const (
+	DsDigestSha1   uint8 = 1
+	DsDigestSha256 uint8 = 2
+	DsDigestSha384 uint8 = 4
+)
+
+// dnssecAlgorithms maps the algorithm names used by NIOS to their DNSSEC numbers.
+var dnssecAlgorithms = map[string]uint8{
+	"RSAMD5":          1,
+	"DH":              2,
+	"DSA":             3,
+	"RSASHA1":         5,
+	"NSEC3DSA":        6,
+	"NSEC3RSASHA1":    7,
+	"RSASHA256":       8,
+	"RSASHA512":       10,
+	"ECDSAP256SHA256": 13,
+	"ECDSAP384SHA384": 14,
+	"ED25519":         15,
+	"ED448":           16,
+}
+
+// dnssecOperation is the argument of the dnssec_operation function of a zone.
+type dnssecOperation struct {
+	Operation string `json:"operation"`
+}
+
+// zoneDnssecKeyParamsUpdate sets the DNSSEC key parameters of an
+// authoritative zone, leaving its other fields untouched.
+type zoneDnssecKeyParamsUpdate struct {
+	IBBase             `json:"-"`
+	DnssecKeyParams    *Dnsseckeyparams `json:"dnssec_key_params"`
+	UseDnssecKeyParams bool             `json:"use_dnssec_key_params"`
+}
+
+func (zoneDnssecKeyParamsUpdate) ObjectType() string {
+	return "zone_auth"
+}
+
+// ZoneDnssecStatus describes the DNSSEC state of an authoritative zone.
+type ZoneDnssecStatus struct {
+	Enabled         bool
+	Signed          bool
+	Keys            []*Dnsseckey
+	KskRolloverDate *UnixTime
+	ZskRolloverDate *UnixTime
+}
+
+// ZoneDS is a DS record to be published in the parent zone.
+type ZoneDS struct {
+	Name       string
+	KeyTag     uint32
+	Algorithm  uint8
+	DigestType uint8
+	Digest     string
+}
+
+// String returns the DS record in zone file presentation format.
+func (ds ZoneDS) String() string {
+	return fmt.Sprintf("%s.\tIN\tDS\t%d %d %d %s",
+		strings.TrimSuffix(ds.Name, "."), ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest)
+}
+
+func dnssecAlgorithmNumber(algorithm string) (uint8, error) {
+	if num, ok := dnssecAlgorithms[strings.ToUpper(algorithm)]; ok {
+		return num, nil
+	}
+	num, err := strconv.ParseUint(algorithm, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("unknown DNSSEC algorithm '%s'", algorithm)
+	}
+	return uint8(num), nil
+}
+
+func validateDnssecKeyAlgorithms(field string, algorithms []*Dnsseckeyalgorithm) error {
+	for _, alg := range algorithms {
+		if alg == nil {
+			return fmt.Errorf("%s must not contain empty entries", field)
+		}
+		if _, ok := dnssecAlgorithms[alg.Algorithm]; !ok {
+			return fmt.Errorf("unknown DNSSEC algorithm '%s' in %s", alg.Algorithm, field)
+		}
+	}
+	return nil
+}
+
+func validateDnssecKeyParams(params *Dnsseckeyparams) error {
+	if err := validateDnssecKeyAlgorithms("ksk_algorithms", params.KskAlgorithms); err != nil {
+		return err
+	}
+	if err := validateDnssecKeyAlgorithms("zsk_algorithms", params.ZskAlgorithms); err != nil {
+		return err
+	}
+	switch params.NextSecureType {
+	case "", "NSEC", "NSEC3":
+	default:
+		return fmt.Errorf("invalid next secure type '%s', must be NSEC or NSEC3", params.NextSecureType)
+	}
+	switch params.ZskRolloverMechanism {
+	case "", "PRE_PUBLISH", "DOUBLE_SIGN":
+	default:
+		return fmt.Errorf("invalid ZSK rollover mechanism '%s', must be PRE_PUBLISH or DOUBLE_SIGN", params.ZskRolloverMechanism)
+	}
+	if params.Nsec3SaltMaxLength > 0 && params.Nsec3SaltMinLength > params.Nsec3SaltMaxLength {
+		return fmt.Errorf("minimum NSEC3 salt length must not be greater than the maximum one")
+	}
+	return nil
+}
+
+// dnssecOperation runs the given DNSSEC operation on the zone.
+func (objMgr *ObjectManager) dnssecOperation(ref string, operation string) error {
+	if ref == "" {
+		return fmt.Errorf("empty reference to an object is not allowed")
+	}
+	caller, ok := objMgr.connector.(IBFunctionCaller)
+	if !ok {
+		return fmt.Errorf("the connector does not support WAPI function calls")
+	}
+	return caller.CallFunction(ref, "dnssec_operation", &dnssecOperation{Operation: operation}, nil)
+}
+
+// SignZone signs the authoritative zone. If key parameters are given, the
+// zone is set to use them instead of the grid's ones before being signed.
+func (objMgr *ObjectManager) SignZone(ref string, keyParams *Dnsseckeyparams) error {
+	if ref == "" {
+		return fmt.Errorf("empty reference to an object is not allowed")
+	}
+	if keyParams != nil {
+		if err := validateDnssecKeyParams(keyParams); err != nil {
+			return err
+		}
+		update := &zoneDnssecKeyParamsUpdate{DnssecKeyParams: keyParams, UseDnssecKeyParams: true}
+		newRef, err := objMgr.connector.UpdateObject(update, ref)
+		if err != nil {
+			return err
+		}
+		ref = newRef
+	}
+	return objMgr.dnssecOperation(ref, "SIGN")
+}
+
+// UnsignZone removes the DNSSEC signatures and keys of the zone.
+func (objMgr *ObjectManager) UnsignZone(ref string) error {
+	return objMgr.dnssecOperation(ref, "UNSIGN")
+}
+
+// ResignZone regenerates the signatures of the zone with its current keys.
+func (objMgr *ObjectManager) ResignZone(ref string) error {
+	return objMgr.dnssecOperation(ref, "RESIGN")
+}
+
+// RolloverZoneKey starts the rollover of the key signing key (KSK) or of the
+// zone signing key (ZSK) of the zone.
+func (objMgr *ObjectManager) RolloverZoneKey(ref string, keyType string) error {
+	switch keyType {
+	case DnssecKeyKsk, DnssecKeyZsk:
+	default:
+		return fmt.Errorf("invalid key type '%s', must be %s or %s", keyType, DnssecKeyKsk, DnssecKeyZsk)
+	}
+	return objMgr.dnssecOperation(ref, "ROLLOVER_"+keyType)
+}
+
+// GetZoneDnssecStatus returns whether the zone is signed, its keys with
+// their status and next event (rollover or removal) date, and the dates
+// of the next scheduled rollovers.
+func (objMgr *ObjectManager) GetZoneDnssecStatus(ref string) (*ZoneDnssecStatus, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty reference to an object is not allowed")
+	}
+	zone := NewEmptyZoneAuth()
+	zone.SetReturnFields(append(zone.ReturnFields(), "is_dnssec_enabled", "is_dnssec_signed",
+		"dnssec_keys", "dnssec_ksk_rollover_date", "dnssec_zsk_rollover_date"))
+	err := objMgr.connector.GetObject(zone, ref, NewQueryParams(false, nil), zone)
+	if err != nil {
+		return nil, err
+	}
+	return &ZoneDnssecStatus{
+		Enabled:         zone.IsDnssecEnabled,
+		Signed:          zone.IsDnssecSigned,
+		Keys:            zone.DnssecKeys,
+		KskRolloverDate: zone.DnssecKskRolloverDate,
+		ZskRolloverDate: zone.DnssecZskRolloverDate,
+	}, nil
+}
+
+// getZoneKsks returns the DNSKEY records of the zone which are key signing keys.
+func (objMgr *ObjectManager) getZoneKsks(fqdn string, view string) ([]RecordDnskey, error) {
+	if fqdn == "" {
+		return nil, fmt.Errorf("FQDN of the zone is required")
+	}
+	if view == "" {
+		view = "default"
+	}
+	var res []RecordDnskey
+	dnskey := &RecordDnskey{}
+	dnskey.SetReturnFields(append(dnskey.ReturnFields(), "algorithm", "flags", "key_tag", "public_key"))
+	sf := map[string]string{
+		"zone": fqdn,
+		"view": view,
+	}
+	if err := objMgr.getObjects(dnskey, NewQueryParams(false, sf), &res); err != nil {
+		return nil, err
+	}
+	var ksks []RecordDnskey
+	for _, key := range res {
+		if key.Flags&1 == 1 {
+			ksks = append(ksks, key)
+		}
+	}
+	if len(ksks) == 0 {
+		return nil, NewNotFoundError(
+			fmt.Sprintf("no key signing key found for zone '%s' in DNS view '%s'", fqdn, view))
+	}
+	sort.Slice(ksks, func(i, j int) bool { return ksks[i].KeyTag < ksks[j].KeyTag })
+	return ksks, nil
+}
+
+// dnssecWireName returns the owner name in canonical wire format (RFC 4034, section 6.2).
+func dnssecWireName(name string) []byte {
+	var wire []byte
+	for _, label := range strings.Split(strings.ToLower(strings.TrimSuffix(name, ".")), ".") {
+		if label == "" {
+			continue
+		}
+		wire = append(wire, byte(len(label)))
+		wire = append(wire, label...)
+	}
+	return append(wire, 0)
+}
+
+// dnssecDS computes the DS record of the given DNSKEY record (RFC 4034, section 5.1.4).
+func dnssecDS(key RecordDnskey, fqdn string, digestType uint8) (*ZoneDS, error) {
+	var h hash.Hash
+	switch digestType {
+	case DsDigestSha1:
+		h = sha1.New()
+	case DsDigestSha256:
+		h = sha256.New()
+	case DsDigestSha384:
+		h = sha512.New384()
+	default:
+		return nil, fmt.Errorf("unsupported DS digest type %d", digestType)
+	}
+	algorithm, err := dnssecAlgorithmNumber(key.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(key.PublicKey), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of the key %d: %s", key.KeyTag, err)
+	}
+
+	rdata := make([]byte, 4, 4+len(publicKey))
+	binary.BigEndian.PutUint16(rdata, uint16(key.Flags))
+	rdata[2] = 3 // protocol
+	rdata[3] = algorithm
+	h.Write(dnssecWireName(fqdn))
+	h.Write(append(rdata, publicKey...))
+
+	return &ZoneDS{
+		Name:       strings.TrimSuffix(fqdn, "."),
+		KeyTag:     key.KeyTag,
+		Algorithm:  algorithm,
+		DigestType: digestType,
+		Digest:     strings.ToUpper(hex.EncodeToString(h.Sum(nil))),
+	}, nil
+}
+
+// ExportZoneDS returns the DS records of the key signing keys of the signed
+// zone, to be published in the parent zone.
+func (objMgr *ObjectManager) ExportZoneDS(fqdn string, view string, digestType uint8) ([]ZoneDS, error) {
+	ksks, err := objMgr.getZoneKsks(fqdn, view)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]ZoneDS, 0, len(ksks))
+	for _, key := range ksks {
+		ds, err := dnssecDS(key, fqdn, digestType)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *ds)
+	}
+	return res, nil
+}
+
+// GetZoneTrustAnchors returns the key signing keys of the signed zone as
+// trust anchors, which can be configured on DNS views and members validating
+// the responses for the zone.
+func (objMgr *ObjectManager) GetZoneTrustAnchors(fqdn string, view string) ([]*Dnssectrustedkey, error) {
+	ksks, err := objMgr.getZoneKsks(fqdn, view)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*Dnssectrustedkey, 0, len(ksks))
+	for _, key := range ksks {
+		res = append(res, &Dnssectrustedkey{
+			Fqdn:             strings.TrimSuffix(fqdn, "."),
+			Algorithm:        key.Algorithm,
+			Key:              key.PublicKey,
+			SecureEntryPoint: true,
+		})
+	}
+	return res, nil
+}
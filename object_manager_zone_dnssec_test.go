@@ -0,0 +1,137 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: zone DNSSEC", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	zoneRef := "zone_auth/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxlLmRza2V5:dskey.example.com/default"
+
+	Describe("Sign zone", func() {
+		It("should set the key parameters and sign the zone", func() {
+			keyParams := &Dnsseckeyparams{
+				KskAlgorithms:  []*Dnsseckeyalgorithm{{Algorithm: "ECDSAP256SHA256", Size: 256}},
+				ZskAlgorithms:  []*Dnsseckeyalgorithm{{Algorithm: "ECDSAP256SHA256", Size: 256}},
+				NextSecureType: "NSEC3",
+			}
+			expected := &zoneDnssecKeyParamsUpdate{DnssecKeyParams: keyParams, UseDnssecKeyParams: true}
+			conn := &fakeConnector{
+				updateObjectObj:  expected,
+				updateObjectRef:  zoneRef,
+				fakeRefReturn:    zoneRef,
+				callFunctionRef:  zoneRef,
+				callFunctionName: "dnssec_operation",
+				callFunctionArgs: &dnssecOperation{Operation: "SIGN"},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			Expect(objMgr.SignZone(zoneRef, keyParams)).To(BeNil())
+		})
+
+		It("should send only the key parameters", func() {
+			data, err := json.Marshal(&zoneDnssecKeyParamsUpdate{UseDnssecKeyParams: true})
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(`{"dnssec_key_params":null,"use_dnssec_key_params":true}`))
+		})
+
+		It("should reject an unknown algorithm", func() {
+			objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+			err := objMgr.SignZone(zoneRef, &Dnsseckeyparams{
+				KskAlgorithms: []*Dnsseckeyalgorithm{{Algorithm: "GOST", Size: 512}}})
+			Expect(err).To(Equal(fmt.Errorf("unknown DNSSEC algorithm 'GOST' in ksk_algorithms")))
+		})
+	})
+
+	Describe("Roll over keys", func() {
+		It("should start a KSK rollover", func() {
+			conn := &fakeConnector{
+				callFunctionRef:  zoneRef,
+				callFunctionName: "dnssec_operation",
+				callFunctionArgs: &dnssecOperation{Operation: "ROLLOVER_KSK"},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			Expect(objMgr.RolloverZoneKey(zoneRef, DnssecKeyKsk)).To(BeNil())
+		})
+
+		It("should reject an unknown key type", func() {
+			objMgr := NewObjectManager(&fakeConnector{}, cmpType, tenantID)
+			err := objMgr.RolloverZoneKey(zoneRef, "CSK")
+			Expect(err).To(Equal(fmt.Errorf("invalid key type 'CSK', must be KSK or ZSK")))
+		})
+	})
+
+	Describe("Get DNSSEC status", func() {
+		nextEvent := UnixTime{}
+		zone := NewEmptyZoneAuth()
+		zone.SetReturnFields(append(zone.ReturnFields(), "is_dnssec_enabled", "is_dnssec_signed",
+			"dnssec_keys", "dnssec_ksk_rollover_date", "dnssec_zsk_rollover_date"))
+		keys := []*Dnsseckey{
+			{Tag: 60486, Status: "ACTIVE", Type: "KSK", Algorithm: "RSASHA1", NextEventDate: &nextEvent},
+			{Tag: 2642, Status: "PUBLISHED", Type: "ZSK", Algorithm: "RSASHA1"},
+		}
+		result := NewEmptyZoneAuth()
+		result.IsDnssecEnabled = true
+		result.IsDnssecSigned = true
+		result.DnssecKeys = keys
+		conn := &fakeConnector{
+			getObjectObj:         zone,
+			getObjectQueryParams: NewQueryParams(false, nil),
+			getObjectRef:         zoneRef,
+			resultObject:         result,
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the keys with their status", func() {
+			status, err := objMgr.GetZoneDnssecStatus(zoneRef)
+			Expect(err).To(BeNil())
+			Expect(status.Signed).To(BeTrue())
+			Expect(status.Keys).To(Equal(keys))
+		})
+	})
+
+	Describe("Export DS records and trust anchors", func() {
+		publicKey := "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMm" +
+			"mAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+		dnskey := &RecordDnskey{}
+		dnskey.SetReturnFields(append(dnskey.ReturnFields(), "algorithm", "flags", "key_tag", "public_key"))
+		conn := &fakeConnector{
+			getObjectObj: dnskey,
+			getObjectQueryParams: NewQueryParams(false, map[string]string{
+				"zone": "dskey.example.com",
+				"view": "default",
+			}),
+			getObjectRef: "",
+			resultObject: []RecordDnskey{
+				{Name: "dskey.example.com", Algorithm: "RSASHA1", Flags: 256, KeyTag: 2642, PublicKey: "AQOW4333ZLdOHLRw"},
+				{Name: "dskey.example.com", Algorithm: "RSASHA1", Flags: 257, KeyTag: 60486, PublicKey: publicKey},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should compute the DS record of the key signing key", func() {
+			ds, err := objMgr.ExportZoneDS("dskey.example.com", "", DsDigestSha1)
+			Expect(err).To(BeNil())
+			Expect(ds).To(Equal([]ZoneDS{{
+				Name: "dskey.example.com", KeyTag: 60486, Algorithm: 5, DigestType: 1,
+				Digest: "ED84C242ADE706BA3F6460DA56650B4ABADC38B8"}}))
+			Expect(ds[0].String()).To(Equal("dskey.example.com.\tIN\tDS\t60486 5 1 ED84C242ADE706BA3F6460DA56650B4ABADC38B8"))
+		})
+
+		It("should return the key signing key as a trust anchor", func() {
+			anchors, err := objMgr.GetZoneTrustAnchors("dskey.example.com", "default")
+			Expect(err).To(BeNil())
+			Expect(anchors).To(Equal([]*Dnssectrustedkey{{
+				Fqdn: "dskey.example.com", Algorithm: "RSASHA1", Key: publicKey, SecureEntryPoint: true}}))
+		})
+
+		It("should reject an unsupported digest type", func() {
+			_, err := objMgr.ExportZoneDS("dskey.example.com", "", 3)
+			Expect(err).To(Equal(fmt.Errorf("unsupported DS digest type 3")))
+		})
+	})
+})
@@ -0,0 +1,901 @@
+package ibclient
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ZoneFileRecord is a resource record of a zone in master-file presentation format.
+type ZoneFileRecord struct {
+	Name string  // owner FQDN in lower case, without the trailing dot
+	Ttl  *uint32 // nil if the record uses the default TTL of the zone
+	Type string
+	Data string // RDATA in presentation format, with fully qualified domain names
+	Ref  string // reference of the WAPI object, empty for records read from a file
+	Host string // reference of the host record the record is derived from, if any
+}
+
+func (r ZoneFileRecord) key() string {
+	return r.Name + " " + r.Type + " " + r.Data
+}
+
+// String returns the record as a zone file line.
+func (r ZoneFileRecord) String() string {
+	ttl := ""
+	if r.Ttl != nil {
+		ttl = strconv.FormatUint(uint64(*r.Ttl), 10)
+	}
+	return fmt.Sprintf("%s.\t%s\tIN\t%s\t%s", r.Name, ttl, r.Type, r.Data)
+}
+
+// ZoneDiff is the result of the comparison of a zone file with the records of a zone.
+type ZoneDiff struct {
+	Create    []ZoneFileRecord
+	Update    []ZoneFileRecord // records whose TTL differs, with the reference of the existing object
+	Delete    []ZoneFileRecord // records missing from the file, removed only when pruning
+	Unchanged []ZoneFileRecord
+	Skipped   []ZoneFileRecord // records which cannot be imported through the object manager
+}
+
+// String renders the diff with one record per line, prefixed by
+// '+' (create), '~' (update), '-' (delete) or '!' (skipped).
+func (d *ZoneDiff) String() string {
+	var sb strings.Builder
+	for _, set := range []struct {
+		prefix  string
+		records []ZoneFileRecord
+	}{{"+", d.Create}, {"~", d.Update}, {"-", d.Delete}, {"!", d.Skipped}} {
+		for _, r := range set.records {
+			fmt.Fprintf(&sb, "%s %s\n", set.prefix, r)
+		}
+	}
+	return sb.String()
+}
+
+// ZoneImportOptions controls how ImportZone reconciles a zone with a zone file.
+type ZoneImportOptions struct {
+	DryRun bool // only compute the diff
+	Prune  bool // delete records which are not in the zone file
+}
+
+// zoneRecordTtl is used to update the TTL of any kind of record.
+type zoneRecordTtl struct {
+	IBBase     `json:"-"`
+	objectType string
+	Ttl        *uint32 `json:"ttl,omitempty"`
+	UseTtl     *bool   `json:"use_ttl,omitempty"`
+}
+
+func (r *zoneRecordTtl) ObjectType() string {
+	return r.objectType
+}
+
+// zoneFileImportTypes lists the record types ImportZone can create.
+var zoneFileImportTypes = map[string]bool{
+	"A": true, "AAAA": true, "CNAME": true, "MX": true, "SRV": true, "TXT": true, "PTR": true,
+	"CAA": true, "NAPTR": true,
+}
+
+func normZoneName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func zoneDataName(name string) string {
+	return normZoneName(name) + "."
+}
+
+func zoneRecordTtlOf(useTtl *bool, ttl *uint32) *uint32 {
+	if useTtl != nil && *useTtl && ttl != nil {
+		return ttl
+	}
+	return nil
+}
+
+func quoteZoneString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func unquoteZoneString(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	return strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(s[1 : len(s)-1])
+}
+
+// quoteTXT renders the text of a TXT record; texts made of several
+// strings are kept in their quoted form.
+func quoteTXT(text string) string {
+	if strings.HasPrefix(text, `"`) {
+		return text
+	}
+	return quoteZoneString(text)
+}
+
+// getZoneFileRecords returns the records of the zone, host records being
+// expanded into their A, AAAA and CNAME (alias) records.
+func (objMgr *ObjectManager) getZoneFileRecords(view string, fqdn string) ([]ZoneFileRecord, error) {
+	sf := map[string]string{"zone": fqdn, "view": view}
+	var recs []ZoneFileRecord
+	add := func(name string, useTtl *bool, ttl *uint32, rrType string, data string, ref string) {
+		recs = append(recs, ZoneFileRecord{
+			Name: normZoneName(name), Ttl: zoneRecordTtlOf(useTtl, ttl), Type: rrType, Data: data, Ref: ref})
+	}
+
+	var aRecs []RecordA
+	if err := objMgr.getAllObjects(NewEmptyRecordA(), sf, &aRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range aRecs {
+		add(strValue(r.Name), r.UseTtl, r.Ttl, "A", strValue(r.Ipv4Addr), r.Ref)
+	}
+
+	var aaaaRecs []RecordAAAA
+	if err := objMgr.getAllObjects(NewEmptyRecordAAAA(), sf, &aaaaRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range aaaaRecs {
+		add(strValue(r.Name), r.UseTtl, r.Ttl, "AAAA", net.ParseIP(strValue(r.Ipv6Addr)).String(), r.Ref)
+	}
+
+	var cnameRecs []RecordCNAME
+	if err := objMgr.getAllObjects(NewEmptyRecordCNAME(), sf, &cnameRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range cnameRecs {
+		add(strValue(r.Name), r.UseTtl, r.Ttl, "CNAME", zoneDataName(strValue(r.Canonical)), r.Ref)
+	}
+
+	var mxRecs []RecordMX
+	if err := objMgr.getAllObjects(NewEmptyRecordMX(), sf, &mxRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range mxRecs {
+		add(strValue(r.Name), r.UseTtl, r.Ttl, "MX",
+			fmt.Sprintf("%d %s", uint32Value(r.Preference), zoneDataName(strValue(r.MailExchanger))), r.Ref)
+	}
+
+	var srvRecs []RecordSRV
+	if err := objMgr.getAllObjects(NewEmptyRecordSRV(), sf, &srvRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range srvRecs {
+		add(strValue(r.Name), r.UseTtl, r.Ttl, "SRV", fmt.Sprintf("%d %d %d %s",
+			uint32Value(r.Priority), uint32Value(r.Weight), uint32Value(r.Port), zoneDataName(strValue(r.Target))), r.Ref)
+	}
+
+	var txtRecs []RecordTXT
+	if err := objMgr.getAllObjects(NewEmptyRecordTXT(), sf, &txtRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range txtRecs {
+		add(strValue(r.Name), r.UseTtl, r.Ttl, "TXT", quoteTXT(strValue(r.Text)), r.Ref)
+	}
+
+	var ptrRecs []RecordPTR
+	if err := objMgr.getAllObjects(NewEmptyRecordPTR(), sf, &ptrRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range ptrRecs {
+		add(strValue(r.Name), r.UseTtl, r.Ttl, "PTR", zoneDataName(strValue(r.PtrdName)), r.Ref)
+	}
+
+	var nsRecs []RecordNS
+	if err := objMgr.getAllObjects(NewEmptyRecordNS(), sf, &nsRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range nsRecs {
+		add(r.Name, nil, nil, "NS", zoneDataName(strValue(r.Nameserver)), r.Ref)
+	}
+
+	var caaRecs []RecordCaa
+	if err := objMgr.getAllObjects(NewEmptyRecordCAA(), sf, &caaRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range caaRecs {
+		add(strValue(r.Name), r.UseTtl, r.Ttl, "CAA", fmt.Sprintf("%d %s %s",
+			uint32Value(r.CaFlag), strings.ToLower(strValue(r.CaTag)), quoteZoneString(strValue(r.CaValue))), r.Ref)
+	}
+
+	var naptrRecs []RecordNaptr
+	if err := objMgr.getAllObjects(NewEmptyRecordNAPTR(), sf, &naptrRecs); err != nil {
+		return nil, err
+	}
+	for _, r := range naptrRecs {
+		replacement := strValue(r.Replacement)
+		if replacement != "." {
+			replacement = zoneDataName(replacement)
+		}
+		add(strValue(r.Name), r.UseTtl, r.Ttl, "NAPTR", fmt.Sprintf("%d %d %s %s %s %s",
+			uint32Value(r.Order), uint32Value(r.Preference), quoteZoneString(strValue(r.Flags)),
+			quoteZoneString(strValue(r.Services)), quoteZoneString(strValue(r.Regexp)), replacement), r.Ref)
+	}
+
+	var hostRecs []HostRecord
+	if err := objMgr.getAllObjects(NewEmptyHostRecord(), sf, &hostRecs); err != nil {
+		return nil, err
+	}
+	for _, h := range hostRecs {
+		if h.EnableDns != nil && !*h.EnableDns {
+			continue
+		}
+		name := strValue(h.Name)
+		ttl := zoneRecordTtlOf(h.UseTtl, h.Ttl)
+		for _, addr := range h.Ipv4Addrs {
+			recs = append(recs, ZoneFileRecord{
+				Name: normZoneName(name), Ttl: ttl, Type: "A", Data: strValue(addr.Ipv4Addr), Host: h.Ref})
+		}
+		for _, addr := range h.Ipv6Addrs {
+			recs = append(recs, ZoneFileRecord{
+				Name: normZoneName(name), Ttl: ttl, Type: "AAAA", Data: net.ParseIP(strValue(addr.Ipv6Addr)).String(), Host: h.Ref})
+		}
+		for _, alias := range h.Aliases {
+			recs = append(recs, ZoneFileRecord{
+				Name: normZoneName(alias), Ttl: ttl, Type: "CNAME", Data: zoneDataName(name), Host: h.Ref})
+		}
+	}
+
+	return recs, nil
+}
+
+// zoneNameLess orders names as in a canonically ordered zone: by labels
+// compared from the right, so that the apex comes first.
+func zoneNameLess(a string, b string) bool {
+	la := strings.Split(a, ".")
+	lb := strings.Split(b, ".")
+	for i := 1; i <= len(la) && i <= len(lb); i++ {
+		if la[len(la)-i] != lb[len(lb)-i] {
+			return la[len(la)-i] < lb[len(lb)-i]
+		}
+	}
+	return len(la) < len(lb)
+}
+
+// newZoneFileZoneAuth returns the zone_auth object used to read the
+// settings rendered in the SOA and apex NS records of a zone file.
+func newZoneFileZoneAuth() *ZoneAuth {
+	zone := NewZoneAuth(ZoneAuth{})
+	zone.SetReturnFields(append(zone.ReturnFields(),
+		"ns_group", "grid_primary", "grid_secondaries", "external_primaries", "external_secondaries",
+		"soa_default_ttl", "soa_expire", "soa_negative_ttl", "soa_refresh", "soa_retry",
+		"soa_email", "dns_soa_email", "soa_serial_number", "member_soa_mnames", "member_soa_serials"))
+	return zone
+}
+
+func (objMgr *ObjectManager) getZoneFileZone(view string, fqdn string) (*ZoneAuth, error) {
+	var res []ZoneAuth
+	sf := map[string]string{
+		"fqdn": fqdn,
+		"view": view,
+	}
+	err := objMgr.connector.GetObject(newZoneFileZoneAuth(), "", NewQueryParams(false, sf), &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, NewNotFoundError(
+			fmt.Sprintf("authoritative zone '%s' not found in DNS view '%s'", fqdn, view))
+	}
+	return &res[0], nil
+}
+
+// zoneFileServers are the name servers of a zone, as rendered in its SOA
+// and apex NS records.
+type zoneFileServers struct {
+	primaries   []string // all the primaries, the first one being the SOA MNAME
+	nameServers []string // the servers which are not stealth
+}
+
+func (s *zoneFileServers) addMembers(members []*Memberserver, primary bool) {
+	for _, ms := range members {
+		if ms != nil && ms.Name != "" {
+			s.add(ms.Name, ms.Stealth, primary)
+		}
+	}
+}
+
+func (s *zoneFileServers) addExternal(servers []NameServer, primary bool) {
+	for _, ns := range servers {
+		if ns.Name != "" {
+			s.add(ns.Name, ns.Stealth, primary)
+		}
+	}
+}
+
+func (s *zoneFileServers) add(name string, stealth bool, primary bool) {
+	name = zoneDataName(name)
+	if primary {
+		s.primaries = append(s.primaries, name)
+	}
+	if !stealth {
+		s.nameServers = append(s.nameServers, name)
+	}
+}
+
+// getZoneFileServers returns the name servers of the zone, read from its
+// name server group if it has one.
+func (objMgr *ObjectManager) getZoneFileServers(zone *ZoneAuth) (*zoneFileServers, error) {
+	servers := &zoneFileServers{}
+	if nsGroup := strValue(zone.NsGroup); nsGroup != "" {
+		groups, err := objMgr.GetAllNsgroup(NewQueryParams(false, map[string]string{"name": nsGroup}))
+		if err != nil {
+			return nil, fmt.Errorf("failed getting the name server group '%s' of the zone: %s", nsGroup, err)
+		}
+		if len(groups) == 0 {
+			return nil, NewNotFoundError(fmt.Sprintf("name server group '%s' of the zone not found", nsGroup))
+		}
+		servers.addMembers(groups[0].GridPrimary, true)
+		servers.addExternal(groups[0].ExternalPrimaries, true)
+		servers.addMembers(groups[0].GridSecondaries, false)
+		servers.addExternal(groups[0].ExternalSecondaries, false)
+		return servers, nil
+	}
+	servers.addMembers(zone.GridPrimary, true)
+	servers.addExternal(zone.ExternalPrimaries, true)
+	servers.addMembers(zone.GridSecondaries, false)
+	servers.addExternal(zone.ExternalSecondaries, false)
+	return servers, nil
+}
+
+// zoneSoaPrimary returns the MNAME and the serial number of the SOA record
+// of the zone, as served by its primary.
+func zoneSoaPrimary(zone *ZoneAuth, servers *zoneFileServers) (string, uint32) {
+	primary := ""
+	if len(servers.primaries) > 0 {
+		primary = servers.primaries[0]
+	}
+	isPrimary := func(member string) bool {
+		return primary == "" || zoneDataName(member) == primary
+	}
+
+	mname := primary
+	if mname == "" && len(servers.nameServers) > 0 {
+		mname = servers.nameServers[0]
+	}
+	for _, m := range zone.MemberSoaMnames {
+		if m != nil && m.DnsMname != "" && isPrimary(m.GridPrimary) {
+			mname = zoneDataName(m.DnsMname)
+			break
+		}
+	}
+
+	// the serial number served by the primary takes precedence over the configured one
+	serial := uint32Value(zone.SoaSerialNumber)
+	for _, s := range zone.MemberSoaSerials {
+		if s != nil && s.Serial != 0 && isPrimary(s.GridPrimary) {
+			serial = s.Serial
+			break
+		}
+	}
+	return mname, serial
+}
+
+func writeZoneFile(w io.Writer, zone *ZoneAuth, servers *zoneFileServers, records []ZoneFileRecord) error {
+	origin := normZoneName(zone.Fqdn)
+	mname, serial := zoneSoaPrimary(zone, servers)
+	if mname == "" {
+		return fmt.Errorf("no primary name server is known for the zone '%s'", origin)
+	}
+
+	fmt.Fprintf(w, "$ORIGIN %s.\n", origin)
+	if zone.SoaDefaultTtl != nil {
+		fmt.Fprintf(w, "$TTL %d\n", *zone.SoaDefaultTtl)
+	}
+	email := zone.DnsSoaEmail
+	if email == "" {
+		email = strValue(zone.SoaEmail)
+	}
+	rname := "hostmaster." + origin + "."
+	if email != "" {
+		rname = zoneDataName(strings.Replace(email, "@", ".", 1))
+	}
+	fmt.Fprintf(w, "%s.\t\tIN\tSOA\t%s %s %d %d %d %d %d\n", origin, mname, rname,
+		serial, uint32Value(zone.SoaRefresh), uint32Value(zone.SoaRetry),
+		uint32Value(zone.SoaExpire), uint32Value(zone.SoaNegativeTtl))
+	for _, ns := range servers.nameServers {
+		fmt.Fprintf(w, "%s.\t\tIN\tNS\t%s\n", origin, ns)
+	}
+
+	sorted := append([]ZoneFileRecord{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return zoneNameLess(sorted[i].Name, sorted[j].Name)
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+	for _, r := range sorted {
+		fmt.Fprintln(w, r.String())
+	}
+	return nil
+}
+
+// ExportZone renders all the records of the authoritative zone as an RFC 1035 master file.
+func (objMgr *ObjectManager) ExportZone(view string, fqdn string) (io.Reader, error) {
+	if fqdn == "" {
+		return nil, fmt.Errorf("FQDN of the zone is required")
+	}
+	if view == "" {
+		view = "default"
+	}
+	zone, err := objMgr.getZoneFileZone(view, fqdn)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := objMgr.getZoneFileServers(zone)
+	if err != nil {
+		return nil, err
+	}
+	records, err := objMgr.getZoneFileRecords(view, zone.Fqdn)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = writeZoneFile(&buf, zone, servers, records); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// zoneFileTokens splits a zone file line into tokens, keeping quoted
+// strings (with their quotes) as single tokens and dropping comments.
+// It returns the change of the parentheses nesting level on the line.
+func zoneFileTokens(line string) ([]string, int, error) {
+	var tokens []string
+	var cur strings.Builder
+	depth := 0
+	inQuotes := false
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			cur.WriteByte(c)
+			cur.WriteByte(line[i+1])
+			i++
+		case c == '"':
+			cur.WriteByte(c)
+			if inQuotes {
+				flush()
+			}
+			inQuotes = !inQuotes
+		case inQuotes:
+			cur.WriteByte(c)
+		case c == ';':
+			flush()
+			return tokens, depth, nil
+		case c == '(' || c == ')':
+			flush()
+			if c == '(' {
+				depth++
+			} else {
+				depth--
+			}
+		case c == ' ' || c == '\t' || c == '\r':
+			flush()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if inQuotes {
+		return nil, 0, fmt.Errorf("unterminated quoted string")
+	}
+	flush()
+	return tokens, depth, nil
+}
+
+func parseZoneTtl(s string) (uint32, bool) {
+	if s == "" {
+		return 0, false
+	}
+	units := map[byte]uint64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var total, cur uint64
+	digits := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			cur = cur*10 + uint64(c-'0')
+			digits = true
+			continue
+		}
+		mult, ok := units[c|0x20]
+		if !ok || !digits {
+			return 0, false
+		}
+		total += cur * mult
+		cur = 0
+		digits = false
+	}
+	total += cur
+	if total > 0xffffffff {
+		return 0, false
+	}
+	return uint32(total), true
+}
+
+func absZoneName(name string, origin string) string {
+	if name == "@" {
+		return origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return normZoneName(name)
+	}
+	if origin == "" {
+		return normZoneName(name)
+	}
+	return normZoneName(name + "." + origin)
+}
+
+// normalizeZoneRdata returns the RDATA of the record in the form used by ZoneFileRecord.Data.
+func normalizeZoneRdata(rrType string, rdata []string, origin string) (string, error) {
+	wantFields := func(n int) error {
+		if len(rdata) != n {
+			return fmt.Errorf("%s record requires %d RDATA fields, got %d", rrType, n, len(rdata))
+		}
+		return nil
+	}
+	wantUint := func(s string, bits int) error {
+		if _, err := strconv.ParseUint(s, 10, bits); err != nil {
+			return fmt.Errorf("invalid %s record field '%s'", rrType, s)
+		}
+		return nil
+	}
+
+	switch rrType {
+	case "A", "AAAA":
+		if err := wantFields(1); err != nil {
+			return "", err
+		}
+		ip := net.ParseIP(rdata[0])
+		if ip == nil || (ip.To4() != nil) != (rrType == "A") {
+			return "", fmt.Errorf("invalid %s record address '%s'", rrType, rdata[0])
+		}
+		return ip.String(), nil
+	case "CNAME", "NS", "PTR", "DNAME":
+		if err := wantFields(1); err != nil {
+			return "", err
+		}
+		return absZoneName(rdata[0], origin) + ".", nil
+	case "MX":
+		if err := wantFields(2); err != nil {
+			return "", err
+		}
+		if err := wantUint(rdata[0], 16); err != nil {
+			return "", err
+		}
+		return rdata[0] + " " + absZoneName(rdata[1], origin) + ".", nil
+	case "SRV":
+		if err := wantFields(4); err != nil {
+			return "", err
+		}
+		for _, f := range rdata[:3] {
+			if err := wantUint(f, 16); err != nil {
+				return "", err
+			}
+		}
+		return strings.Join(rdata[:3], " ") + " " + absZoneName(rdata[3], origin) + ".", nil
+	case "TXT":
+		if len(rdata) == 0 {
+			return "", fmt.Errorf("TXT record requires at least one string")
+		}
+		if len(rdata) == 1 {
+			return quoteZoneString(unquoteZoneString(rdata[0])), nil
+		}
+		parts := make([]string, len(rdata))
+		for i, s := range rdata {
+			parts[i] = quoteZoneString(unquoteZoneString(s))
+		}
+		return strings.Join(parts, " "), nil
+	case "CAA":
+		if err := wantFields(3); err != nil {
+			return "", err
+		}
+		if err := wantUint(rdata[0], 8); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s %s", rdata[0], strings.ToLower(rdata[1]), quoteZoneString(unquoteZoneString(rdata[2]))), nil
+	case "NAPTR":
+		if err := wantFields(6); err != nil {
+			return "", err
+		}
+		for _, f := range rdata[:2] {
+			if err := wantUint(f, 16); err != nil {
+				return "", err
+			}
+		}
+		replacement := rdata[5]
+		if replacement != "." {
+			replacement = absZoneName(replacement, origin) + "."
+		}
+		return fmt.Sprintf("%s %s %s %s %s %s", rdata[0], rdata[1], quoteZoneString(unquoteZoneString(rdata[2])),
+			quoteZoneString(unquoteZoneString(rdata[3])), quoteZoneString(unquoteZoneString(rdata[4])), replacement), nil
+	}
+	return strings.Join(rdata, " "), nil
+}
+
+// parseZoneFile parses an RFC 1035 master file; relative names are
+// qualified with origin. Records without a TTL take the one set by the
+// last $TTL directive, unless it is zoneTtl, the default TTL of the zone,
+// in which case their TTL is left nil. $INCLUDE and $GENERATE are not
+// supported.
+func parseZoneFile(r io.Reader, origin string, zoneTtl *uint32) ([]ZoneFileRecord, error) {
+	origin = normZoneName(origin)
+	var records []ZoneFileRecord
+	var defaultTtl *uint32
+	var entry []string
+	var ownerOmitted bool
+	var lastOwner string
+	depth := 0
+	lineNo := 0
+
+	processEntry := func(tokens []string, ownerOmitted bool) error {
+		if strings.HasPrefix(tokens[0], "$") && !ownerOmitted {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return fmt.Errorf("$ORIGIN requires a domain name")
+				}
+				origin = absZoneName(tokens[1], origin)
+			case "$TTL":
+				if len(tokens) != 2 {
+					return fmt.Errorf("$TTL requires a TTL value")
+				}
+				ttl, ok := parseZoneTtl(tokens[1])
+				if !ok {
+					return fmt.Errorf("invalid TTL '%s'", tokens[1])
+				}
+				defaultTtl = &ttl
+				if sameZoneTtl(defaultTtl, zoneTtl) {
+					defaultTtl = nil
+				}
+			default:
+				return fmt.Errorf("unsupported directive '%s'", tokens[0])
+			}
+			return nil
+		}
+
+		rec := ZoneFileRecord{}
+		if ownerOmitted {
+			if lastOwner == "" {
+				return fmt.Errorf("record without an owner name")
+			}
+			rec.Name = lastOwner
+		} else {
+			rec.Name = absZoneName(tokens[0], origin)
+			tokens = tokens[1:]
+		}
+		lastOwner = rec.Name
+
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			if ttl, ok := parseZoneTtl(tokens[0]); ok && rec.Ttl == nil {
+				rec.Ttl = &ttl
+				tokens = tokens[1:]
+			} else if strings.EqualFold(tokens[0], "IN") {
+				tokens = tokens[1:]
+			} else if strings.EqualFold(tokens[0], "CH") || strings.EqualFold(tokens[0], "HS") {
+				return fmt.Errorf("unsupported class '%s'", tokens[0])
+			}
+		}
+		if len(tokens) == 0 {
+			return fmt.Errorf("record type is missing")
+		}
+		if rec.Ttl == nil {
+			rec.Ttl = defaultTtl
+		}
+		rec.Type = strings.ToUpper(tokens[0])
+		data, err := normalizeZoneRdata(rec.Type, tokens[1:], origin)
+		if err != nil {
+			return err
+		}
+		rec.Data = data
+		records = append(records, rec)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		tokens, d, err := zoneFileTokens(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNo, err)
+		}
+		if depth == 0 {
+			if len(tokens) == 0 {
+				continue
+			}
+			ownerOmitted = line[0] == ' ' || line[0] == '\t'
+		}
+		entry = append(entry, tokens...)
+		depth += d
+		if depth < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNo)
+		}
+		if depth > 0 {
+			continue
+		}
+		if err = processEntry(entry, ownerOmitted); err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNo, err)
+		}
+		entry = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses at the end of the zone file")
+	}
+	return records, nil
+}
+
+func sameZoneTtl(a *uint32, b *uint32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// diffZoneRecords compares the records of a zone with the records of a
+// zone file. The SOA and apex NS records are managed through the zone
+// settings and are ignored.
+func diffZoneRecords(zone string, existing []ZoneFileRecord, parsed []ZoneFileRecord) *ZoneDiff {
+	diff := &ZoneDiff{}
+	byKey := make(map[string]*ZoneFileRecord, len(existing))
+	for i := range existing {
+		if _, ok := byKey[existing[i].key()]; !ok {
+			byKey[existing[i].key()] = &existing[i]
+		}
+	}
+	seen := make(map[string]bool)
+
+	for _, rec := range parsed {
+		if rec.Type == "SOA" || (rec.Type == "NS" && rec.Name == zone) {
+			continue
+		}
+		cur, ok := byKey[rec.key()]
+		if ok {
+			seen[rec.key()] = true
+			switch {
+			case sameZoneTtl(cur.Ttl, rec.Ttl):
+				diff.Unchanged = append(diff.Unchanged, *cur)
+			case cur.Host != "":
+				rec.Host = cur.Host
+				diff.Skipped = append(diff.Skipped, rec)
+			default:
+				rec.Ref = cur.Ref
+				diff.Update = append(diff.Update, rec)
+			}
+			continue
+		}
+		if !zoneFileImportTypes[rec.Type] {
+			diff.Skipped = append(diff.Skipped, rec)
+			continue
+		}
+		diff.Create = append(diff.Create, rec)
+	}
+
+	for _, rec := range existing {
+		if seen[rec.key()] || rec.Ref == "" || rec.Host != "" {
+			continue
+		}
+		if rec.Type == "NS" && rec.Name == zone {
+			continue
+		}
+		diff.Delete = append(diff.Delete, rec)
+	}
+	return diff
+}
+
+func (objMgr *ObjectManager) createZoneFileRecord(view string, rec ZoneFileRecord) error {
+	var ttl uint32
+	useTtl := rec.Ttl != nil
+	if useTtl {
+		ttl = *rec.Ttl
+	}
+	fields, _, err := zoneFileTokens(rec.Data)
+	if err != nil {
+		return err
+	}
+	parseUint := func(s string) uint32 {
+		u, _ := strconv.ParseUint(s, 10, 32)
+		return uint32(u)
+	}
+
+	switch rec.Type {
+	case "A":
+		_, err = objMgr.CreateARecord("", view, rec.Name, "", rec.Data, ttl, useTtl, "", nil)
+	case "AAAA":
+		_, err = objMgr.CreateAAAARecord("", view, rec.Name, "", rec.Data, useTtl, ttl, "", nil)
+	case "CNAME":
+		_, err = objMgr.CreateCNAMERecord(view, normZoneName(rec.Data), rec.Name, useTtl, ttl, "", nil)
+	case "MX":
+		_, err = objMgr.CreateMXRecord(view, rec.Name, normZoneName(fields[1]), parseUint(fields[0]), ttl, useTtl, "", nil)
+	case "SRV":
+		_, err = objMgr.CreateSRVRecord(view, rec.Name, parseUint(fields[0]), parseUint(fields[1]), parseUint(fields[2]),
+			normZoneName(fields[3]), ttl, useTtl, "", nil)
+	case "TXT":
+		text := rec.Data
+		if len(fields) == 1 {
+			text = unquoteZoneString(fields[0])
+		}
+		_, err = objMgr.CreateTXTRecord(view, rec.Name, text, ttl, useTtl, "", nil)
+	case "PTR":
+		_, err = objMgr.CreatePTRRecord("", view, normZoneName(rec.Data), rec.Name, "", "", useTtl, ttl, "", nil)
+	case "CAA":
+		_, err = objMgr.CreateCAARecord(rec.Name, view, parseUint(fields[0]), fields[1], unquoteZoneString(fields[2]),
+			"", false, nil, ttl, useTtl)
+	case "NAPTR":
+		replacement := fields[5]
+		if replacement != "." {
+			replacement = normZoneName(replacement)
+		}
+		_, err = objMgr.CreateNAPTRRecord(rec.Name, view, parseUint(fields[0]), parseUint(fields[1]),
+			unquoteZoneString(fields[2]), unquoteZoneString(fields[3]), unquoteZoneString(fields[4]), replacement,
+			"", false, nil, ttl, useTtl)
+	default:
+		err = fmt.Errorf("import of %s records is not supported", rec.Type)
+	}
+	return err
+}
+
+// ImportZone parses a zone file and reconciles the records of the
+// authoritative zone with it: missing records are created, the TTLs of
+// existing ones are updated and, when pruning, records which are not in
+// the file are deleted. Records derived from host records are never
+// modified. The returned diff lists the changes, which are not applied
+// in dry-run mode.
+func (objMgr *ObjectManager) ImportZone(view string, fqdn string, r io.Reader, opts ZoneImportOptions) (*ZoneDiff, error) {
+	if fqdn == "" {
+		return nil, fmt.Errorf("FQDN of the zone is required")
+	}
+	if view == "" {
+		view = "default"
+	}
+	zone := normZoneName(fqdn)
+	zoneAuth, err := objMgr.getZoneFileZone(view, zone)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseZoneFile(r, zone, zoneAuth.SoaDefaultTtl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the zone file: %s", err)
+	}
+	existing, err := objMgr.getZoneFileRecords(view, zone)
+	if err != nil {
+		return nil, err
+	}
+	diff := diffZoneRecords(zone, existing, parsed)
+	if opts.DryRun {
+		return diff, nil
+	}
+
+	for _, rec := range diff.Create {
+		if err = objMgr.createZoneFileRecord(view, rec); err != nil {
+			return diff, fmt.Errorf("failed to create record '%s': %s", rec, err)
+		}
+	}
+	for _, rec := range diff.Update {
+		useTtl := rec.Ttl != nil
+		upd := &zoneRecordTtl{objectType: strings.SplitN(rec.Ref, "/", 2)[0], Ttl: rec.Ttl, UseTtl: &useTtl}
+		if _, err = objMgr.connector.UpdateObject(upd, rec.Ref); err != nil {
+			return diff, fmt.Errorf("failed to update record '%s': %s", rec, err)
+		}
+	}
+	if opts.Prune {
+		for _, rec := range diff.Delete {
+			if _, err = objMgr.connector.DeleteObject(rec.Ref); err != nil {
+				return diff, fmt.Errorf("failed to delete record '%s': %s", rec, err)
+			}
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,192 @@
+package ibclient
+
+import (
+	"io"
+	"strings"
+
+	"github.com/infobloxopen/infoblox-go-client/v2/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager: zone file", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	fqdn := "example.com"
+	view := "default"
+	zoneRef := "zone_auth/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxl:example.com/default"
+	aRef := "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUsd3d3LDEwLjAuMC4x:www.example.com/default"
+	mxRef := "record:mx/ZG5zLmJpbmRfbXgkLl9kZWZhdWx0LmNvbS5leGFtcGxlLm1haWw:example.com/default"
+	txtRef := "record:txt/ZG5zLmJpbmRfdHh0JC5fZGVmYXVsdC5jb20uZXhhbXBsZS5vbGQ:old.example.com/default"
+	hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLmRi:db.example.com/default"
+
+	ttl := uint32(300)
+	refresh := uint32(10800)
+	retry := uint32(3600)
+	expire := uint32(2419200)
+	negTtl := uint32(900)
+	defaultTtl := uint32(28800)
+	serial := uint32(7)
+	useTtl := true
+
+	newConnector := func() *fakeConnector {
+		return &fakeConnector{
+			getObjectObj: map[string]interface{}{"ZoneAuth": newZoneFileZoneAuth()},
+			resultObject: map[string]interface{}{
+				"ZoneAuth": []ZoneAuth{{
+					Ref:             zoneRef,
+					Fqdn:            fqdn,
+					GridPrimary:     []*Memberserver{{Name: "ns1.example.com"}},
+					DnsSoaEmail:     "hostmaster@example.com",
+					SoaSerialNumber: &serial,
+					SoaRefresh:      &refresh,
+					SoaRetry:        &retry,
+					SoaExpire:       &expire,
+					SoaNegativeTtl:  &negTtl,
+					SoaDefaultTtl:   &defaultTtl,
+					MemberSoaSerials: []*GridmemberSoaserial{
+						{GridPrimary: "ns0.example.com", Serial: 3},
+						{GridPrimary: "ns1.example.com", Serial: 9},
+					},
+				}},
+				"RecordA": []RecordA{{
+					Ref: aRef, Name: utils.StringPtr("www.example.com"), Ipv4Addr: utils.StringPtr("10.0.0.1"), UseTtl: &useTtl, Ttl: &ttl}},
+				"RecordMX": []RecordMX{{
+					Ref: mxRef, Name: utils.StringPtr("example.com"), MailExchanger: utils.StringPtr("mail.example.com"), Preference: utils.Uint32Ptr(10)}},
+				"RecordTXT": []RecordTXT{{
+					Ref: txtRef, Name: utils.StringPtr("old.example.com"), Text: utils.StringPtr("v=spf1 -all")}},
+				"HostRecord": []HostRecord{{
+					Ref:       hostRef,
+					Name:      utils.StringPtr("db.example.com"),
+					Ipv4Addrs: []HostRecordIpv4Addr{{Ipv4Addr: utils.StringPtr("10.0.0.5")}},
+					Aliases:   []string{"sql.example.com"},
+				}},
+			},
+		}
+	}
+
+	Describe("Export zone", func() {
+		objMgr := NewObjectManager(newConnector(), cmpType, tenantID)
+
+		It("should render every record of the zone", func() {
+			r, err := objMgr.ExportZone(view, fqdn)
+			Expect(err).To(BeNil())
+			out, err := io.ReadAll(r)
+			Expect(err).To(BeNil())
+			Expect(string(out)).To(Equal(`$ORIGIN example.com.
+$TTL 28800
+example.com.		IN	SOA	ns1.example.com. hostmaster.example.com. 9 10800 3600 2419200 900
+example.com.		IN	NS	ns1.example.com.
+example.com.		IN	MX	10 mail.example.com.
+db.example.com.		IN	A	10.0.0.5
+old.example.com.		IN	TXT	"v=spf1 -all"
+sql.example.com.		IN	CNAME	db.example.com.
+www.example.com.	300	IN	A	10.0.0.1
+`))
+		})
+
+		It("should read the name servers from the name server group of the zone", func() {
+			conn := &fakeConnector{
+				getObjectObj: map[string]interface{}{"ZoneAuth": newZoneFileZoneAuth()},
+				resultObject: map[string]interface{}{
+					"ZoneAuth": []ZoneAuth{{
+						Ref:             zoneRef,
+						Fqdn:            fqdn,
+						NsGroup:         utils.StringPtr("example-ns"),
+						SoaSerialNumber: &serial,
+					}},
+					"Nsgroup": []Nsgroup{{
+						GridPrimary:         []*Memberserver{{Name: "gm.example.com", Stealth: true}},
+						GridSecondaries:     []*Memberserver{{Name: "ns1.example.com"}},
+						ExternalSecondaries: []NameServer{{Name: "ns2.example.net", Address: "192.0.2.2"}},
+					}},
+				},
+			}
+			r, err := NewObjectManager(conn, cmpType, tenantID).ExportZone(view, fqdn)
+			Expect(err).To(BeNil())
+			out, err := io.ReadAll(r)
+			Expect(err).To(BeNil())
+			Expect(string(out)).To(Equal(`$ORIGIN example.com.
+example.com.		IN	SOA	gm.example.com. hostmaster.example.com. 7 0 0 0 0
+example.com.		IN	NS	ns1.example.com.
+example.com.		IN	NS	ns2.example.net.
+`))
+		})
+
+		It("should fail without any name server", func() {
+			conn := &fakeConnector{
+				getObjectObj: map[string]interface{}{"ZoneAuth": newZoneFileZoneAuth()},
+				resultObject: map[string]interface{}{"ZoneAuth": []ZoneAuth{{Ref: zoneRef, Fqdn: fqdn}}},
+			}
+			_, err := NewObjectManager(conn, cmpType, tenantID).ExportZone(view, fqdn)
+			Expect(err).To(MatchError("no primary name server is known for the zone 'example.com'"))
+		})
+	})
+
+	Describe("Parse zone file", func() {
+		It("should handle directives, relative names, omitted owners and parentheses", func() {
+			records, err := parseZoneFile(strings.NewReader(`$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1 hostmaster ( 7 ; serial
+		10800 3600 2419200 900 )
+www	300	IN	A	10.0.0.1
+	IN	AAAA	2001:DB8::0:1
+_sip._tcp	SRV	0 5 5060 sip
+txt	TXT	"hello \"world\"" "again"
+`), "example.com", nil)
+			Expect(err).To(BeNil())
+			Expect(records).To(HaveLen(5))
+			Expect(records[1]).To(Equal(ZoneFileRecord{Name: "www.example.com", Ttl: &ttl, Type: "A", Data: "10.0.0.1"}))
+			hour := uint32(3600)
+			Expect(records[2]).To(Equal(ZoneFileRecord{Name: "www.example.com", Ttl: &hour, Type: "AAAA", Data: "2001:db8::1"}))
+			Expect(records[3].Data).To(Equal("0 5 5060 sip.example.com."))
+			Expect(records[4].Data).To(Equal(`"hello \"world\"" "again"`))
+		})
+
+		It("should leave the TTL unset when the file uses the default TTL of the zone", func() {
+			records, err := parseZoneFile(strings.NewReader("$TTL 8h\nwww IN A 10.0.0.1\n"), "example.com", &defaultTtl)
+			Expect(err).To(BeNil())
+			Expect(records).To(Equal([]ZoneFileRecord{{Name: "www.example.com", Type: "A", Data: "10.0.0.1"}}))
+		})
+
+		It("should report the line of an invalid record", func() {
+			_, err := parseZoneFile(strings.NewReader("www IN A 10.0.0.1\nmail IN MX mail\n"), "example.com", nil)
+			Expect(err).To(MatchError("line 2: MX record requires 2 RDATA fields, got 1"))
+		})
+	})
+
+	Describe("Import zone in dry-run mode", func() {
+		objMgr := NewObjectManager(newConnector(), cmpType, tenantID)
+
+		It("should compute the changes without applying them", func() {
+			diff, err := objMgr.ImportZone(view, fqdn, strings.NewReader(`$ORIGIN example.com.
+@	IN	NS	ns1
+@	IN	MX	10 mail
+www	600	IN	A	10.0.0.1
+db	IN	A	10.0.0.5
+ftp	IN	A	10.0.0.9
+@	IN	HINFO	"PC" "Linux"
+`), ZoneImportOptions{DryRun: true})
+			Expect(err).To(BeNil())
+			newTtl := uint32(600)
+			Expect(diff.Create).To(Equal([]ZoneFileRecord{{Name: "ftp.example.com", Type: "A", Data: "10.0.0.9"}}))
+			Expect(diff.Update).To(Equal([]ZoneFileRecord{{Name: "www.example.com", Ttl: &newTtl, Type: "A", Data: "10.0.0.1", Ref: aRef}}))
+			Expect(diff.Delete).To(Equal([]ZoneFileRecord{{Name: "old.example.com", Type: "TXT", Data: `"v=spf1 -all"`, Ref: txtRef}}))
+			Expect(diff.Skipped).To(Equal([]ZoneFileRecord{{Name: "example.com", Type: "HINFO", Data: `"PC" "Linux"`}}))
+			Expect(diff.Unchanged).To(HaveLen(2))
+			Expect(diff.String()).To(ContainSubstring("+ ftp.example.com.\t\tIN\tA\t10.0.0.9\n"))
+		})
+
+		It("should apply the default TTL of the file to the records without one", func() {
+			diff, err := objMgr.ImportZone(view, fqdn, strings.NewReader(`$ORIGIN example.com.
+$TTL 300
+www	IN	A	10.0.0.1
+ftp	IN	A	10.0.0.9
+`), ZoneImportOptions{DryRun: true})
+			Expect(err).To(BeNil())
+			Expect(diff.Create).To(Equal([]ZoneFileRecord{{Name: "ftp.example.com", Ttl: &ttl, Type: "A", Data: "10.0.0.9"}}))
+			Expect(diff.Update).To(BeEmpty())
+			Expect(diff.Unchanged).To(HaveLen(1))
+		})
+	})
+})
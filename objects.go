@@ -387,6 +387,9 @@ type FixedAddress struct {
 	IPv4Address                 string            `json:"ipv4addr,omitempty"`
 	IPv6Address                 string            `json:"ipv6addr,omitempty"`
 	Duid                        string            `json:"duid,omitempty"`
+	AddressType                 string            `json:"address_type,omitempty"`
+	Ipv6Prefix                  string            `json:"ipv6prefix,omitempty"`
+	Ipv6PrefixBits              *uint32           `json:"ipv6prefix_bits,omitempty"`
 	Mac                         *string           `json:"mac,omitempty"`
 	Name                        *string           `json:"name,omitempty"`
 	MatchClient                 *string           `json:"match_client,omitempty"`
@@ -410,7 +413,7 @@ func NewEmptyFixedAddress(isIPv6 bool) *FixedAddress {
 	res.Ea = make(EA)
 	if isIPv6 {
 		res.objectType = "ipv6fixedaddress"
-		res.returnFields = []string{"extattrs", "ipv6addr", "duid", "name", "network", "network_view", "comment"}
+		res.returnFields = []string{"extattrs", "ipv6addr", "duid", "name", "network", "network_view", "comment", "address_type", "ipv6prefix", "ipv6prefix_bits"}
 	} else {
 		res.objectType = "fixedaddress"
 		res.returnFields = []string{"extattrs", "ipv4addr", "mac", "name", "network", "network_view", "comment", "match_client", "agent_circuit_id", "agent_remote_id", "client_identifier_prepend_zero", "options", "use_options", "cloud_info", "disable", "dhcp_client_identifier"}
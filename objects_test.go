@@ -340,7 +340,7 @@ var _ = Describe("Objects", func() {
 
 			It("should set base fields correctly", func() {
 				Expect(fixedAddr.ObjectType()).To(Equal("ipv6fixedaddress"))
-				Expect(fixedAddr.ReturnFields()).To(ConsistOf("extattrs", "ipv6addr", "duid", "name", "network", "network_view", "comment"))
+				Expect(fixedAddr.ReturnFields()).To(ConsistOf("extattrs", "ipv6addr", "duid", "name", "network", "network_view", "comment", "address_type", "ipv6prefix", "ipv6prefix_bits"))
 			})
 		})
 
@@ -24,6 +24,22 @@ func NewNotFoundError(msg string) *NotFoundError {
 	return &NotFoundError{msg: msg}
 }
 
+// ConflictError is returned by guarded updates when the object on the grid
+// no longer matches the copy the caller read before updating it.
+type ConflictError struct {
+	Ref    string
+	Fields []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("object '%s' was modified concurrently, changed fields: %s",
+		e.Ref, strings.Join(e.Fields, ", "))
+}
+
+func NewConflictError(ref string, fields []string) *ConflictError {
+	return &ConflictError{Ref: ref, Fields: fields}
+}
+
 type GenericObj interface {
 	ObjectType() string
 	ReturnFields() []string